@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/blogs"
+	"github.com/Dostonlv/task-del/internal/news"
+	"github.com/Dostonlv/task-del/pkg/db/postgres"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// commandTimeout upper bound of single CLI command
+const commandTimeout = 30 * time.Minute
+
+// app use cases wired the same way as in API server, changes go through the outbox as well
+type app struct {
+	db      *sqlx.DB
+	blogsUC blogs.UseCase
+	newsUC  news.UseCase
+}
+
+func newApp(cfg *config.Config, appLogger logger.Logger) (*app, error) {
+	db, err := postgres.NewPsqlDB(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "Postgresql init")
+	}
+
+	return &app{
+		db:      db,
+		blogsUC: blogs.NewUseCase(cfg, blogs.NewRepository(db), appLogger),
+		newsUC:  news.NewUseCase(cfg, news.NewRepository(db), appLogger),
+	}, nil
+}
+
+func (a *app) Close() error {
+	if a.db == nil {
+		return nil
+	}
+	return a.db.Close()
+}
+
+func commandContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), commandTimeout)
+}
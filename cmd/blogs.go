@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const blogsUsage = `usage: blogs <command>
+  list [-page N] [-size N] [-title TITLE]
+  get ID
+  delete ID
+` + transferUsage
+
+// runBlogs blogs subcommand
+func (c *cli) runBlogs(args []string) error {
+	if len(args) == 0 {
+		return usageError(blogsUsage)
+	}
+	command, args := args[0], args[1:]
+
+	var (
+		blogID uuid.UUID
+		query  *utils.PaginationQuery
+		title  string
+	)
+	switch command {
+	case "list":
+		flags := flag.NewFlagSet("blogs list", flag.ContinueOnError)
+		flags.SetOutput(c.stderr)
+		page := flags.Int("page", 1, "page number")
+		size := flags.Int("size", 10, "number of blogs per page")
+		flags.StringVar(&title, "title", "", "search by title")
+		if err := parseFlags(flags, args); err != nil {
+			return err
+		}
+		query = utils.NewPaginationQuery(*page, *size, "")
+	case "get", "delete":
+		if len(args) != 1 {
+			return usageError(blogsUsage)
+		}
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			return errors.Wrap(err, "blog ID")
+		}
+		blogID = id
+	case "export", "import":
+		return c.runTransfer("blogs", command, args, func(a *app) transferUseCase { return a.blogsUC })
+	default:
+		return usageError(blogsUsage)
+	}
+
+	a, err := c.newApp()
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	switch command {
+	case "list":
+		blogsList, err := a.blogsUC.GetAll(ctx, title, query)
+		if err != nil {
+			return err
+		}
+		return c.printJSON(blogsList)
+	case "get":
+		blog, err := a.blogsUC.GetByID(ctx, blogID)
+		if err != nil {
+			return err
+		}
+		return c.printJSON(blog)
+	default:
+		if err := a.blogsUC.Delete(ctx, blogID); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.stdout, "blog %s deleted\n", blogID)
+		return nil
+	}
+}
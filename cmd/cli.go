@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/pkg/logger"
+)
+
+const usage = `usage: task-del [--config PATH] [command]
+  --config PATH             config file, APP_CONFIG or ./config/config-local.yml by default,
+                            any field is overridden by APP_<SECTION>_<FIELD> variable, e.g. APP_SERVER_PORT
+  serve                     run API server, default command, config is reloaded on change or SIGHUP
+  migrate <command>         manage database schema, run "migrate" for commands
+  blogs list [flags]        list blogs, -page, -size, -title
+  blogs get ID              print blog
+  blogs delete ID           delete blog
+  blogs export [flags]      write blogs as JSON lines or CSV, run "blogs" for flags
+  blogs import [flags]      create or update blogs by ID, sanitized and validated as in the API
+  news export [flags]       write news as JSON lines or CSV, run "news" for flags
+  news import [flags]       create or update news by ID, sanitized and validated as in the API`
+
+// run dispatch subcommand, commands share config, use cases and validation with the API server
+func run(cfg *config.Config, configPath string, appLogger logger.Logger, args []string) error {
+	if len(args) == 0 {
+		return serve(cfg, configPath, appLogger)
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "serve":
+		return serve(cfg, configPath, appLogger)
+	case "migrate":
+		return runMigrate(cfg, appLogger, args)
+	case "blogs":
+		return newCLI(cfg, appLogger).runBlogs(args)
+	case "news":
+		return newCLI(cfg, appLogger).runNews(args)
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+		return nil
+	default:
+		return usageError(fmt.Sprintf("unknown command %q\n%s", command, usage))
+	}
+}
+
+// cli standard streams and app of data commands, tests replace them
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	newApp func() (*app, error)
+}
+
+func newCLI(cfg *config.Config, appLogger logger.Logger) *cli {
+	return &cli{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		newApp: func() (*app, error) {
+			return newApp(cfg, appLogger)
+		},
+	}
+}
+
+// printJSON write value to stdout as indented JSON
+func (c *cli) printJSON(v interface{}) error {
+	encoder := json.NewEncoder(c.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// configFlag take leading --config PATH or --config=PATH off command line args
+func configFlag(args []string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", args, nil
+	}
+	switch arg := args[0]; {
+	case arg == "--config" || arg == "-config":
+		if len(args) < 2 || args[1] == "" {
+			return "", nil, usageError("--config requires a path")
+		}
+		return args[1], args[2:], nil
+	case strings.HasPrefix(arg, "--config="), strings.HasPrefix(arg, "-config="):
+		path := arg[strings.Index(arg, "=")+1:]
+		if path == "" {
+			return "", nil, usageError("--config requires a path")
+		}
+		return path, args[1:], nil
+	}
+	return "", args, nil
+}
+
+// usageError invalid command line, printed as is instead of logged
+type usageError string
+
+func (e usageError) Error() string {
+	return string(e)
+}
+
+// parseFlags flag package already printed the problem together with flags usage
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return usageError("")
+	}
+	if flags.NArg() > 0 {
+		return usageError(fmt.Sprintf("unexpected arguments %v", flags.Args()))
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dostonlv/task-del/internal/content/mock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testCLI struct {
+	*cli
+	stdout  *bytes.Buffer
+	stderr  *bytes.Buffer
+	blogsUC *mock.MockUseCase[models.Blog]
+	newsUC  *mock.MockUseCase[models.New]
+	opened  int
+}
+
+// newTestCLI cli with mocked use cases, stdin reads given input
+func newTestCLI(t *testing.T, stdin string) *testCLI {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	tc := &testCLI{
+		stdout:  &bytes.Buffer{},
+		stderr:  &bytes.Buffer{},
+		blogsUC: mock.NewMockUseCase[models.Blog](ctrl),
+		newsUC:  mock.NewMockUseCase[models.New](ctrl),
+	}
+	tc.cli = &cli{
+		stdin:  strings.NewReader(stdin),
+		stdout: tc.stdout,
+		stderr: tc.stderr,
+		newApp: func() (*app, error) {
+			tc.opened++
+			return &app{blogsUC: tc.blogsUC, newsUC: tc.newsUC}, nil
+		},
+	}
+	return tc
+}
+
+func requireUsageError(t *testing.T, err error) {
+	t.Helper()
+
+	var usageErr usageError
+	require.ErrorAs(t, err, &usageErr)
+}
+
+func TestCLI_Blogs(t *testing.T) {
+	t.Parallel()
+
+	blogID := uuid.New()
+
+	// flags are parsed into title and pagination query, list is printed as JSON
+	t.Run("list", func(t *testing.T) {
+		t.Parallel()
+
+		tc := newTestCLI(t, "")
+		tc.blogsUC.EXPECT().GetAll(gomock.Any(), "go", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, query *utils.PaginationQuery) (*models.BlogsList, error) {
+				return &models.BlogsList{TotalCount: 6, Page: query.GetPage(), Size: query.GetSize(), Items: []*models.Blog{
+					{Entry: models.Entry{ID: blogID, Title: "go"}},
+				}}, nil
+			})
+
+		require.NoError(t, tc.runBlogs([]string{"list", "-page", "2", "-size", "5", "-title", "go"}))
+		require.Contains(t, tc.stdout.String(), `"page": 2`)
+		require.Contains(t, tc.stdout.String(), `"size": 5`)
+		require.Contains(t, tc.stdout.String(), blogID.String())
+	})
+
+	t.Run("get", func(t *testing.T) {
+		t.Parallel()
+
+		tc := newTestCLI(t, "")
+		tc.blogsUC.EXPECT().GetByID(gomock.Any(), blogID).Return(&models.Blog{Entry: models.Entry{ID: blogID, Title: "test-title"}}, nil)
+
+		require.NoError(t, tc.runBlogs([]string{"get", blogID.String()}))
+		require.Contains(t, tc.stdout.String(), `"title": "test-title"`)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+
+		tc := newTestCLI(t, "")
+		tc.blogsUC.EXPECT().Delete(gomock.Any(), blogID).Return(nil)
+
+		require.NoError(t, tc.runBlogs([]string{"delete", blogID.String()}))
+		require.Equal(t, "blog "+blogID.String()+" deleted\n", tc.stdout.String())
+	})
+
+	// invalid command lines fail before app is opened
+	tests := []struct {
+		name  string
+		args  []string
+		usage bool
+	}{
+		{name: "no command", args: nil, usage: true},
+		{name: "unknown command", args: []string{"update"}, usage: true},
+		{name: "unknown flag", args: []string{"list", "-limit", "5"}, usage: true},
+		{name: "invalid page", args: []string{"list", "-page", "two"}, usage: true},
+		{name: "extra argument", args: []string{"list", "go"}, usage: true},
+		{name: "get without ID", args: []string{"get"}, usage: true},
+		{name: "invalid ID", args: []string{"delete", "42"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc := newTestCLI(t, "")
+			err := tc.runBlogs(tt.args)
+			require.Error(t, err)
+			if tt.usage {
+				requireUsageError(t, err)
+			}
+			require.Zero(t, tc.opened)
+		})
+	}
+}
+
+func TestCLI_Export(t *testing.T) {
+	t.Parallel()
+
+	// filter and format flags reach use case, records go to stdout and count to stderr
+	t.Run("blogs export", func(t *testing.T) {
+		t.Parallel()
+
+		tc := newTestCLI(t, "")
+		tc.blogsUC.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any(), transfer.FormatCSV).DoAndReturn(
+			func(_ context.Context, filter *models.ExportFilter, w io.Writer, _ transfer.Format) (int, error) {
+				require.Equal(t, "go", filter.Title)
+				require.Equal(t, []string{"go", "rust"}, filter.Tags)
+				require.NotNil(t, filter.From)
+				_, err := io.WriteString(w, "id,title\n")
+				return 2, err
+			})
+
+		require.NoError(t, tc.runBlogs([]string{"export", "-format", "csv", "-title", "go", "-tags", "go,rust", "-from", "2024-01-01"}))
+		require.Equal(t, "id,title\n", tc.stdout.String())
+		require.Equal(t, "exported 2 blogs\n", tc.stderr.String())
+	})
+
+	t.Run("news export to file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "news.ndjson")
+		tc := newTestCLI(t, "")
+		tc.newsUC.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any(), transfer.FormatNDJSON).DoAndReturn(
+			func(_ context.Context, _ *models.ExportFilter, w io.Writer, _ transfer.Format) (int, error) {
+				_, err := io.WriteString(w, "{}\n")
+				return 1, err
+			})
+
+		require.NoError(t, tc.runNews([]string{"export", "-o", path}))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, "{}\n", string(data))
+		require.Empty(t, tc.stdout.String())
+		require.Equal(t, "exported 1 news\n", tc.stderr.String())
+	})
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown format", args: []string{"export", "-format", "xml"}},
+		{name: "invalid date", args: []string{"export", "-from", "yesterday"}},
+		{name: "import flag", args: []string{"export", "-dry-run"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc := newTestCLI(t, "")
+			requireUsageError(t, tc.runNews(tt.args))
+			require.Zero(t, tc.opened)
+		})
+	}
+}
+
+func TestCLI_Import(t *testing.T) {
+	t.Parallel()
+
+	// records are read from stdin, result is printed as JSON
+	t.Run("news import dry run", func(t *testing.T) {
+		t.Parallel()
+
+		tc := newTestCLI(t, `{"title":"test-title"}`+"\n")
+		tc.newsUC.EXPECT().Import(gomock.Any(), gomock.Any(), transfer.FormatNDJSON, true).DoAndReturn(
+			func(_ context.Context, r io.Reader, _ transfer.Format, dryRun bool) (*models.ImportResult, error) {
+				data, err := io.ReadAll(r)
+				require.NoError(t, err)
+				require.Equal(t, `{"title":"test-title"}`+"\n", string(data))
+				return &models.ImportResult{DryRun: dryRun, Created: 1}, nil
+			})
+
+		require.NoError(t, tc.runNews([]string{"import", "-dry-run"}))
+		require.Contains(t, tc.stdout.String(), `"dry_run": true`)
+		require.Contains(t, tc.stdout.String(), `"created": 1`)
+	})
+
+	// failed records are printed and fail the command
+	t.Run("blogs import from file", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "blogs.csv")
+		require.NoError(t, os.WriteFile(path, []byte("id,title\n"), 0o600))
+
+		tc := newTestCLI(t, "")
+		tc.blogsUC.EXPECT().Import(gomock.Any(), gomock.Any(), transfer.FormatCSV, false).
+			Return(&models.ImportResult{Created: 1, Failed: 2}, nil)
+
+		err := tc.runBlogs([]string{"import", "-f", path, "-format", "csv"})
+		require.EqualError(t, err, "blogs import: 2 records failed")
+		require.Contains(t, tc.stdout.String(), `"failed": 2`)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		tc := newTestCLI(t, "")
+		err := tc.runBlogs([]string{"import", "-f", filepath.Join(t.TempDir(), "missing.ndjson")})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "blogs import")
+	})
+
+	t.Run("unknown news command", func(t *testing.T) {
+		t.Parallel()
+
+		tc := newTestCLI(t, "")
+		requireUsageError(t, tc.runNews([]string{"list"}))
+		require.Zero(t, tc.opened)
+	})
+}
@@ -1,11 +1,10 @@
 package main
 
 import (
+	"fmt"
 	"github.com/Dostonlv/task-del/config"
-	"github.com/Dostonlv/task-del/internal/server"
-	"github.com/Dostonlv/task-del/pkg/db/postgres"
 	"github.com/Dostonlv/task-del/pkg/logger"
-	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/pkg/errors"
 	"log"
 	"os"
 )
@@ -13,41 +12,40 @@ import (
 // @title Blog and News API.
 // @version 1.0
 // @description Blog and News API Server.
+// @description Errors are returned as RestError, clients sending Accept: application/problem+json get RFC 7807 problem details.
+// @description Failed validation lists every failed rule in fields, messages follow Accept-Language.
+// @description /v1 is frozen, /v2 wraps every response in a {data, meta, links} envelope, answers delete with 204 and create with Location.
 // @contact.name Doston Nematov (kei)
 // @contact.url  https://github.com/Dostonlv
 // @contact.telegram https://t.me/dostonlv
 // @contact.email dostonlv@icloud.com
-// @BasePath /v1
+// @BasePath /
 func main() {
-	log.Println("Starting api server")
-
-	configPath := utils.GetConfigPath(os.Getenv("config"))
-
-	cfgFile, err := config.LoadConfig(configPath)
+	configPath, args, err := configFlag(os.Args[1:])
 	if err != nil {
-		log.Fatalf("LoadConfig: %v", err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
 	}
 
-	cfg, err := config.ParseConfig(cfgFile)
+	configPath = config.Path(configPath)
+	cfg, err := config.Load(configPath)
 	if err != nil {
-		log.Fatalf("ParseConfig: %v", err)
+		log.Fatalf("Load config: %v", err)
 	}
 
 	appLogger := logger.NewApiLogger(cfg)
 
 	appLogger.InitLogger()
-	appLogger.Infof("AppVersion: %s, LogLevel: %s, Mode: %s", cfg.Server.AppVersion, cfg.Logger.Level, cfg.Server.Mode)
-
-	psqlDB, err := postgres.NewPsqlDB(cfg)
-	if err != nil {
-		appLogger.Fatalf("Postgresql init: %s", err)
-	} else {
-		appLogger.Infof("Postgres connected, Status: %#v", psqlDB.Stats())
-	}
-	defer psqlDB.Close()
 
-	s := server.NewServer(cfg, psqlDB, appLogger)
-	if err = s.Run(); err != nil {
-		log.Fatal(err)
+	if err = run(cfg, configPath, appLogger, args); err != nil {
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			if usageErr != "" {
+				fmt.Fprintln(os.Stderr, usageErr)
+			}
+			os.Exit(2)
+		}
+		appLogger.Errorf("%s", err)
+		os.Exit(1)
 	}
 }
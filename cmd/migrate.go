@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/migrations"
+	"github.com/Dostonlv/task-del/pkg/db/postgres"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/migrate"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// migrateTimeout includes waiting for advisory lock held by another instance
+const migrateTimeout = 5 * time.Minute
+
+const migrateUsage = `usage: migrate <command>
+  up [N]        apply all or N pending migrations
+  down [N]      revert N applied migrations, 1 by default
+  status        show applied version and pending migrations
+  force V       set version V without running migrations and clear dirty flag
+  create NAME   create empty up and down migration files in Postgres.MigrationsPath`
+
+// runMigrate migrate subcommand, embedded migrations are applied to database from config
+func runMigrate(cfg *config.Config, appLogger logger.Logger, args []string) error {
+	command, n, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if command == "create" {
+		paths, err := migrate.Create(cfg.Postgres.MigrationsPath, args[1])
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			fmt.Println(path)
+		}
+		return nil
+	}
+
+	db, err := postgres.NewPsqlDB(cfg)
+	if err != nil {
+		return errors.Wrap(err, "Postgresql init")
+	}
+	defer db.Close()
+
+	migrator, err := migrate.NewMigrator(db, migrations.FS, appLogger)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	return execMigrate(ctx, migrator, os.Stdout, command, n)
+}
+
+// parseMigrateArgs validate command line before connecting to database, n is the optional or required number argument
+func parseMigrateArgs(args []string) (string, int64, error) {
+	if len(args) == 0 {
+		return "", 0, usageError(migrateUsage)
+	}
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "create":
+		if len(args) != 1 {
+			return "", 0, usageError(migrateUsage)
+		}
+		return command, 0, nil
+	case "up", "down", "status":
+		if len(args) > 1 || (command == "status" && len(args) > 0) {
+			return "", 0, usageError(migrateUsage)
+		}
+	case "force":
+		if len(args) != 1 {
+			return "", 0, usageError(migrateUsage)
+		}
+	default:
+		return "", 0, usageError(migrateUsage)
+	}
+
+	n, err := intArg(args)
+	if err != nil {
+		return "", 0, err
+	}
+	if command == "down" && n <= 0 {
+		n = 1
+	}
+	return command, n, nil
+}
+
+// execMigrate run parsed migrate command and print its outcome to out
+func execMigrate(ctx context.Context, migrator *migrate.Migrator, out io.Writer, command string, n int64) error {
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx, int(n))
+		fmt.Fprintf(out, "applied %d migrations\n", applied)
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx, int(n))
+		fmt.Fprintf(out, "reverted %d migrations\n", reverted)
+		return err
+	case "force":
+		return migrator.Force(ctx, n)
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "version: %d, dirty: %t, latest: %d\n", status.Version, status.Dirty, status.Latest)
+		for _, migration := range status.Pending {
+			fmt.Fprintf(out, "pending: %02d_%s\n", migration.Version, migration.Name)
+		}
+		return nil
+	default:
+		return usageError(migrateUsage)
+	}
+}
+
+// autoMigrate apply pending migrations on start, replicas starting together wait for each other on advisory lock
+func autoMigrate(db *sqlx.DB, appLogger logger.Logger) error {
+	migrator, err := migrate.NewMigrator(db, migrations.FS, appLogger)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+
+	applied, err := migrator.Up(ctx, 0)
+	if err != nil {
+		return err
+	}
+	appLogger.Infof("Auto migrate finished, Applied: %d", applied)
+	return nil
+}
+
+func intArg(args []string) (int64, error) {
+	if len(args) == 0 {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || n < 0 {
+		return 0, usageError(fmt.Sprintf("invalid number %q\n%s", args[0], migrateUsage))
+	}
+	return n, nil
+}
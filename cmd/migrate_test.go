@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/migrate"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMigrateArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    []string
+		command string
+		n       int64
+		usage   bool
+	}{
+		{name: "up all", args: []string{"up"}, command: "up"},
+		{name: "up steps", args: []string{"up", "2"}, command: "up", n: 2},
+		{name: "down defaults to one", args: []string{"down"}, command: "down", n: 1},
+		{name: "down steps", args: []string{"down", "3"}, command: "down", n: 3},
+		{name: "status", args: []string{"status"}, command: "status"},
+		{name: "force", args: []string{"force", "4"}, command: "force", n: 4},
+		{name: "create", args: []string{"create", "add_tags"}, command: "create"},
+		{name: "no command", args: nil, usage: true},
+		{name: "unknown command", args: []string{"redo"}, usage: true},
+		{name: "force without version", args: []string{"force"}, usage: true},
+		{name: "create without name", args: []string{"create"}, usage: true},
+		{name: "negative steps", args: []string{"up", "-1"}, usage: true},
+		{name: "not a number", args: []string{"down", "all"}, usage: true},
+		{name: "extra arguments", args: []string{"up", "1", "2"}, usage: true},
+		{name: "status with argument", args: []string{"status", "1"}, usage: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			command, n, err := parseMigrateArgs(tt.args)
+			if tt.usage {
+				var usageErr usageError
+				require.ErrorAs(t, err, &usageErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.command, command)
+			require.Equal(t, tt.n, n)
+		})
+	}
+}
+
+func TestExecMigrate(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"01_create_blogs.up.sql":   {Data: []byte(`CREATE TABLE blogs (id UUID PRIMARY KEY)`)},
+		"01_create_blogs.down.sql": {Data: []byte(`DROP TABLE blogs`)},
+		"02_add_tags.up.sql":       {Data: []byte(`ALTER TABLE blogs ADD COLUMN tags TEXT[]`)},
+	}
+	createVersionTable := `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`
+	getVersion := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	newMigrator := func(t *testing.T) (*migrate.Migrator, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		migrator, err := migrate.NewMigrator(sqlx.NewDb(db, "sqlmock"), fsys, logger.NewApiLogger(nil))
+		require.NoError(t, err)
+		return migrator, mock
+	}
+
+	// status lists pending migrations
+	t.Run("status", func(t *testing.T) {
+		t.Parallel()
+
+		migrator, mock := newMigrator(t)
+		mock.ExpectExec(createVersionTable).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(getVersion).WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+
+		out := &bytes.Buffer{}
+		require.NoError(t, execMigrate(context.Background(), migrator, out, "status", 0))
+		require.Equal(t, "version: 1, dirty: false, latest: 2\npending: 02_add_tags\n", out.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// dirty database is reported with number of applied migrations
+	t.Run("up dirty", func(t *testing.T) {
+		t.Parallel()
+
+		migrator, mock := newMigrator(t)
+		mock.ExpectExec(`SELECT pg_advisory_lock($1)`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(createVersionTable).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(getVersion).WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, true))
+		mock.ExpectExec(`SELECT pg_advisory_unlock($1)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		out := &bytes.Buffer{}
+		err := execMigrate(context.Background(), migrator, out, "up", 0)
+		require.ErrorIs(t, err, migrate.ErrDirty)
+		require.Equal(t, "applied 0 migrations\n", out.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// down reverts applied migration and sets previous version
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+
+		migrator, mock := newMigrator(t)
+		mock.ExpectExec(`SELECT pg_advisory_lock($1)`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(createVersionTable).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(getVersion).WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(1, false))
+		mock.ExpectBegin()
+		mock.ExpectExec(`DROP TABLE blogs`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectExec(`SELECT pg_advisory_unlock($1)`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		out := &bytes.Buffer{}
+		require.NoError(t, execMigrate(context.Background(), migrator, out, "down", 1))
+		require.Equal(t, "reverted 1 migrations\n", out.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,19 @@
+package main
+
+const newsUsage = `usage: news <command>
+` + transferUsage
+
+// runNews news subcommand
+func (c *cli) runNews(args []string) error {
+	if len(args) == 0 {
+		return usageError(newsUsage)
+	}
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "export", "import":
+		return c.runTransfer("news", command, args, func(a *app) transferUseCase { return a.newsUC })
+	default:
+		return usageError(newsUsage)
+	}
+}
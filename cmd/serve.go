@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/server"
+	"github.com/Dostonlv/task-del/pkg/db/postgres"
+	"github.com/Dostonlv/task-del/pkg/lifecycle"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/pkg/errors"
+)
+
+// serve run API server until shutdown signal, reloadable settings of config file follow its changes
+func serve(cfg *config.Config, configPath string, appLogger logger.Logger) error {
+	appLogger.Infof("AppVersion: %s, LogLevel: %s, Mode: %s", cfg.Server.AppVersion, cfg.Logger.Level, cfg.Server.Mode)
+	appLogger.Debugf("Config: %+v", cfg.Redacted())
+
+	shutdownTracer, err := tracing.InitTracer(cfg)
+	if err != nil {
+		return errors.Wrap(err, "Tracing init")
+	}
+
+	// tracer and database are released here when start fails before server lifecycle takes them over
+	owned := false
+	defer func() {
+		if owned {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout*time.Second)
+		defer cancel()
+		if err := shutdownTracer(ctx); err != nil {
+			appLogger.Errorf("Tracing shutdown: %v", err)
+		}
+	}()
+
+	psqlDB, err := postgres.NewPsqlDB(cfg)
+	if err != nil {
+		return errors.Wrap(err, "Postgresql init")
+	}
+	defer func() {
+		if owned {
+			return
+		}
+		if err := psqlDB.Close(); err != nil {
+			appLogger.Errorf("Postgresql close: %v", err)
+		}
+	}()
+	appLogger.Infof("Postgres connected, Status: %#v", psqlDB.Stats())
+
+	if cfg.Postgres.AutoMigrate {
+		if err = autoMigrate(psqlDB, appLogger); err != nil {
+			return errors.Wrap(err, "Auto migrate")
+		}
+	}
+
+	watcher := config.NewWatcher(configPath, cfg, appLogger)
+	watcher.Subscribe(func(cfg *config.Config) {
+		if err := appLogger.SetLevel(cfg.Logger.Level); err != nil {
+			appLogger.Errorf("Set log level: %v", err)
+		}
+	})
+
+	s := server.NewServer(cfg, psqlDB, appLogger, watcher)
+	s.AddWorker(lifecycle.Component{Name: "tracing", Stop: shutdownTracer})
+	owned = true
+
+	return errors.Wrap(s.Run(), "Server")
+}
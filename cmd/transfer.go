@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+	"github.com/pkg/errors"
+)
+
+const transferUsage = `  export [-o FILE] [-format ndjson|csv] [-title T] [-tags a,b] [-from DATE] [-to DATE]
+                     write records to file, stdout by default
+  import [-f FILE] [-format ndjson|csv] [-dry-run]
+                     create or update records by ID from file, stdin by default`
+
+// transferUseCase export and import shared by blogs and news use cases
+type transferUseCase interface {
+	Export(ctx context.Context, filter *models.ExportFilter, w io.Writer, format transfer.Format) (int, error)
+	Import(ctx context.Context, r io.Reader, format transfer.Format, dryRun bool) (*models.ImportResult, error)
+}
+
+// runTransfer export or import subcommand of domain, useCase picks domain use case of initialized app
+func (c *cli) runTransfer(domain string, command string, args []string, useCase func(a *app) transferUseCase) error {
+	flags := flag.NewFlagSet(domain+" "+command, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	formatName := flags.String("format", "ndjson", "ndjson or csv")
+	var (
+		path                  string
+		title, tags, from, to string
+		dryRun                bool
+	)
+	if command == "export" {
+		flags.StringVar(&path, "o", "", "output file")
+		flags.StringVar(&title, "title", "", "title contains")
+		flags.StringVar(&tags, "tags", "", "comma separated tags, records with any of them")
+		flags.StringVar(&from, "from", "", "created at or after, RFC 3339 time or date")
+		flags.StringVar(&to, "to", "", "created before, RFC 3339 time or date")
+	} else {
+		flags.StringVar(&path, "f", "", "input file")
+		flags.BoolVar(&dryRun, "dry-run", false, "validate and count without writing")
+	}
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	format, err := transfer.ParseFormat(*formatName)
+	if err != nil {
+		return usageError(err.Error())
+	}
+	filter, err := models.ParseExportFilter(title, tags, from, to)
+	if err != nil {
+		return usageError(err.Error())
+	}
+
+	a, err := c.newApp()
+	if err != nil {
+		return err
+	}
+	defer a.Close()
+
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	if command == "export" {
+		out := c.stdout
+		if path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				return errors.Wrap(err, domain+" export")
+			}
+			defer f.Close()
+			out = f
+		}
+		exported, err := useCase(a).Export(ctx, filter, out, format)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.stderr, "exported %d %s\n", exported, domain)
+		return nil
+	}
+
+	in := c.stdin
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return errors.Wrap(err, domain+" import")
+		}
+		defer f.Close()
+		in = f
+	}
+	result, err := useCase(a).Import(ctx, in, format, dryRun)
+	if err != nil {
+		return err
+	}
+	if err := c.printJSON(result); err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return errors.Errorf("%s import: %d records failed", domain, result.Failed)
+	}
+	return nil
+}
@@ -1,72 +1,387 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
+const (
+	// EnvPrefix prefix of environment overrides, every field has one named by its path, e.g. APP_SERVER_PORT
+	EnvPrefix = "APP"
+	// PathEnv environment variable with config file path, --config flag takes precedence over it
+	PathEnv = EnvPrefix + "_CONFIG"
+	// DefaultPath config file used when path is not given
+	DefaultPath = "./config/config-local.yml"
+)
+
 // App config struct
 type Config struct {
-	Server   ServerConfig
-	Postgres PostgresConfig
-	Logger   Logger
+	Server      ServerConfig
+	Postgres    PostgresConfig
+	Logger      Logger
+	Tracing     TracingConfig
+	GRPC        GRPCConfig
+	GraphQL     GraphQLConfig
+	Webhooks    WebhooksConfig
+	Outbox      OutboxConfig
+	Stream      StreamConfig
+	Locales     LocalesConfig
+	Secrets     SecretsConfig
+	CORS        CORSConfig
+	RateLimit   RateLimitConfig
+	Cache       CacheConfig
+	Idempotency IdempotencyConfig
 }
 
-// Server config struct
+// Server config struct. TrustedProxies are CIDR ranges of reverse proxies whose X-Forwarded-For is trusted,
+// without them client IP is the connection address.
 type ServerConfig struct {
-	AppVersion        string
-	Port              string
-	PprofPort         string
-	Mode              string
-	JwtSecretKey      string
-	CookieName        string
-	ReadTimeout       time.Duration
-	WriteTimeout      time.Duration
-	CtxDefaultTimeout time.Duration
-	CSRF              bool
-	Debug             bool
-}
-
-// Logger config
+	AppVersion         string
+	Port               string
+	PprofPort          string
+	Mode               string
+	JwtSecretKey       string `secret:"true"`
+	CookieName         string
+	ReadTimeout        time.Duration
+	WriteTimeout       time.Duration
+	CtxDefaultTimeout  time.Duration
+	HealthCheckTimeout time.Duration
+	ShutdownTimeout    time.Duration
+	DrainDelay         time.Duration
+	AdminToken         string `secret:"true"`
+	TrustedProxies     []string
+	CSRF               bool
+	Debug              bool
+}
+
+// Logger config, without sinks logs are written to stderr in Encoding
 type Logger struct {
 	Development       bool
 	DisableCaller     bool
 	DisableStacktrace bool
 	Encoding          string
 	Level             string
+	Sinks             []LogSink
+	Sampling          LogSampling
+	RedactKeys        []string
+}
+
+// Log output, Type is stdout, stderr, file or syslog. Encoding defaults to Logger.Encoding, Level is the lowest level written.
+// File is rotated when it grows over MaxSize megabytes or gets older than MaxAge hours, MaxBackups rotated files are kept.
+// Syslog Network and Address are empty for local syslog.
+type LogSink struct {
+	Type       string
+	Encoding   string
+	Level      string
+	Path       string
+	MaxSize    int
+	MaxAge     time.Duration
+	MaxBackups int
+	Network    string
+	Address    string
+	Tag        string
+}
+
+// Log sampling, per second Initial entries with the same level and message are written, then every Thereafter-th
+type LogSampling struct {
+	Enabled    bool
+	Initial    int
+	Thereafter int
+}
+
+// gRPC server config
+type GRPCConfig struct {
+	Enabled           bool
+	Port              string
+	Reflection        bool
+	MaxConnectionIdle time.Duration
+	MaxConnectionAge  time.Duration
+}
+
+// GraphQL config
+type GraphQLConfig struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Webhooks dispatcher config
+type WebhooksConfig struct {
+	Enabled              bool
+	Workers              int
+	BatchSize            int
+	Timeout              time.Duration
+	PollInterval         time.Duration
+	MaxAttempts          int
+	BackoffBase          time.Duration
+	BackoffMax           time.Duration
+	DisableAfterFailures int
+}
+
+// Outbox relay config
+type OutboxConfig struct {
+	Enabled      bool
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BackoffBase  time.Duration
+	BackoffMax   time.Duration
+	Retention    time.Duration
+	Sinks        []string
+	Broker       BrokerConfig
+}
+
+// Live news stream config
+type StreamConfig struct {
+	Enabled        bool
+	BufferSize     int
+	ClientBuffer   int
+	Heartbeat      time.Duration
+	MaxConnections int
 }
 
-// Postgresql config
+// CORS config, reloaded live
+type CORSConfig struct {
+	AllowOrigins []string
+}
+
+// Per client IP rate limit of API requests, reloaded live
+type RateLimitConfig struct {
+	Enabled   bool
+	Rate      float64
+	Burst     int
+	ExpiresIn time.Duration
+}
+
+// HTTP cache config, reloaded live. ContentTTL is max-age of successful content reads in seconds, zero disables caching
+type CacheConfig struct {
+	ContentTTL time.Duration
+}
+
+// Idempotency-Key config of create and import requests. Store is postgres or memory (single instance only),
+// responses are kept for TTL hours, retries wait up to LockTimeout seconds for request in flight
+type IdempotencyConfig struct {
+	Enabled     bool
+	Store       string
+	TTL         time.Duration
+	LockTimeout time.Duration
+}
+
+// Content locales config, source content of blogs and news is written in Default locale
+type LocalesConfig struct {
+	Default   string
+	Supported []string
+	Fallbacks map[string][]string
+}
+
+// Message broker config
+type BrokerConfig struct {
+	URL           string
+	SubjectPrefix string
+	Timeout       time.Duration
+}
+
+// Tracing config
+type TracingConfig struct {
+	Enabled     bool
+	ServiceName string
+	Exporter    string
+	Endpoint    string
+	Insecure    bool
+	FilePath    string
+	SampleRatio float64
+}
+
+// Postgresql config, env tags are short override names next to the full ones, e.g. APP_POSTGRES_HOST
 type PostgresConfig struct {
-	PostgresqlHost     string
-	PostgresqlPort     string
-	PostgresqlUser     string
-	PostgresqlPassword string
-	PostgresqlDbname   string
-	PostgresqlSSLMode  bool
+	PostgresqlHost     string `env:"POSTGRES_HOST"`
+	PostgresqlPort     string `env:"POSTGRES_PORT"`
+	PostgresqlUser     string `env:"POSTGRES_USER"`
+	PostgresqlPassword string `env:"POSTGRES_PASSWORD" secret:"true"`
+	PostgresqlDbname   string `env:"POSTGRES_DBNAME"`
+	PostgresqlSSLMode  bool   `env:"POSTGRES_SSLMODE"`
 	PgDriver           string
+	MigrationsPath     string
+	AutoMigrate        bool
+}
+
+// Path config file path, flag value goes first, then APP_CONFIG, then DefaultPath
+func Path(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if path := os.Getenv(PathEnv); path != "" {
+		return path
+	}
+	return DefaultPath
+}
+
+// Load read, parse and validate config file at path with defaults, environment overrides and secret references applied
+func Load(path string) (*Config, error) {
+	v, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := ParseConfig(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolveSecrets(context.Background(), cfg); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
-// Load config file from given path
+// Load config file from given path, path without extension is looked up as yml, json or toml file.
+// Values missing in file get defaults, APP_ prefixed environment variables override both.
 func LoadConfig(filename string) (*viper.Viper, error) {
 	v := viper.New()
 
-	v.SetConfigName(filename)
-	v.AddConfigPath(".")
+	if filepath.Ext(filename) != "" {
+		v.SetConfigFile(filename)
+	} else {
+		v.SetConfigName(filepath.Base(filename))
+		v.AddConfigPath(filepath.Dir(filename))
+	}
+	setDefaults(v)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
+	if err := bindEnvs(v, reflect.TypeOf(Config{}), ""); err != nil {
+		return nil, err
+	}
+
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			return nil, errors.New("config file not found")
 		}
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.New("config file not found: " + filename)
+		}
 		return nil, err
 	}
 
 	return v, nil
 }
 
+// bindEnvs register environment override of every field, keys unknown to viper are not read by Unmarshal otherwise
+func bindEnvs(v *viper.Viper, t reflect.Type, prefix string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.ToLower(prefix + field.Name)
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			if field.Type != reflect.TypeOf(time.Duration(0)) {
+				if err := bindEnvs(v, field.Type, key+"."); err != nil {
+					return err
+				}
+				continue
+			}
+		case reflect.Map:
+			// maps have no flat environment form
+			continue
+		}
+
+		names := []string{EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))}
+		if alias := field.Tag.Get("env"); alias != "" {
+			names = append(names, EnvPrefix+"_"+alias)
+		}
+		if err := v.BindEnv(append([]string{key}, names...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setDefaults values of settings that may be left out of config file, secrets and addresses have none
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("server.appVersion", "1.0.0")
+	v.SetDefault("server.port", ":8080")
+	v.SetDefault("server.mode", ModeDevelopment)
+	v.SetDefault("server.cookieName", "jwt-token")
+	v.SetDefault("server.readTimeout", 5)
+	v.SetDefault("server.writeTimeout", 5)
+	v.SetDefault("server.ctxDefaultTimeout", 12)
+	v.SetDefault("server.healthCheckTimeout", 2)
+	v.SetDefault("server.shutdownTimeout", 10)
+	v.SetDefault("server.drainDelay", 5)
+	v.SetDefault("server.csrf", true)
+
+	v.SetDefault("grpc.port", ":5000")
+	v.SetDefault("grpc.maxConnectionIdle", 300)
+	v.SetDefault("grpc.maxConnectionAge", 300)
+
+	v.SetDefault("graphql.maxDepth", 8)
+	v.SetDefault("graphql.maxComplexity", 1000)
+
+	v.SetDefault("webhooks.workers", 4)
+	v.SetDefault("webhooks.batchSize", 50)
+	v.SetDefault("webhooks.timeout", 10)
+	v.SetDefault("webhooks.pollInterval", 5)
+	v.SetDefault("webhooks.maxAttempts", 8)
+	v.SetDefault("webhooks.backoffBase", 10)
+	v.SetDefault("webhooks.backoffMax", 3600)
+	v.SetDefault("webhooks.disableAfterFailures", 50)
+
+	v.SetDefault("outbox.pollInterval", 1)
+	v.SetDefault("outbox.batchSize", 100)
+	v.SetDefault("outbox.maxAttempts", 10)
+	v.SetDefault("outbox.backoffBase", 1)
+	v.SetDefault("outbox.backoffMax", 300)
+	v.SetDefault("outbox.retention", 168)
+	v.SetDefault("outbox.sinks", []string{"log"})
+	v.SetDefault("outbox.broker.subjectPrefix", "content")
+	v.SetDefault("outbox.broker.timeout", 5)
+
+	v.SetDefault("stream.bufferSize", 1000)
+	v.SetDefault("stream.clientBuffer", 64)
+	v.SetDefault("stream.heartbeat", 15)
+	v.SetDefault("stream.maxConnections", 1000)
+
+	v.SetDefault("locales.default", "en")
+	v.SetDefault("locales.supported", []string{"en"})
+
+	v.SetDefault("cors.allowOrigins", []string{"*"})
+	v.SetDefault("rateLimit.rate", 20)
+	v.SetDefault("rateLimit.burst", 40)
+	v.SetDefault("rateLimit.expiresIn", 180)
+
+	v.SetDefault("idempotency.store", "postgres")
+	v.SetDefault("idempotency.ttl", 24)
+	v.SetDefault("idempotency.lockTimeout", 30)
+
+	v.SetDefault("logger.encoding", "json")
+	v.SetDefault("logger.level", "info")
+	v.SetDefault("logger.sampling.initial", 100)
+	v.SetDefault("logger.sampling.thereafter", 100)
+	v.SetDefault("logger.redactKeys", []string{"password", "passwd", "secret", "token", "cookie", "authorization", "apikey", "api_key"})
+
+	v.SetDefault("tracing.serviceName", "task-del")
+	v.SetDefault("tracing.exporter", "stdout")
+	v.SetDefault("tracing.sampleRatio", 1)
+
+	v.SetDefault("secrets.vault.mount", "secret")
+	v.SetDefault("secrets.vault.timeout", 5)
+
+	v.SetDefault("postgres.postgresqlPort", "5432")
+	v.SetDefault("postgres.pgDriver", "pgx")
+	v.SetDefault("postgres.migrationsPath", "./migrations")
+}
+
 // Parse config file
 func ParseConfig(v *viper.Viper) (*Config, error) {
 	var c Config
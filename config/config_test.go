@@ -0,0 +1,275 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/pkg/secrets"
+	"github.com/stretchr/testify/require"
+)
+
+const minimalConfig = `
+server:
+  JwtSecretKey: test-secret
+postgres:
+  PostgresqlHost: localhost
+  PostgresqlUser: test
+  PostgresqlPassword: test-password
+  PostgresqlDbname: test
+`
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(writeConfig(t, minimalConfig))
+	require.NoError(t, err)
+
+	require.Equal(t, ModeDevelopment, cfg.Server.Mode)
+	require.Equal(t, ":8080", cfg.Server.Port)
+	require.Equal(t, time.Duration(5), cfg.Server.ReadTimeout)
+	require.Equal(t, time.Duration(5), cfg.Server.DrainDelay)
+	require.Equal(t, "5432", cfg.Postgres.PostgresqlPort)
+	require.Equal(t, []string{"log"}, cfg.Outbox.Sinks)
+	require.Equal(t, 10, cfg.Outbox.MaxAttempts)
+	require.Equal(t, time.Duration(300), cfg.Outbox.BackoffMax)
+}
+
+func TestLoad_EnvOverrides(t *testing.T) {
+	t.Setenv("APP_POSTGRES_HOST", "db.internal")
+	t.Setenv("APP_POSTGRES_POSTGRESQLPORT", "6432")
+	t.Setenv("APP_SERVER_PORT", ":9090")
+	t.Setenv("APP_OUTBOX_BROKER_URL", "nats://broker:4222")
+	t.Setenv("APP_OUTBOX_SINKS", "log,broker")
+	t.Setenv("APP_GRPC_ENABLED", "true")
+
+	cfg, err := Load(writeConfig(t, minimalConfig))
+	require.NoError(t, err)
+
+	require.Equal(t, "db.internal", cfg.Postgres.PostgresqlHost)
+	require.Equal(t, "6432", cfg.Postgres.PostgresqlPort)
+	require.Equal(t, ":9090", cfg.Server.Port)
+	require.Equal(t, "nats://broker:4222", cfg.Outbox.Broker.URL)
+	require.Equal(t, []string{"log", "broker"}, cfg.Outbox.Sinks)
+	require.True(t, cfg.GRPC.Enabled)
+}
+
+func TestLoad_Validation(t *testing.T) {
+	t.Setenv("APP_SERVER_MODE", "prod")
+	t.Setenv("APP_SERVER_PORT", "8080")
+	t.Setenv("APP_SERVER_JWTSECRETKEY", " ")
+
+	_, err := Load(writeConfig(t, minimalConfig))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `server.Mode: unknown mode "prod"`)
+	require.Contains(t, err.Error(), `server.Port: "8080" is not a [host]:port address`)
+	require.Contains(t, err.Error(), "server.JwtSecretKey: secret must not be empty")
+}
+
+func TestLoad_DrainDelay(t *testing.T) {
+	// drain delay is part of shutdown timeout
+	_, err := Load(writeConfig(t, `
+server:
+  JwtSecretKey: test-secret
+  ShutdownTimeout: 10
+  DrainDelay: 10
+postgres:
+  PostgresqlHost: localhost
+  PostgresqlUser: test
+  PostgresqlPassword: test-password
+  PostgresqlDbname: test
+`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "server.DrainDelay: 10 must not be negative and must be less than ShutdownTimeout 10")
+}
+
+func TestLoad_OutboxRetries(t *testing.T) {
+	_, err := Load(writeConfig(t, `
+server:
+  JwtSecretKey: test-secret
+postgres:
+  PostgresqlHost: localhost
+  PostgresqlUser: test
+  PostgresqlPassword: test-password
+  PostgresqlDbname: test
+outbox:
+  Enabled: true
+  MaxAttempts: -1
+  BackoffBase: 60
+  BackoffMax: 30
+`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "outbox.MaxAttempts: must not be negative, got -1")
+	require.Contains(t, err.Error(), "outbox.BackoffBase: 60 must not be negative and must not exceed BackoffMax 30")
+}
+
+func TestLoad_StreamRequiresOutboxSink(t *testing.T) {
+	const streamConfig = minimalConfig + `
+stream:
+  Enabled: true
+outbox:
+  Enabled: true
+`
+	// default outbox sinks do not feed the stream
+	_, err := Load(writeConfig(t, streamConfig))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stream.Enabled: requires outbox.Enabled with stream in outbox.Sinks")
+
+	t.Setenv("APP_OUTBOX_SINKS", "log,stream")
+	cfg, err := Load(writeConfig(t, streamConfig))
+	require.NoError(t, err)
+	require.True(t, cfg.Stream.Enabled)
+
+	// stream sink is useless while relay is off
+	t.Setenv("APP_OUTBOX_ENABLED", "false")
+	_, err = Load(writeConfig(t, streamConfig))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stream.Enabled: requires outbox.Enabled with stream in outbox.Sinks")
+}
+
+func TestLoad_LogSinks(t *testing.T) {
+	cfg, err := Load(writeConfig(t, minimalConfig+`
+logger:
+  Sinks:
+    - Type: stdout
+      Encoding: console
+    - Type: file
+      Path: /var/log/app.log
+      Level: warn
+      MaxSize: 100
+`))
+	require.NoError(t, err)
+	require.Len(t, cfg.Logger.Sinks, 2)
+	require.Equal(t, "console", cfg.Logger.Sinks[0].Encoding)
+	require.Equal(t, 100, cfg.Logger.Sinks[1].MaxSize)
+	require.Contains(t, cfg.Logger.RedactKeys, "password")
+
+	_, err = Load(writeConfig(t, minimalConfig+`
+logger:
+  Sinks:
+    - Type: kafka
+    - Type: file
+      Level: loud
+`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `logger.Sinks[0].Type: unknown sink "kafka"`)
+	require.Contains(t, err.Error(), `logger.Sinks[1].Level: unknown level "loud"`)
+	require.Contains(t, err.Error(), "logger.Sinks[1].Path: must be set for file sink")
+}
+
+func TestLoad_SecretReferences(t *testing.T) {
+	jwtFile := filepath.Join(t.TempDir(), "jwt")
+	require.NoError(t, os.WriteFile(jwtFile, []byte("jwt-from-file\n"), 0o600))
+
+	// KV v2 read API stub
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/kv/data/app/postgres":
+			w.Write([]byte(`{"data":{"data":{"password":"pg-from-vault"},"metadata":{"version":1}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+
+	t.Setenv("APP_SERVER_JWTSECRETKEY", "file:"+jwtFile)
+	t.Setenv("APP_SERVER_ADMINTOKEN", "env:ADMIN_TOKEN")
+	t.Setenv("ADMIN_TOKEN", "admin-from-env")
+	t.Setenv("APP_POSTGRES_PASSWORD", "vault:app/postgres#password")
+	t.Setenv("APP_SECRETS_VAULT_ADDRESS", vault.URL)
+	t.Setenv("APP_SECRETS_VAULT_TOKEN", "env:VAULT_TOKEN")
+	t.Setenv("APP_SECRETS_VAULT_MOUNT", "kv")
+	t.Setenv("VAULT_TOKEN", "vault-token")
+
+	cfg, err := Load(writeConfig(t, minimalConfig))
+	require.NoError(t, err)
+	require.Equal(t, "jwt-from-file", cfg.Server.JwtSecretKey)
+	require.Equal(t, "admin-from-env", cfg.Server.AdminToken)
+	require.Equal(t, "pg-from-vault", cfg.Postgres.PostgresqlPassword)
+
+	redacted := cfg.Redacted()
+	require.Equal(t, secrets.Redacted, redacted.Server.JwtSecretKey)
+	require.Equal(t, secrets.Redacted, redacted.Postgres.PostgresqlPassword)
+	require.Equal(t, secrets.Redacted, redacted.Secrets.Vault.Token)
+	require.Equal(t, "jwt-from-file", cfg.Server.JwtSecretKey)
+
+	// missing key of Vault secret
+	t.Setenv("APP_POSTGRES_PASSWORD", "vault:app/postgres#user")
+	_, err = Load(writeConfig(t, minimalConfig))
+	require.ErrorIs(t, err, secrets.ErrNotFound)
+	require.Contains(t, err.Error(), "postgres.PostgresqlPassword")
+
+	// unset variable
+	t.Setenv("APP_POSTGRES_PASSWORD", "env:UNSET_DB_PASS")
+	_, err = Load(writeConfig(t, minimalConfig))
+	require.ErrorIs(t, err, secrets.ErrNotFound)
+
+	// vault reference without Vault address is not taken as the password
+	t.Setenv("APP_POSTGRES_PASSWORD", "vault:app/postgres#password")
+	t.Setenv("APP_SECRETS_VAULT_ADDRESS", "")
+	_, err = Load(writeConfig(t, minimalConfig))
+	require.ErrorIs(t, err, secrets.ErrNoProvider)
+	require.Contains(t, err.Error(), "postgres.PostgresqlPassword")
+}
+
+func TestLoad_WeakSecretsInProduction(t *testing.T) {
+	t.Setenv("APP_SERVER_MODE", ModeProduction)
+	t.Setenv("APP_SERVER_JWTSECRETKEY", "secretkey")
+	t.Setenv("APP_SERVER_ADMINTOKEN", "local-admin-token")
+
+	_, err := Load(writeConfig(t, minimalConfig))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "server.JwtSecretKey: weak or default secret")
+	require.Contains(t, err.Error(), "server.AdminToken: weak or default secret")
+	require.Contains(t, err.Error(), "postgres.PostgresqlPassword: weak or default secret")
+
+	t.Setenv("APP_SERVER_JWTSECRETKEY", "kX9v2Qm7Lp4Rt8Zw1Nc6")
+	t.Setenv("APP_SERVER_ADMINTOKEN", "")
+	t.Setenv("APP_POSTGRES_PASSWORD", "Hq3Vn8Jd5Ws2Ub7Yf0Ke")
+	_, err = Load(writeConfig(t, minimalConfig))
+	require.NoError(t, err)
+
+	// weak secrets are allowed outside production
+	t.Setenv("APP_SERVER_MODE", ModeDevelopment)
+	t.Setenv("APP_SERVER_JWTSECRETKEY", "secretkey")
+	_, err = Load(writeConfig(t, minimalConfig))
+	require.NoError(t, err)
+}
+
+func TestPath(t *testing.T) {
+	t.Setenv(PathEnv, "/etc/app/config.yml")
+	require.Equal(t, "/run/config.yml", Path("/run/config.yml"))
+	require.Equal(t, "/etc/app/config.yml", Path(""))
+
+	t.Setenv(PathEnv, "")
+	require.Equal(t, DefaultPath, Path(""))
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	_, err := Load(writeConfig(t, `
+server:
+  JwtSecretKey: test-secret
+  TrustedProxies:
+    - 10.0.0.0/8
+    - 10.0.0.1
+postgres:
+  PostgresqlHost: localhost
+  PostgresqlUser: test
+  PostgresqlPassword: test-password
+  PostgresqlDbname: test
+`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `server.TrustedProxies[1]: "10.0.0.1" is not a CIDR range`)
+	require.NotContains(t, err.Error(), "TrustedProxies[0]")
+}
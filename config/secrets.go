@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Dostonlv/task-del/pkg/secrets"
+	"github.com/pkg/errors"
+)
+
+// Secrets backends config, fields tagged secret may hold file:, env: or vault: references instead of values
+type SecretsConfig struct {
+	Vault VaultConfig
+}
+
+// Vault KV v2 backend config, references look like vault:path#key
+type VaultConfig struct {
+	Address string
+	Token   string `secret:"true"`
+	Mount   string
+	Timeout time.Duration
+}
+
+// secretField secret config field with its config path, e.g. server.JwtSecretKey
+type secretField struct {
+	name  string
+	value *string
+}
+
+// secretFields string fields of config tagged secret
+func (c *Config) secretFields() []secretField {
+	var fields []secretField
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			switch {
+			case field.Type.Kind() == reflect.Struct:
+				walk(v.Field(i), prefix+strings.ToLower(field.Name)+".")
+			case field.Type.Kind() == reflect.String && field.Tag.Get("secret") == "true":
+				fields = append(fields, secretField{name: prefix + field.Name, value: v.Field(i).Addr().Interface().(*string)})
+			}
+		}
+	}
+	walk(reflect.ValueOf(c).Elem(), "")
+	return fields
+}
+
+// resolveSecrets replace secret references with values, Vault token is resolved first so it may be referenced too.
+// Vault token is left as is while Vault address is not set.
+func resolveSecrets(ctx context.Context, c *Config) error {
+	resolver := secrets.NewResolver()
+
+	vault := &c.Secrets.Vault
+	if vault.Address != "" {
+		token, err := resolver.Resolve(ctx, vault.Token)
+		if err != nil {
+			return errors.Wrap(err, "secrets.vault.Token")
+		}
+		vault.Token = token
+		resolver.Register("vault", secrets.NewVaultProvider(vault.Address, vault.Token, vault.Mount, vault.Timeout*time.Second))
+	}
+
+	for _, field := range c.secretFields() {
+		if field.value == &vault.Token {
+			continue
+		}
+		value, err := resolver.Resolve(ctx, *field.value)
+		if err != nil {
+			return errors.Wrap(err, field.name)
+		}
+		*field.value = value
+	}
+	return nil
+}
+
+// Redacted copy of config safe to log, secret values are masked
+func (c *Config) Redacted() Config {
+	redacted := *c
+	for _, field := range redacted.secretFields() {
+		*field.value = secrets.Redact(*field.value)
+	}
+	return redacted
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/Dostonlv/task-del/pkg/secrets"
+)
+
+// Server modes
+const (
+	ModeDevelopment = "Development"
+	ModeStaging     = "Staging"
+	ModeProduction  = "Production"
+)
+
+var (
+	modes             = []string{ModeDevelopment, ModeStaging, ModeProduction}
+	loggerLevels      = []string{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}
+	loggerEncodings   = []string{"json", "console"}
+	logSinkTypes      = []string{"stdout", "stderr", "file", "syslog"}
+	idempotencyStores = []string{"postgres", "memory"}
+)
+
+// Validate check config after defaults and overrides are applied, every problem is reported at once
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.check(contains(modes, c.Server.Mode), "server.Mode: unknown mode %q, want one of %s", c.Server.Mode, strings.Join(modes, ", "))
+	v.port("server.Port", c.Server.Port)
+	if c.Server.Debug && c.Server.PprofPort != "" {
+		v.port("server.PprofPort", c.Server.PprofPort)
+	}
+	v.secret("server.JwtSecretKey", c.Server.JwtSecretKey)
+	v.positive("server.ReadTimeout", int64(c.Server.ReadTimeout))
+	v.positive("server.WriteTimeout", int64(c.Server.WriteTimeout))
+	v.positive("server.CtxDefaultTimeout", int64(c.Server.CtxDefaultTimeout))
+	v.positive("server.ShutdownTimeout", int64(c.Server.ShutdownTimeout))
+	v.check(c.Server.DrainDelay >= 0 && c.Server.DrainDelay < c.Server.ShutdownTimeout,
+		"server.DrainDelay: %d must not be negative and must be less than ShutdownTimeout %d", c.Server.DrainDelay, c.Server.ShutdownTimeout)
+
+	for i, cidr := range c.Server.TrustedProxies {
+		_, _, err := net.ParseCIDR(cidr)
+		v.check(err == nil, "server.TrustedProxies[%d]: %q is not a CIDR range", i, cidr)
+	}
+
+	if c.GRPC.Enabled {
+		v.port("grpc.Port", c.GRPC.Port)
+	}
+
+	v.check(c.Postgres.PostgresqlHost != "", "postgres.PostgresqlHost: must be set")
+	v.check(validPortNumber(c.Postgres.PostgresqlPort), "postgres.PostgresqlPort: %q is not a port number", c.Postgres.PostgresqlPort)
+	v.check(c.Postgres.PostgresqlUser != "", "postgres.PostgresqlUser: must be set")
+	v.check(c.Postgres.PostgresqlDbname != "", "postgres.PostgresqlDbname: must be set")
+	v.secret("postgres.PostgresqlPassword", c.Postgres.PostgresqlPassword)
+
+	v.check(contains(loggerLevels, c.Logger.Level), "logger.Level: unknown level %q, want one of %s", c.Logger.Level, strings.Join(loggerLevels, ", "))
+	v.check(contains(loggerEncodings, c.Logger.Encoding), "logger.Encoding: unknown encoding %q, want json or console", c.Logger.Encoding)
+	for i, sink := range c.Logger.Sinks {
+		name := fmt.Sprintf("logger.Sinks[%d]", i)
+		v.check(contains(logSinkTypes, sink.Type), "%s.Type: unknown sink %q, want one of %s", name, sink.Type, strings.Join(logSinkTypes, ", "))
+		v.check(sink.Encoding == "" || contains(loggerEncodings, sink.Encoding), "%s.Encoding: unknown encoding %q, want json or console", name, sink.Encoding)
+		v.check(sink.Level == "" || contains(loggerLevels, sink.Level), "%s.Level: unknown level %q", name, sink.Level)
+		if sink.Type == "file" {
+			v.check(sink.Path != "", "%s.Path: must be set for file sink", name)
+			v.check(sink.MaxSize >= 0 && sink.MaxAge >= 0 && sink.MaxBackups >= 0, "%s: MaxSize, MaxAge and MaxBackups must not be negative", name)
+		}
+	}
+	if c.Logger.Sampling.Enabled {
+		v.positive("logger.Sampling.Initial", int64(c.Logger.Sampling.Initial))
+		v.positive("logger.Sampling.Thereafter", int64(c.Logger.Sampling.Thereafter))
+	}
+
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.SampleRatio: %v is out of [0, 1]", c.Tracing.SampleRatio)
+
+	if c.Webhooks.Enabled {
+		v.positive("webhooks.Workers", int64(c.Webhooks.Workers))
+		v.positive("webhooks.BatchSize", int64(c.Webhooks.BatchSize))
+	}
+	if c.Outbox.Enabled {
+		v.positive("outbox.BatchSize", int64(c.Outbox.BatchSize))
+		v.positive("outbox.PollInterval", int64(c.Outbox.PollInterval))
+		v.check(c.Outbox.MaxAttempts >= 0, "outbox.MaxAttempts: must not be negative, got %d", c.Outbox.MaxAttempts)
+		v.check(c.Outbox.BackoffBase >= 0 && c.Outbox.BackoffMax >= c.Outbox.BackoffBase,
+			"outbox.BackoffBase: %d must not be negative and must not exceed BackoffMax %d", c.Outbox.BackoffBase, c.Outbox.BackoffMax)
+	}
+	// stream hub is fed only by outbox relay through the stream sink
+	if c.Stream.Enabled {
+		v.check(c.Outbox.Enabled && contains(c.Outbox.Sinks, "stream"), "stream.Enabled: requires outbox.Enabled with stream in outbox.Sinks")
+	}
+
+	v.check(len(c.CORS.AllowOrigins) > 0, "cors.AllowOrigins: must not be empty, use * to allow any origin")
+	if c.RateLimit.Enabled {
+		v.check(c.RateLimit.Rate > 0, "rateLimit.Rate: must be positive, got %v", c.RateLimit.Rate)
+		v.positive("rateLimit.Burst", int64(c.RateLimit.Burst))
+		v.positive("rateLimit.ExpiresIn", int64(c.RateLimit.ExpiresIn))
+	}
+	v.check(c.Cache.ContentTTL >= 0, "cache.ContentTTL: must not be negative, got %d", c.Cache.ContentTTL)
+	if c.Idempotency.Enabled {
+		v.check(contains(idempotencyStores, c.Idempotency.Store), "idempotency.Store: unknown store %q, want postgres or memory", c.Idempotency.Store)
+		v.positive("idempotency.TTL", int64(c.Idempotency.TTL))
+		v.positive("idempotency.LockTimeout", int64(c.Idempotency.LockTimeout))
+	}
+
+	if len(c.Locales.Supported) > 0 {
+		v.check(contains(c.Locales.Supported, c.Locales.Default), "locales.Default: %q is not in locales.Supported", c.Locales.Default)
+	}
+
+	if c.Server.Mode == ModeProduction {
+		for _, field := range c.secretFields() {
+			v.check(*field.value == "" || !secrets.IsWeak(*field.value), "%s: weak or default secret is not allowed in %s mode, use at least %d random characters", field.name, ModeProduction, secrets.MinLength)
+		}
+	}
+	if c.Secrets.Vault.Address != "" {
+		v.positive("secrets.Vault.Timeout", int64(c.Secrets.Vault.Timeout))
+	}
+
+	return v.err()
+}
+
+// validator collects config problems
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, format string, args ...interface{}) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+// port address in [host]:port form
+func (v *validator) port(name, addr string) {
+	_, port, err := net.SplitHostPort(addr)
+	v.check(err == nil && validPortNumber(port), "%s: %q is not a [host]:port address with port in 1-65535", name, addr)
+}
+
+func (v *validator) secret(name, value string) {
+	v.check(strings.TrimSpace(value) != "", "%s: secret must not be empty", name)
+}
+
+func (v *validator) positive(name string, value int64) {
+	v.check(value > 0, "%s: must be positive, got %d", name, value)
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return errors.New("invalid config:\n  " + strings.Join(v.problems, "\n  "))
+}
+
+func validPortNumber(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce editors write config file in several steps, reload once they settle
+const reloadDebounce = 500 * time.Millisecond
+
+// reloadable settings applied live, changes of any other setting need restart
+var reloadable = []string{"logger.Level", "cors.", "ratelimit.", "cache."}
+
+// WatchLogger logger of Watcher, satisfied by logger.Logger
+type WatchLogger interface {
+	Infof(template string, args ...interface{})
+	Warnf(template string, args ...interface{})
+	Errorf(template string, args ...interface{})
+}
+
+// Watcher reload config file on change or SIGHUP and apply reloadable settings live.
+// Components read new settings from Subscribe callbacks, config passed to constructors is never mutated.
+type Watcher struct {
+	path   string
+	logger WatchLogger
+
+	mu          sync.Mutex
+	current     *Config
+	subscribers map[int]func(cfg *Config)
+	nextID      int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatcher config watcher constructor, cfg is config loaded from path on start
+func NewWatcher(path string, cfg *Config, logger WatchLogger) *Watcher {
+	return &Watcher{path: path, logger: logger, current: cfg, subscribers: make(map[int]func(cfg *Config))}
+}
+
+// Current last applied config
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Subscribe call fn with new config after every reload that changed reloadable settings, returns unsubscribe func
+func (w *Watcher) Subscribe(fn func(cfg *Config)) func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.nextID
+	w.nextID++
+	w.subscribers[id] = fn
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subscribers, id)
+	}
+}
+
+// Reload load config file again and apply changed reloadable settings.
+// Invalid config is rejected as a whole, changes of other settings are ignored.
+// Returns names of applied and rejected settings.
+func (w *Watcher) Reload() (applied, rejected []string, err error) {
+	next, err := Load(w.path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w.mu.Lock()
+	current := w.current
+	for _, name := range diff(reflect.ValueOf(*current), reflect.ValueOf(*next), "") {
+		if isReloadable(name) {
+			applied = append(applied, name)
+		} else {
+			rejected = append(rejected, name)
+		}
+	}
+	if len(applied) == 0 {
+		w.mu.Unlock()
+		return nil, rejected, nil
+	}
+
+	cfg := *current
+	cfg.Logger.Level = next.Logger.Level
+	cfg.CORS = next.CORS
+	cfg.RateLimit = next.RateLimit
+	cfg.Cache = next.Cache
+	w.current = &cfg
+
+	subscribers := make([]func(cfg *Config), 0, len(w.subscribers))
+	for _, fn := range w.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(&cfg)
+	}
+	return applied, rejected, nil
+}
+
+// Start watch config file and SIGHUP in background
+func (w *Watcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// watch directory, config file replaced by rename or symlink swap (e.g. Kubernetes ConfigMap) keeps being watched
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go func() {
+		defer close(w.done)
+		defer signal.Stop(hup)
+		defer watcher.Close()
+		w.run(runCtx, watcher, hup)
+	}()
+	return nil
+}
+
+// Stop stop watching, reload in progress is finished first
+func (w *Watcher) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Watcher) run(ctx context.Context, watcher *fsnotify.Watcher, hup <-chan os.Signal) {
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			w.reload("SIGHUP")
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if w.isConfigFile(event.Name) && (event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) {
+				debounce = time.After(reloadDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Errorf("Config watcher error: %v", err)
+		case <-debounce:
+			debounce = nil
+			w.reload("file change")
+		}
+	}
+}
+
+func (w *Watcher) reload(reason string) {
+	applied, rejected, err := w.Reload()
+	if err != nil {
+		w.logger.Errorf("Config reload on %s failed, keeping current config: %v", reason, err)
+		return
+	}
+	if len(rejected) > 0 {
+		w.logger.Warnf("Config reload on %s ignored changes that need restart: %s", reason, strings.Join(rejected, ", "))
+	}
+	if len(applied) > 0 {
+		w.logger.Infof("Config reloaded on %s, applied: %s", reason, strings.Join(applied, ", "))
+	}
+}
+
+// isConfigFile path without extension matches config files of any extension
+func (w *Watcher) isConfigFile(name string) bool {
+	if filepath.Ext(w.path) != "" {
+		return filepath.Base(name) == filepath.Base(w.path)
+	}
+	return strings.TrimSuffix(filepath.Base(name), filepath.Ext(name)) == filepath.Base(w.path)
+}
+
+func isReloadable(name string) bool {
+	for _, prefix := range reloadable {
+		if name == prefix || (strings.HasSuffix(prefix, ".") && strings.HasPrefix(name, prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// diff names of settings that differ, e.g. server.Port, values are not reported as they may be secrets
+func diff(a, b reflect.Value, prefix string) []string {
+	var names []string
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			names = append(names, diff(a.Field(i), b.Field(i), prefix+strings.ToLower(field.Name)+".")...)
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			names = append(names, prefix+field.Name)
+		}
+	}
+	return names
+}
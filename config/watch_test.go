@@ -0,0 +1,113 @@
+package config
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testLogger records watcher messages
+type testLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *testLogger) log(template string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, template)
+}
+
+func (l *testLogger) Infof(template string, args ...interface{})  { l.log(template, args...) }
+func (l *testLogger) Warnf(template string, args ...interface{})  { l.log(template, args...) }
+func (l *testLogger) Errorf(template string, args ...interface{}) { l.log(template, args...) }
+
+func TestWatcher_Reload(t *testing.T) {
+	path := writeConfig(t, minimalConfig)
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	w := NewWatcher(path, cfg, &testLogger{})
+	var received []*Config
+	unsubscribe := w.Subscribe(func(cfg *Config) {
+		received = append(received, cfg)
+	})
+
+	// nothing changed
+	applied, rejected, err := w.Reload()
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.Empty(t, rejected)
+	require.Empty(t, received)
+
+	// reloadable settings are applied, port and database host are kept
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(minimalConfig, "localhost", "db.internal", 1)+`
+  PostgresqlPort: 6432
+logger:
+  Level: debug
+cors:
+  AllowOrigins: [https://example.com]
+rateLimit:
+  Enabled: true
+  Rate: 5
+cache:
+  ContentTTL: 60
+`), 0o600))
+	applied, rejected, err = w.Reload()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"logger.Level", "cors.AllowOrigins", "ratelimit.Enabled", "ratelimit.Rate", "cache.ContentTTL"}, applied)
+	require.ElementsMatch(t, []string{"postgres.PostgresqlHost", "postgres.PostgresqlPort"}, rejected)
+
+	require.Len(t, received, 1)
+	require.Same(t, w.Current(), received[0])
+	require.Equal(t, "debug", received[0].Logger.Level)
+	require.Equal(t, []string{"https://example.com"}, received[0].CORS.AllowOrigins)
+	require.Equal(t, time.Duration(60), received[0].Cache.ContentTTL)
+	require.Equal(t, "localhost", received[0].Postgres.PostgresqlHost)
+	require.Equal(t, "5432", received[0].Postgres.PostgresqlPort)
+	// config given to constructor is not mutated
+	require.Equal(t, "info", cfg.Logger.Level)
+
+	// invalid config is rejected as a whole
+	require.NoError(t, os.WriteFile(path, []byte(minimalConfig+"logger:\n  Level: verbose\n"), 0o600))
+	_, _, err = w.Reload()
+	require.Error(t, err)
+	require.Equal(t, "debug", w.Current().Logger.Level)
+
+	unsubscribe()
+	require.NoError(t, os.WriteFile(path, []byte(minimalConfig), 0o600))
+	applied, _, err = w.Reload()
+	require.NoError(t, err)
+	require.NotEmpty(t, applied)
+	require.Len(t, received, 1)
+}
+
+func TestWatcher_FileChange(t *testing.T) {
+	path := writeConfig(t, minimalConfig)
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	w := NewWatcher(path, cfg, &testLogger{})
+	levels := make(chan string, 1)
+	w.Subscribe(func(cfg *Config) {
+		levels <- cfg.Logger.Level
+	})
+
+	require.NoError(t, w.Start(context.Background()))
+	defer func() {
+		require.NoError(t, w.Stop(context.Background()))
+	}()
+
+	require.NoError(t, os.WriteFile(path, []byte(minimalConfig+"logger:\n  Level: warn\n"), 0o600))
+	select {
+	case level := <-levels:
+		require.Equal(t, "warn", level)
+	case <-time.After(5 * time.Second):
+		t.Fatal("config change was not applied")
+	}
+}
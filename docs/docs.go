@@ -1,4 +1,5 @@
-// Package docs Code generated by swaggo/swag. DO NOT EDIT
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -9,30 +10,136 @@ const docTemplate = `{
     "info": {
         "description": "{{escape .Description}}",
         "title": "{{.Title}}",
-        "contact": {},
+        "contact": {
+            "name": "Doston Nematov (kei)",
+            "url": "https://github.com/Dostonlv",
+            "email": "dostonlv@icloud.com"
+        },
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/blogs": {
+        "/livez": {
             "get": {
-                "description": "Get all blog",
-                "consumes": [
+                "description": "process is running and able to serve requests",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "run dependency checks, returns 503 if any of them fails",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "content create, update and delete entries with actor, IP, request ID and before/after snapshots, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "blogs"
+                    "Audit"
                 ],
-                "summary": "Get blogs",
+                "summary": "Get audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "title",
-                        "name": "title",
+                        "description": "actor, admin:\u003ctoken hash\u003e, user:\u003cJWT subject\u003e, anonymous or system",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "client IP address",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "request ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete"
+                        ],
+                        "type": "string",
+                        "description": "action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "blog",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "entity",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, RFC 3339 time or date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created before, RFC 3339 time or date",
+                        "name": "to",
                         "in": "query"
                     },
                     {
@@ -54,89 +161,160 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.BlogsList"
+                            "$ref": "#/definitions/models.AuditList"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "create new blog",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/v1/admin/audit/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "stream audit entries matching filter as JSON lines, oldest first",
                 "produces": [
-                    "application/json"
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "blogs"
+                    "Audit"
                 ],
-                "summary": "Create new blog",
+                "summary": "Export audit log",
                 "parameters": [
                     {
-                        "description": "body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.BlogsSwagger"
-                        }
+                        "type": "string",
+                        "description": "actor, admin:\u003ctoken hash\u003e, user:\u003cJWT subject\u003e, anonymous or system",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "client IP address",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "request ID",
+                        "name": "request_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "update",
+                            "delete"
+                        ],
+                        "type": "string",
+                        "description": "action",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "blog",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "entity",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, RFC 3339 time or date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created before, RFC 3339 time or date",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Blog"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
                     }
                 }
             }
         },
-        "/blogs/{id}": {
+        "/v1/admin/log-level": {
             "get": {
-                "description": "Get blog by id",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "current log level, package overrides and time of automatic revert if change is time boxed",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "blogs"
-                ],
-                "summary": "Get blog",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Logging"
                 ],
+                "summary": "Get log level",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Blog"
+                            "$ref": "#/definitions/logger.LevelState"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
                     }
                 }
             },
             "put": {
-                "description": "update new blog",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace log level and package overrides of this instance, with duration previous levels are restored after it (max 24h)",
                 "consumes": [
                     "application/json"
                 ],
@@ -144,24 +322,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "blogs"
+                    "Logging"
                 ],
-                "summary": "Update blog",
+                "summary": "Set log level",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "body",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.BlogsSwagger"
+                            "$ref": "#/definitions/models.LogLevel"
                         }
                     }
                 ],
@@ -169,102 +340,52 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.BlogsSwagger"
+                            "$ref": "#/definitions/logger.LevelState"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
-                    }
-                }
-            },
-            "delete": {
-                "description": "delete blog",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "blogs"
-                ],
-                "summary": "Delete blog",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "ok",
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httpErrors.RestError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Health"
-                ],
-                "summary": "Health check endpoint",
-                "responses": {
-                    "200": {
-                        "description": "{\"status\": \"Healthy!\"}",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httpErrors.RestError"
                         }
                     }
                 }
             }
         },
-        "/news": {
+        "/v1/admin/webhooks": {
             "get": {
-                "description": "get all news",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "get all webhooks with pagination",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "Webhooks"
                 ],
-                "summary": "Get all news",
+                "summary": "Get all webhooks",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "title",
-                        "name": "title",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
-                        "description": "limit",
-                        "name": "limit",
+                        "format": "page",
+                        "description": "page number",
+                        "name": "page",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "offset",
-                        "name": "offset",
+                        "format": "size",
+                        "description": "number of elements per page",
+                        "name": "size",
                         "in": "query"
                     }
                 ],
@@ -272,17 +393,24 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.NewsList"
+                            "$ref": "#/definitions/models.WebhooksList"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
                     }
                 }
             },
             "post": {
-                "description": "create new news",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "subscribe endpoint to content lifecycle events, generated secret is returned only in this response",
                 "consumes": [
                     "application/json"
                 ],
@@ -290,9 +418,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "Webhooks"
                 ],
-                "summary": "Create new news",
+                "summary": "Create webhook",
                 "parameters": [
                     {
                         "description": "body",
@@ -300,7 +428,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.NewsSwagger"
+                            "$ref": "#/definitions/models.WebhookSwagger"
                         }
                     }
                 ],
@@ -308,55 +436,116 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.New"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
                     }
                 }
             }
         },
-        "/news/{id}": {
-            "get": {
-                "description": "get news by ID",
-                "consumes": [
-                    "application/json"
+        "/v1/admin/webhooks/deliveries/{delivery_id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "schedule new delivery of the same event payload",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "Webhooks"
                 ],
-                "summary": "Get news by ID",
+                "summary": "Redeliver webhook event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "news ID",
-                        "name": "id",
+                        "description": "delivery ID",
+                        "name": "delivery_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/models.New"
+                            "$ref": "#/definitions/models.WebhookDelivery"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get webhook by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
                         }
                     }
                 }
             },
             "put": {
-                "description": "update new news",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "replace url, events and active flag, activating disabled webhook resets its failures counter",
                 "consumes": [
                     "application/json"
                 ],
@@ -364,13 +553,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "Webhooks"
                 ],
-                "summary": "Update news",
+                "summary": "Update webhook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id",
+                        "description": "webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -381,7 +570,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.NewsSwagger"
+                            "$ref": "#/definitions/models.WebhookSwagger"
                         }
                     }
                 ],
@@ -389,55 +578,1897 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.NewsSwagger"
+                            "$ref": "#/definitions/models.Webhook"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {}
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "delete webhook together with its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get deliveries of webhook, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "page",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "size",
+                        "description": "number of elements per page",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDeliveriesList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/graphiql": {
+            "get": {
+                "description": "in-browser IDE for exploring the schema, disabled in production",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "GraphiQL playground",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/graphql": {
+            "post": {
+                "description": "execute query against blogs and news schema, depth and complexity are limited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "Execute GraphQL query",
+                "parameters": [
+                    {
+                        "description": "query",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/http.graphqlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.graphqlResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.graphqlResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/health": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "{\"status\": \"Healthy!\"}",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/news/stream": {
+            "get": {
+                "description": "push news.created, news.updated and news.deleted events over Server-Sent Events,\nor over WebSocket when upgrade is requested. Send Last-Event-ID header (or last_event_id param)\nto resume, \"reset\" event means events were missed and news should be reloaded.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "news"
+                ],
+                "summary": "Live news stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "comma separated tags, only news with any of them are streamed",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "resume after event ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Event"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/{type}": {
+            "get": {
+                "description": "get page of blogs or news, items are listed under the content type key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Get all content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "page",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "size",
+                        "description": "number of elements per page",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "orderBy",
+                        "description": "filter name",
+                        "name": "orderBy",
+                        "in": "query"
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NewsList"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create blog or news, body is sanitized and validated by rules of content type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Create content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NewsSwagger"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "client generated key, retries with the same key and body replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.New"
+                        }
+                    },
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "409": {
+                        "description": "key reused with different request or still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/{type}/export": {
+            "get": {
+                "description": "stream blogs or news as JSON lines or CSV ordered by creation time, admin only",
+                "produces": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Export content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ndjson (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "title contains",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma separated tags, records with any of them",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created at or after, RFC 3339 time or date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created before, RFC 3339 time or date",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.New"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/{type}/import": {
+            "post": {
+                "description": "create or update blogs or news by ID from JSON lines or CSV body, admin only.\nRecords are sanitized and validated as in create request, invalid lines are reported and skipped.",
+                "consumes": [
+                    "application/x-ndjson",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Import content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ndjson (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "validate and count without writing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "client generated key, retries with the same key and body replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "409": {
+                        "description": "key reused with different request or still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/{type}/{id}": {
+            "get": {
+                "description": "get blog or news by ID, localized by lang query or Accept-Language",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Get content by ID",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.New"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "update blog or news, revision is bumped when title or content changes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Update content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NewsSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.New"
+                        }
+                    },
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete blog or news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Delete content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/{type}/{id}/translations": {
+            "get": {
+                "description": "translation status of blog or news in every supported locale: current, outdated (made from older revision) or missing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Get content translations",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationsList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/{type}/{id}/translations/{locale}": {
+            "put": {
+                "description": "translation is marked as made from current revision of blog or news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content"
+                ],
+                "summary": "Create or replace content translation",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "supported locale other than the source one",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Translation"
+                        }
+                    },
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "requests for the locale fall back along the locale chain",
+                "tags": [
+                    "content"
+                ],
+                "summary": "Delete content translation",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{type}": {
+            "get": {
+                "description": "get page of blogs or news, links keep title and lang filters",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content v2"
+                ],
+                "summary": "Get all content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "page",
+                        "description": "page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "size",
+                        "description": "number of elements per page",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "orderBy",
+                        "description": "filter name",
+                        "name": "orderBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred locale, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred locales",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "meta.page and first, prev, next and last links describe pagination",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.New"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create blog or news, Location header points to created item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content v2"
+                ],
+                "summary": "Create content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NewsSwagger"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "client generated key, retries with the same key and body replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.New"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "created item"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "409": {
+                        "description": "key reused with different request or still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{type}/{id}": {
+            "get": {
+                "description": "get blog or news by ID, localized by lang query or Accept-Language",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content v2"
+                ],
+                "summary": "Get content by ID",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred locale, overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "preferred locales",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.New"
+                                        }
+                                    }
+                                }
+                            ]
+                        },
+                        "headers": {
+                            "Content-Language": {
+                                "type": "string",
+                                "description": "locale of title and content"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "update blog or news, revision is bumped when title or content changes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content v2"
+                ],
+                "summary": "Update content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NewsSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.New"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete blog or news",
+                "tags": [
+                    "content v2"
+                ],
+                "summary": "Delete content",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{type}/{id}/translations": {
+            "get": {
+                "description": "translation status of blog or news in every supported locale: current, outdated (made from older revision) or missing",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content v2"
+                ],
+                "summary": "Get content translations",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.TranslationsList"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/{type}/{id}/translations/{locale}": {
+            "put": {
+                "description": "translation is marked as made from current revision of blog or news",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "content v2"
+                ],
+                "summary": "Create or replace content translation",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "supported locale other than the source one",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TranslationSwagger"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.Envelope"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Translation"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "validation failed, fields lists every failed rule",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "requests for the locale fall back along the locale chain",
+                "tags": [
+                    "content v2"
+                ],
+                "summary": "Delete content translation",
+                "parameters": [
+                    {
+                        "enum": [
+                            "blogs",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "locale",
+                        "name": "locale",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.RestError"
+                        }
+                    },
+                    "default": {
+                        "description": "same error as RFC 7807 problem details when Accept is application/problem+json",
+                        "schema": {
+                            "$ref": "#/definitions/httpErrors.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "version, commit and build time of running binary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Build info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/buildinfo.Info"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "buildinfo.Info": {
+            "type": "object",
+            "properties": {
+                "build_time": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "go_version": {
+                    "type": "string"
+                },
+                "modified": {
+                    "type": "boolean"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.graphqlError": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "http.graphqlRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "http.graphqlResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/http.graphqlError"
+                    }
+                }
+            }
+        },
+        "httpErrors.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "param": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpErrors.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpErrors.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpErrors.RestError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpErrors.FieldError"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "logger.LevelState": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string"
+                },
+                "overrides": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "revertAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditList": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditEntry"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Envelope": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "item or list of items"
+                },
+                "links": {
+                    "description": "links to the item or pages of the list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Links"
+                        }
+                    ]
+                },
+                "meta": {
+                    "description": "request metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Meta"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.HealthCheck": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.HealthCheck"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportLineError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportLineError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Links": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "type": "string",
+                    "example": "/v2/news?page=1\u0026size=10"
+                },
+                "last": {
+                    "type": "string",
+                    "example": "/v2/news?page=5\u0026size=10"
+                },
+                "next": {
+                    "description": "omitted on last page",
+                    "type": "string",
+                    "example": "/v2/news?page=3\u0026size=10"
+                },
+                "prev": {
+                    "description": "omitted on first page",
+                    "type": "string",
+                    "example": "/v2/news?page=1\u0026size=10"
+                },
+                "self": {
+                    "type": "string",
+                    "example": "/v2/news?page=2\u0026size=10"
                 }
-            },
-            "delete": {
-                "description": "deleted news",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "news"
-                ],
-                "summary": "Delete news",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "news ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+            }
+        },
+        "models.LogLevel": {
+            "type": "object",
+            "required": [
+                "level",
+                "overrides"
+            ],
+            "properties": {
+                "duration": {
+                    "type": "string",
+                    "example": "15m"
+                },
+                "level": {
+                    "type": "string",
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn",
+                        "error",
+                        "dpanic",
+                        "panic",
+                        "fatal"
+                    ]
+                },
+                "overrides": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
+                }
+            }
+        },
+        "models.Meta": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "description": "lists only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PageMeta"
                         }
-                    }
+                    ]
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "5f0c9a8e-2b1d-4a3c-9e7f-1d2c3b4a5e6f"
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Blog": {
+        },
+        "models.New": {
             "type": "object",
             "required": [
                 "content",
@@ -454,21 +2485,31 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "locale": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
                     "type": "string",
                     "minLength": 3
+                },
+                "translation_outdated": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.BlogsList": {
+        "models.NewsList": {
             "type": "object",
             "properties": {
-                "blogs": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Blog"
-                    }
-                },
                 "has_more": {
                     "type": "boolean"
                 },
@@ -486,7 +2527,7 @@ const docTemplate = `{
                 }
             }
         },
-        "models.BlogsSwagger": {
+        "models.NewsSwagger": {
             "type": "object",
             "required": [
                 "content",
@@ -497,13 +2538,37 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 10
                 },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
                     "type": "string",
                     "minLength": 3
                 }
             }
         },
-        "models.New": {
+        "models.PageMeta": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Translation": {
             "type": "object",
             "required": [
                 "content",
@@ -514,30 +2579,133 @@ const docTemplate = `{
                     "type": "string",
                     "minLength": 10
                 },
-                "created_at": {
+                "entity_id": {
                     "type": "string"
                 },
-                "id": {
+                "locale": {
                     "type": "string"
                 },
+                "source_revision": {
+                    "type": "integer"
+                },
                 "title": {
                     "type": "string",
                     "minLength": 3
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "models.NewsList": {
+        "models.TranslationStatus": {
             "type": "object",
             "properties": {
-                "has_more": {
+                "locale": {
+                    "type": "string"
+                },
+                "source_revision": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "translation": {
+                    "$ref": "#/definitions/models.Translation"
+                }
+            }
+        },
+        "models.TranslationSwagger": {
+            "type": "object",
+            "required": [
+                "content",
+                "title"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "minLength": 10
+                },
+                "title": {
+                    "type": "string",
+                    "minLength": 3
+                }
+            }
+        },
+        "models.TranslationsList": {
+            "type": "object",
+            "properties": {
+                "entity_id": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "source_locale": {
+                    "type": "string"
+                },
+                "translations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TranslationStatus"
+                    }
+                }
+            }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "active": {
                     "type": "boolean"
                 },
-                "news": {
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failure_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 16
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "models.WebhookDeliveriesList": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.New"
+                        "$ref": "#/definitions/models.WebhookDelivery"
                     }
                 },
+                "has_more": {
+                    "type": "boolean"
+                },
                 "page": {
                     "type": "integer"
                 },
@@ -552,20 +2720,115 @@ const docTemplate = `{
                 }
             }
         },
-        "models.NewsSwagger": {
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "redelivery_of": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookSwagger": {
             "type": "object",
             "required": [
-                "content",
-                "title"
+                "events",
+                "url"
             ],
             "properties": {
-                "content": {
+                "active": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
                     "type": "string",
-                    "minLength": 10
+                    "maxLength": 128,
+                    "minLength": 16
                 },
-                "title": {
+                "url": {
                     "type": "string",
-                    "minLength": 3
+                    "maxLength": 2048
+                }
+            }
+        },
+        "models.WebhooksList": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Webhook"
+                    }
+                }
+            }
+        },
+        "stream.Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         }
@@ -574,12 +2837,12 @@ const docTemplate = `{
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "",
+	Version:          "1.0",
 	Host:             "",
-	BasePath:         "",
+	BasePath:         "/",
 	Schemes:          []string{},
-	Title:            "",
-	Description:      "",
+	Title:            "Blog and News API.",
+	Description:      "Blog and News API Server.\nErrors are returned as RestError, clients sending Accept: application/problem+json get RFC 7807 problem details.\nFailed validation lists every failed rule in fields, messages follow Accept-Language.\n/v1 is frozen, /v2 wraps every response in a {data, meta, links} envelope, answers delete with 204 and create with Location.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 }
@@ -0,0 +1,35 @@
+package audit
+
+import "context"
+
+// Actor names of requests without user identity
+const (
+	// ActorAdmin request authorized with admin token
+	ActorAdmin = "admin"
+	// ActorAnonymous request without credentials
+	ActorAnonymous = "anonymous"
+	// ActorSystem change made outside of request
+	ActorSystem = "system"
+)
+
+type ctxKey struct{}
+
+// Actor who made the change, stored in request context by delivery layer
+type Actor struct {
+	Name      string
+	IP        string
+	RequestID string
+}
+
+// WithActor context carrying actor of changes made with it
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, ctxKey{}, actor)
+}
+
+// ActorFromContext actor stored in context, system actor when there is none
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(ctxKey{}).(Actor); ok {
+		return actor
+	}
+	return Actor{Name: ActorSystem}
+}
@@ -0,0 +1,9 @@
+package audit
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Audit HTTP Handlers interface
+type Handlers interface {
+	GetAll() echo.HandlerFunc
+	Export() echo.HandlerFunc
+}
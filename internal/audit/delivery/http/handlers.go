@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/audit"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// audit handlers
+type auditHandlers struct {
+	cfg     *config.Config
+	auditUC audit.UseCase
+	logger  logger.Logger
+}
+
+// NewAuditHandlers Audit handlers constructor
+func NewAuditHandlers(cfg *config.Config, auditUC audit.UseCase, logger logger.Logger) audit.Handlers {
+	return &auditHandlers{cfg: cfg, auditUC: auditUC, logger: logger}
+}
+
+// GetAll
+// @Summary Get audit log
+// @Description content create, update and delete entries with actor, IP, request ID and before/after snapshots, newest first
+// @Tags Audit
+// @Produce json
+// @Param actor query string false "actor, admin:<token hash>, user:<JWT subject>, anonymous or system"
+// @Param ip query string false "client IP address"
+// @Param request_id query string false "request ID"
+// @Param action query string false "action" Enums(create, update, delete)
+// @Param entity query string false "entity" Enums(blog, news)
+// @Param entity_id query string false "entity ID"
+// @Param from query string false "created at or after, RFC 3339 time or date"
+// @Param to query string false "created before, RFC 3339 time or date"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.AuditList
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/audit [get]
+func (h *auditHandlers) GetAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter, err := models.ParseAuditFilter(c.QueryParams())
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, appErrors.Wrap(err, appErrors.CodeBadRequest, err.Error()))
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		entries, err := h.auditUC.GetAll(c.Request().Context(), filter, pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, entries)
+	}
+}
+
+// Export
+// @Summary Export audit log
+// @Description stream audit entries matching filter as JSON lines, oldest first
+// @Tags Audit
+// @Produce application/x-ndjson
+// @Param actor query string false "actor, admin:<token hash>, user:<JWT subject>, anonymous or system"
+// @Param ip query string false "client IP address"
+// @Param request_id query string false "request ID"
+// @Param action query string false "action" Enums(create, update, delete)
+// @Param entity query string false "entity" Enums(blog, news)
+// @Param entity_id query string false "entity ID"
+// @Param from query string false "created at or after, RFC 3339 time or date"
+// @Param to query string false "created before, RFC 3339 time or date"
+// @Success 200 {array} models.AuditEntry
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/audit/export [get]
+func (h *auditHandlers) Export() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter, err := models.ParseAuditFilter(c.QueryParams())
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, appErrors.Wrap(err, appErrors.CodeBadRequest, err.Error()))
+		}
+
+		res := c.Response()
+		// server write timeout would cut large export
+		if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil {
+			return errors.Wrap(err, "auditHandlers.Export.SetWriteDeadline")
+		}
+		res.Header().Set(echo.HeaderContentType, transfer.FormatNDJSON.ContentType())
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit.ndjson"`)
+		res.WriteHeader(http.StatusOK)
+
+		// status is already sent, failed export shows up as truncated body
+		if _, err := h.auditUC.Export(c.Request().Context(), filter, res); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+		}
+		return nil
+	}
+}
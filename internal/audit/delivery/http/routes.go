@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/Dostonlv/task-del/internal/audit"
+	"github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Map audit log admin routes
+func MapAuditRoutes(auditGroup *echo.Group, h audit.Handlers, mw *middleware.MiddlewareManager) {
+	auditGroup.Use(mw.AdminAuthMiddleware)
+	auditGroup.GET("", h.GetAll())
+	auditGroup.GET("/export", h.Export())
+}
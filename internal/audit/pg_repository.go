@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/utils"
+)
+
+// Repository Audit repository interface, entries are added in transaction of the change, see repository.AddEntry
+type Repository interface {
+	GetAll(ctx context.Context, filter *models.AuditFilter, query *utils.PaginationQuery) (*models.AuditList, error)
+	Stream(ctx context.Context, filter *models.AuditFilter, fn func(entry *models.AuditEntry) error) error
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+)
+
+const (
+	// actorUserPrefix actor name prefix of requests authenticated with JWT
+	actorUserPrefix = "user:"
+	// maxActorLength length of audit_log actor column
+	maxActorLength = 64
+	// fingerprintLength hex characters of hash identifying credentials in actor name
+	fingerprintLength = 12
+)
+
+// AdminActor actor name of requests with admin token, hash of the token tells rotated tokens apart without storing them
+func AdminActor(token string) string {
+	return ActorAdmin + ":" + fingerprint(token)
+}
+
+// UserActor actor name of requests with JWT signed by secret, subject identifies the user.
+// Subjects too long for the actor column are replaced with their hash.
+func UserActor(token string, secret string) (string, bool) {
+	if token == "" || secret == "" {
+		return "", false
+	}
+
+	claims := &jwt.StandardClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil || !parsed.Valid || claims.Subject == "" {
+		return "", false
+	}
+
+	if len(actorUserPrefix)+len(claims.Subject) > maxActorLength {
+		return actorUserPrefix + "#" + fingerprint(claims.Subject), true
+	}
+	return actorUserPrefix + claims.Subject, true
+}
+
+func fingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:fingerprintLength]
+}
@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "jwt-secret"
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.StandardClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestAdminActor(t *testing.T) {
+	t.Parallel()
+
+	// token is identified by hash, never stored
+	actor := AdminActor("admin-token")
+	require.True(t, strings.HasPrefix(actor, "admin:"))
+	require.Len(t, actor, len("admin:")+fingerprintLength)
+	require.NotContains(t, actor, "admin-token")
+	require.Equal(t, actor, AdminActor("admin-token"))
+	require.NotEqual(t, actor, AdminActor("rotated-token"))
+}
+
+func TestUserActor(t *testing.T) {
+	t.Parallel()
+
+	valid := jwt.StandardClaims{Subject: "user-1", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	longSubject := strings.Repeat("s", maxActorLength)
+
+	tests := []struct {
+		name   string
+		token  string
+		secret string
+		actor  string
+	}{
+		{name: "valid", token: signToken(t, jwt.SigningMethodHS256, []byte(testSecret), valid), secret: testSecret, actor: "user:user-1"},
+		{name: "wrong secret", token: signToken(t, jwt.SigningMethodHS256, []byte("other"), valid), secret: testSecret},
+		{name: "no secret", token: signToken(t, jwt.SigningMethodHS256, []byte(""), valid)},
+		{
+			name:   "expired",
+			token:  signToken(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.StandardClaims{Subject: "user-1", ExpiresAt: time.Now().Add(-time.Hour).Unix()}),
+			secret: testSecret,
+		},
+		{name: "no subject", token: signToken(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.StandardClaims{}), secret: testSecret},
+		{name: "unsigned", token: signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid), secret: testSecret},
+		{name: "not JWT", token: "admin-token", secret: testSecret},
+		{
+			// subject too long for actor column is hashed
+			name:   "long subject",
+			token:  signToken(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.StandardClaims{Subject: longSubject}),
+			secret: testSecret,
+			actor:  "user:#" + fingerprint(longSubject),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actor, ok := UserActor(tt.token, tt.secret)
+			require.Equal(t, tt.actor != "", ok)
+			require.Equal(t, tt.actor, actor)
+			require.LessOrEqual(t, len(actor), maxActorLength)
+		})
+	}
+}
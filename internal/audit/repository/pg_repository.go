@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Dostonlv/task-del/internal/audit"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// maxColumnLength length of actor, ip and request_id columns
+const maxColumnLength = 64
+
+const (
+	addEntryQuery = `INSERT INTO audit_log (actor, ip, request_id, action, entity, entity_id, before, after) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	entryColumns  = `id, actor, ip, request_id, action, entity, entity_id, before, after, created_at`
+)
+
+// AddEntry write audit entry of change made by actor of ctx, tx must be the transaction of the change.
+// Before is nil for created entity, after is nil for deleted one.
+func AddEntry(ctx context.Context, tx sqlx.ExecerContext, action string, entity string, entityID uuid.UUID, before, after interface{}) error {
+	beforeSnapshot, err := models.NewAuditSnapshot(before)
+	if err != nil {
+		return errors.Wrap(err, "audit.AddEntry.before")
+	}
+	afterSnapshot, err := models.NewAuditSnapshot(after)
+	if err != nil {
+		return errors.Wrap(err, "audit.AddEntry.after")
+	}
+
+	// IP and request ID may come from client headers, oversized values must not fail the change
+	actor := audit.ActorFromContext(ctx)
+	if _, err := tx.ExecContext(ctx, addEntryQuery, truncate(actor.Name), truncate(actor.IP), truncate(actor.RequestID),
+		action, entity, entityID, beforeSnapshot, afterSnapshot); err != nil {
+		return errors.Wrap(err, "audit.AddEntry.ExecContext")
+	}
+
+	return nil
+}
+
+// truncate valid UTF-8 prefix of value fitting into actor, ip and request_id columns
+func truncate(value string) string {
+	value = strings.ToValidUTF8(value, "")
+	if utf8.RuneCountInString(value) <= maxColumnLength {
+		return value
+	}
+	return string([]rune(value)[:maxColumnLength])
+}
+
+// audit Repository, audit_log rows are only inserted
+type auditRepo struct {
+	db *sqlx.DB
+}
+
+// NewAuditRepository Audit Repository constructor
+func NewAuditRepository(db *sqlx.DB) audit.Repository {
+	return &auditRepo{db: db}
+}
+
+// GetAll audit entries page matching filter, newest first
+func (r *auditRepo) GetAll(ctx context.Context, filter *models.AuditFilter, query *utils.PaginationQuery) (*models.AuditList, error) {
+	where, args := filterConditions(filter)
+	getTotalCount := `SELECT COUNT(id) FROM audit_log` + where
+	getEntries := fmt.Sprintf(`SELECT %s FROM audit_log%s ORDER BY id DESC OFFSET $%d LIMIT $%d`, entryColumns, where, len(args)+1, len(args)+2)
+
+	ctx, span := tracing.StartSQLSpan(ctx, "auditRepo.GetAll", getEntries)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getTotalCount, args...); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, "auditRepo.GetAll.GetContext.totalCount"))
+	}
+
+	logger.FromContext(ctx).Debugf("auditRepo.GetAll, Page: %d, Size: %d, TotalCount: %d", query.GetPage(), query.GetSize(), totalCount)
+
+	entries := make([]*models.AuditEntry, 0, query.GetSize())
+	if totalCount > 0 {
+		if err := r.db.SelectContext(ctx, &entries, getEntries, append(args, query.GetOffset(), query.GetLimit())...); err != nil {
+			return nil, tracing.RecordError(span, errors.Wrap(err, "auditRepo.GetAll.SelectContext"))
+		}
+	}
+
+	return &models.AuditList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		Entries:    entries,
+	}, nil
+}
+
+// Stream audit entries matching filter oldest first, rows are passed to fn one by one as they are read
+func (r *auditRepo) Stream(ctx context.Context, filter *models.AuditFilter, fn func(entry *models.AuditEntry) error) error {
+	where, args := filterConditions(filter)
+	stream := fmt.Sprintf(`SELECT %s FROM audit_log%s ORDER BY id`, entryColumns, where)
+
+	ctx, span := tracing.StartSQLSpan(ctx, "auditRepo.Stream", stream)
+	defer span.End()
+
+	rows, err := r.db.QueryxContext(ctx, stream, args...)
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "auditRepo.Stream.QueryxContext"))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := &models.AuditEntry{}
+		if err := rows.StructScan(entry); err != nil {
+			return tracing.RecordError(span, errors.Wrap(err, "auditRepo.Stream.StructScan"))
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "auditRepo.Stream.rows.Err"))
+	}
+
+	return nil
+}
+
+// filterConditions WHERE clause of filter with its args, empty when filter matches everything
+func filterConditions(filter *models.AuditFilter) (string, []interface{}) {
+	conditions := make([]string, 0, 8)
+	args := make([]interface{}, 0, 8)
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Actor != "" {
+		add("actor = $%d", filter.Actor)
+	}
+	if filter.IP != "" {
+		add("ip = $%d", filter.IP)
+	}
+	if filter.RequestID != "" {
+		add("request_id = $%d", filter.RequestID)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.Entity != "" {
+		add("entity = $%d", filter.Entity)
+	}
+	if filter.EntityID != nil {
+		add("entity_id = $%d", *filter.EntityID)
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at < $%d", *filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/internal/audit"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditRepo_GetAll(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewAuditRepository(sqlxDB)
+
+	newsID := uuid.New()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := &models.AuditFilter{Action: models.AuditActionDelete, Entity: "news", EntityID: &newsID, From: &from}
+
+	mock.ExpectQuery(`SELECT COUNT(id) FROM audit_log WHERE action = $1 AND entity = $2 AND entity_id = $3 AND created_at >= $4`).
+		WithArgs(models.AuditActionDelete, "news", newsID, from).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT id, actor, ip, request_id, action, entity, entity_id, before, after, created_at FROM audit_log WHERE action = $1 AND entity = $2 AND entity_id = $3 AND created_at >= $4 ORDER BY id DESC OFFSET $5 LIMIT $6`).
+		WithArgs(models.AuditActionDelete, "news", newsID, from, 0, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "actor", "ip", "request_id", "action", "entity", "entity_id", "before", "after", "created_at"}).
+			AddRow(7, "admin", "10.0.0.1", "request-id", models.AuditActionDelete, "news", newsID, []byte(`{"title":"title"}`), nil, time.Now()))
+
+	list, err := repo.GetAll(context.Background(), filter, &utils.PaginationQuery{Size: 10, Page: 1})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.TotalCount)
+	require.Len(t, list.Entries, 1)
+	require.Equal(t, "admin", list.Entries[0].Actor)
+	require.Equal(t, models.AuditSnapshot(`{"title":"title"}`), list.Entries[0].Before)
+	require.Nil(t, list.Entries[0].After)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddEntry(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	// oversized request ID from client header is cut to column length instead of failing the change
+	newsID := uuid.New()
+	requestID := strings.Repeat("r", 100)
+	ctx := audit.WithActor(context.Background(), audit.Actor{Name: audit.ActorAnonymous, IP: "192.0.2.1", RequestID: requestID})
+
+	mock.ExpectExec(addEntryQuery).
+		WithArgs(audit.ActorAnonymous, "192.0.2.1", requestID[:maxColumnLength], models.AuditActionCreate, "news", newsID, nil, []byte(`{"title":"title"}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, AddEntry(ctx, sqlxDB, models.AuditActionCreate, "news", newsID, nil, map[string]string{"title": "title"}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "request-id", truncate("request-id"))
+	require.Equal(t, strings.Repeat("я", maxColumnLength), truncate(strings.Repeat("я", maxColumnLength+1)))
+	require.Equal(t, "request-id", truncate("request-\xffid"))
+}
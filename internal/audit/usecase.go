@@ -0,0 +1,15 @@
+package audit
+
+import (
+	"context"
+	"io"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/utils"
+)
+
+// UseCase Audit use case interface
+type UseCase interface {
+	GetAll(ctx context.Context, filter *models.AuditFilter, query *utils.PaginationQuery) (*models.AuditList, error)
+	Export(ctx context.Context, filter *models.AuditFilter, w io.Writer) (int, error)
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/audit"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+// audit use case
+type auditUC struct {
+	cfg       *config.Config
+	auditRepo audit.Repository
+	logger    logger.Logger
+}
+
+// NewAuditUseCase audit use case constructor
+func NewAuditUseCase(cfg *config.Config, auditRepo audit.Repository, logger logger.Logger) audit.UseCase {
+	return &auditUC{cfg: cfg, auditRepo: auditRepo, logger: logger}
+}
+
+// GetAll audit entries page matching filter, newest first
+func (u *auditUC) GetAll(ctx context.Context, filter *models.AuditFilter, query *utils.PaginationQuery) (*models.AuditList, error) {
+	ctx, span := tracing.StartSpan(ctx, "auditUC.GetAll")
+	defer span.End()
+
+	return u.auditRepo.GetAll(ctx, filter, query)
+}
+
+// Export write audit entries matching filter to w as JSON lines, oldest first
+func (u *auditUC) Export(ctx context.Context, filter *models.AuditFilter, w io.Writer) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, "auditUC.Export")
+	defer span.End()
+
+	encoder := transfer.NewEncoder(w, transfer.FormatNDJSON, nil)
+	exported := 0
+	if err := u.auditRepo.Stream(ctx, filter, func(entry *models.AuditEntry) error {
+		exported++
+		return encoder.Encode(entry)
+	}); err != nil {
+		return exported, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return exported, errors.Wrap(err, "auditUC.Export.Flush")
+	}
+
+	u.logger.FromContext(ctx).Infof("Audit log exported, Count: %d", exported)
+	return exported, nil
+}
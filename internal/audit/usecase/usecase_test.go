@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/audit/mock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditUC_Export(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	auditUC := NewAuditUseCase(&config.Config{}, mockRepo, logger.NewApiLogger(nil))
+
+	newsID := uuid.New()
+	filter := &models.AuditFilter{Entity: "news", EntityID: &newsID}
+	entries := []*models.AuditEntry{
+		{ID: 1, Actor: "admin", Action: models.AuditActionCreate, Entity: "news", EntityID: newsID, After: models.AuditSnapshot(`{"title":"title"}`)},
+		{ID: 2, Actor: "anonymous", IP: "10.0.0.1", Action: models.AuditActionDelete, Entity: "news", EntityID: newsID, Before: models.AuditSnapshot(`{"title":"title"}`)},
+	}
+	mockRepo.EXPECT().Stream(gomock.Any(), filter, gomock.Any()).DoAndReturn(func(ctx context.Context, f *models.AuditFilter, fn func(entry *models.AuditEntry) error) error {
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	var buf bytes.Buffer
+	exported, err := auditUC.Export(context.Background(), filter, &buf)
+	require.NoError(t, err)
+	require.Equal(t, 2, exported)
+
+	// one JSON document per line, snapshots are embedded as is
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var deleted map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &deleted))
+	require.Equal(t, "anonymous", deleted["actor"])
+	require.Equal(t, "10.0.0.1", deleted["ip"])
+	require.Equal(t, map[string]interface{}{"title": "title"}, deleted["before"])
+	require.Nil(t, deleted["after"])
+}
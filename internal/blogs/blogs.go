@@ -0,0 +1,69 @@
+package blogs
+
+import (
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/content"
+	contentHttp "github.com/Dostonlv/task-del/internal/content/delivery/http"
+	"github.com/Dostonlv/task-del/internal/content/repository"
+	"github.com/Dostonlv/task-del/internal/content/usecase"
+	"github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+)
+
+// Type blogs content type
+var Type = &content.Type{
+	Name:              "blog",
+	Plural:            "blogs",
+	Table:             "blogs",
+	TranslationsTable: "blog_translations",
+	TranslationsKey:   "blog_id",
+	Aggregate:         models.AggregateBlog,
+	EventCreated:      models.EventBlogCreated,
+	EventUpdated:      models.EventBlogUpdated,
+	EventDeleted:      models.EventBlogDeleted,
+}
+
+// Repository Blogs repository interface
+type Repository = content.Repository[models.Blog]
+
+// UseCase Blogs use case interface
+type UseCase = content.UseCase[models.Blog]
+
+// Handlers Blogs HTTP Handlers interface
+type Handlers = content.Handlers
+
+// HandlersV2 Blogs HTTP v2 Handlers interface
+type HandlersV2 = content.HandlersV2
+
+// NewRepository Blogs repository constructor
+func NewRepository(db *sqlx.DB) Repository {
+	return repository.NewRepository[models.Blog](db, Type)
+}
+
+// NewUseCase Blogs use case constructor
+func NewUseCase(cfg *config.Config, repo Repository, logger logger.Logger) UseCase {
+	return usecase.NewUseCase[models.Blog](cfg, Type, repo, logger)
+}
+
+// NewHandlers Blogs handlers constructor
+func NewHandlers(cfg *config.Config, uc UseCase, logger logger.Logger) Handlers {
+	return contentHttp.NewHandlers[models.Blog](cfg, Type, uc, logger)
+}
+
+// NewHandlersV2 Blogs v2 handlers constructor
+func NewHandlersV2(cfg *config.Config, uc UseCase, logger logger.Logger) HandlersV2 {
+	return contentHttp.NewHandlersV2[models.Blog](cfg, Type, uc, logger)
+}
+
+// MapRoutes Map blogs routes
+func MapRoutes(group *echo.Group, h Handlers, mw *middleware.MiddlewareManager) {
+	contentHttp.MapRoutes(group, h, mw)
+}
+
+// MapRoutesV2 Map blogs v2 routes
+func MapRoutesV2(group *echo.Group, h HandlersV2, mw *middleware.MiddlewareManager) {
+	contentHttp.MapRoutesV2(group, h, mw)
+}
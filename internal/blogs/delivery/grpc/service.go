@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/blogs"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/grpcErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/sanitize"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	blogsService "github.com/Dostonlv/task-del/proto/blogs"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// blogs gRPC service
+type blogsMicroservice struct {
+	blogsService.UnimplementedBlogsServiceServer
+	cfg    *config.Config
+	blogUC blogs.UseCase
+	logger logger.Logger
+}
+
+// NewBlogsMicroservice Blogs gRPC service constructor
+func NewBlogsMicroservice(cfg *config.Config, blogsUC blogs.UseCase, logger logger.Logger) blogsService.BlogsServiceServer {
+	return &blogsMicroservice{cfg: cfg, blogUC: blogsUC, logger: logger}
+}
+
+// Create blog
+func (s *blogsMicroservice) Create(ctx context.Context, r *blogsService.CreateBlogRequest) (*blogsService.CreateBlogResponse, error) {
+	blog := &models.Blog{
+		Entry: models.Entry{
+			Title:   sanitize.SanitizeString(r.GetTitle()),
+			Content: sanitize.SanitizeString(r.GetContent()),
+			Tags:    sanitize.SanitizeStrings(r.GetTags()),
+		},
+	}
+	if err := utils.ValidateStruct(ctx, blog); err != nil {
+		return nil, s.errResponse(ctx, "Create", err)
+	}
+
+	createdBlog, err := s.blogUC.Create(ctx, blog)
+	if err != nil {
+		return nil, s.errResponse(ctx, "Create", err)
+	}
+
+	return &blogsService.CreateBlogResponse{Blog: blogToProto(createdBlog)}, nil
+}
+
+// Update blog
+func (s *blogsMicroservice) Update(ctx context.Context, r *blogsService.UpdateBlogRequest) (*blogsService.UpdateBlogResponse, error) {
+	blogID, err := utils.ParseID(r.GetId())
+	if err != nil {
+		return nil, s.errResponse(ctx, "Update", err)
+	}
+
+	blog := &models.Blog{
+		Entry: models.Entry{
+			ID:      blogID,
+			Title:   sanitize.SanitizeString(r.GetTitle()),
+			Content: sanitize.SanitizeString(r.GetContent()),
+			Tags:    sanitize.SanitizeStrings(r.GetTags()),
+		},
+	}
+	if err = utils.ValidateStruct(ctx, blog); err != nil {
+		return nil, s.errResponse(ctx, "Update", err)
+	}
+
+	updatedBlog, err := s.blogUC.Update(ctx, blog)
+	if err != nil {
+		return nil, s.errResponse(ctx, "Update", err)
+	}
+
+	return &blogsService.UpdateBlogResponse{Blog: blogToProto(updatedBlog)}, nil
+}
+
+// Delete blog
+func (s *blogsMicroservice) Delete(ctx context.Context, r *blogsService.DeleteBlogRequest) (*blogsService.DeleteBlogResponse, error) {
+	blogID, err := utils.ParseID(r.GetId())
+	if err != nil {
+		return nil, s.errResponse(ctx, "Delete", err)
+	}
+
+	if err = s.blogUC.Delete(ctx, blogID); err != nil {
+		return nil, s.errResponse(ctx, "Delete", err)
+	}
+
+	return &blogsService.DeleteBlogResponse{}, nil
+}
+
+// GetByID blog
+func (s *blogsMicroservice) GetByID(ctx context.Context, r *blogsService.GetBlogByIDRequest) (*blogsService.GetBlogByIDResponse, error) {
+	blogID, err := utils.ParseID(r.GetId())
+	if err != nil {
+		return nil, s.errResponse(ctx, "GetByID", err)
+	}
+
+	blog, err := s.blogUC.GetByID(ctx, blogID)
+	if err != nil {
+		return nil, s.errResponse(ctx, "GetByID", err)
+	}
+
+	return &blogsService.GetBlogByIDResponse{Blog: blogToProto(blog)}, nil
+}
+
+// GetAll blogs
+func (s *blogsMicroservice) GetAll(ctx context.Context, r *blogsService.GetBlogsRequest) (*blogsService.GetBlogsResponse, error) {
+	pq := utils.NewPaginationQuery(int(r.GetPage()), int(r.GetSize()), r.GetOrderBy())
+
+	blogsList, err := s.blogUC.GetAll(ctx, r.GetTitle(), pq)
+	if err != nil {
+		return nil, s.errResponse(ctx, "GetAll", err)
+	}
+
+	res := &blogsService.GetBlogsResponse{
+		TotalCount: int64(blogsList.TotalCount),
+		TotalPages: int64(blogsList.TotalPages),
+		Page:       int64(blogsList.Page),
+		Size:       int64(blogsList.Size),
+		HasMore:    blogsList.HasMore,
+		Blogs:      make([]*blogsService.Blog, 0, len(blogsList.Items)),
+	}
+	for _, blog := range blogsList.Items {
+		res.Blogs = append(res.Blogs, blogToProto(blog))
+	}
+
+	return res, nil
+}
+
+func (s *blogsMicroservice) errResponse(ctx context.Context, method string, err error) error {
+	s.logger.FromContext(ctx).Errorf("blogsMicroservice.%s: %s", method, err)
+	return grpcErrors.ErrorResponse(ctx, err)
+}
+
+func blogToProto(blog *models.Blog) *blogsService.Blog {
+	return &blogsService.Blog{
+		Id:        blog.ID.String(),
+		Title:     blog.Title,
+		Content:   blog.Content,
+		Tags:      blog.Tags,
+		CreatedAt: timestamppb.New(blog.CreatedAt),
+	}
+}
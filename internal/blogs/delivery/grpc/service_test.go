@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/Dostonlv/task-del/internal/content/mock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	blogsService "github.com/Dostonlv/task-del/proto/blogs"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestBlogsMicroservice_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// logger, use case, service of blog
+	logger := logger.NewApiLogger(nil)
+	mockBlogUC := mock.NewMockUseCase[models.Blog](ctrl)
+	service := NewBlogsMicroservice(nil, mockBlogUC, logger)
+
+	blogID := uuid.New()
+
+	// Create a blog success case
+	t.Run("Create", func(t *testing.T) {
+		mockBlogUC.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&models.Blog{
+			Entry: models.Entry{
+				ID:      blogID,
+				Title:   "test-title",
+				Content: "test-content",
+			},
+		}, nil)
+
+		res, err := service.Create(context.Background(), &blogsService.CreateBlogRequest{
+			Title:   "test-title",
+			Content: "test-content",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, blogID.String(), res.GetBlog().GetId())
+		require.Equal(t, "test-title", res.GetBlog().GetTitle())
+	})
+
+	// Create blog validation error case, use case is not called
+	t.Run("Create Validation Error", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "uz"))
+		res, err := service.Create(ctx, &blogsService.CreateBlogRequest{
+			Title:   "t",
+			Content: "short",
+		})
+
+		require.Nil(t, res)
+		st := status.Convert(err)
+		require.Equal(t, codes.InvalidArgument, st.Code())
+
+		details := st.Details()
+		require.Len(t, details, 3)
+		badRequest, ok := details[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		require.Len(t, badRequest.GetFieldViolations(), 2)
+		require.Equal(t, "title", badRequest.GetFieldViolations()[0].GetField())
+		require.Equal(t, "title maydoni kamida 3 ta belgidan iborat bo'lishi kerak", badRequest.GetFieldViolations()[0].GetDescription())
+		info, ok := details[2].(*errdetails.ErrorInfo)
+		require.True(t, ok)
+		require.Equal(t, "gte", info.GetReason())
+		require.Equal(t, map[string]string{"field": "content", "param": "10"}, info.GetMetadata())
+	})
+}
+
+func TestBlogsMicroservice_GetByID(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// logger, use case, service of blog
+	logger := logger.NewApiLogger(nil)
+	mockBlogUC := mock.NewMockUseCase[models.Blog](ctrl)
+	service := NewBlogsMicroservice(nil, mockBlogUC, logger)
+
+	// invalid id case
+	t.Run("GetByID Invalid ID", func(t *testing.T) {
+		res, err := service.GetByID(context.Background(), &blogsService.GetBlogByIDRequest{Id: "invalid"})
+
+		require.Nil(t, res)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	// not found case
+	t.Run("GetByID Not Found", func(t *testing.T) {
+		mockBlogUC.EXPECT().GetByID(gomock.Any(), gomock.Any()).
+			Return(nil, errors.Wrap(sql.ErrNoRows, "blogsRepo.GetByID.GetContext"))
+
+		res, err := service.GetByID(context.Background(), &blogsService.GetBlogByIDRequest{Id: uuid.NewString()})
+
+		require.Nil(t, res)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
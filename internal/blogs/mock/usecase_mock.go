@@ -1,116 +0,0 @@
-// Code generated by MockGen. DO NOT EDIT.
-// Source: usecase.go
-
-// Package mock is a generated GoMock package.
-package mock
-
-import (
-	"context"
-	"github.com/Dostonlv/task-del/internal/models"
-	"github.com/Dostonlv/task-del/pkg/utils"
-	"github.com/golang/mock/gomock"
-	"github.com/google/uuid"
-	"reflect"
-)
-
-// Mock of UseCase interface
-type MockUseCase struct {
-	ctrl     *gomock.Controller
-	recorder *MockUseCaseMockRecorder
-}
-
-// MockUseCaseMockRecorder is the mock recorder for MockUseCase
-type MockUseCaseMockRecorder struct {
-	mock *MockUseCase
-}
-
-// NewMockUseCase creates a new mock instance
-func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
-	mock := &MockUseCase{ctrl: ctrl}
-	mock.recorder = &MockUseCaseMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
-	return m.recorder
-}
-
-
-
-
-
-// Create mocks base method
-func (m *MockUseCase) Create(arg0 context.Context, arg1 *models.Blog) (*models.Blog, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1)
-	ret0, _ := ret[0].(*models.Blog)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// Create indicates an expected call of Create
-func (mr *MockUseCaseMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUseCase)(nil).Create), arg0, arg1)
-}
-
-// Delete mocks base method
-func (m *MockUseCase) Delete(arg0 context.Context, arg1 uuid.UUID) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Delete indicates an expected call of Delete
-func (mr *MockUseCaseMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), arg0, arg1)
-}
-
-// GetAll mocks base method
-func (m *MockUseCase) GetAll(arg0 context.Context, arg1 string, arg2 *utils.PaginationQuery) (*models.BlogsList, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", arg0, arg1, arg2)
-	ret0, _ := ret[0].(*models.BlogsList)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetAll indicates an expected call of GetAll
-func (mr *MockUseCaseMockRecorder) GetAll(arg0, arg1, arg2 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUseCase)(nil).GetAll), arg0, arg1, arg2)
-}
-
-// GetByID mocks base method
-func (m *MockUseCase) GetByID(arg0 context.Context, arg1 uuid.UUID) (*models.Blog, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
-	ret0, _ := ret[0].(*models.Blog)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetByID indicates an expected call of GetByID
-func (mr *MockUseCaseMockRecorder) GetByID(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUseCase)(nil).GetByID), arg0, arg1)
-}
-
-// Update mocks base method
-func (m *MockUseCase) Update(arg0 context.Context, arg1 *models.Blog) (*models.Blog, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
-	ret0, _ := ret[0].(*models.Blog)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-
-}
-
-// Update indicates an expected call of Update
-func (mr *MockUseCaseMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUseCase)(nil).Update), arg0, arg1)
-}
@@ -0,0 +1,72 @@
+package content
+
+import (
+	"context"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+)
+
+// Model pointer to content model embedding models.Entry
+type Model[T any] interface {
+	*T
+	Base() *models.Entry
+}
+
+// Validator implemented by content models having rules beyond struct tags, checked on create, update and import
+type Validator interface {
+	Validate(ctx context.Context) error
+}
+
+// Field extra column of content type next to models.Entry ones, List fields are TEXT[] columns
+type Field struct {
+	Column string
+	List   bool
+}
+
+// Type content type declaration, blogs and news are registrations of it
+type Type struct {
+	// Name singular name used in logs and messages, e.g. "blog"
+	Name string
+	// Plural used in span names, list key and export file name, e.g. "blogs"
+	Plural string
+	// Table of content, it has models.Entry columns and Fields
+	Table string
+	// TranslationsTable per-locale translations table, TranslationsKey its column referencing Table
+	TranslationsTable string
+	TranslationsKey   string
+	// Fields extra columns, model has them as db tagged fields
+	Fields []Field
+	// Aggregate and events of outbox
+	Aggregate    string
+	EventCreated string
+	EventUpdated string
+	EventDeleted string
+}
+
+// entryColumns models.Entry columns stored in content table
+var entryColumns = []string{"id", "title", "content", "tags", "created_at", "revision"}
+
+// Columns all columns of content table
+func (t *Type) Columns() []string {
+	columns := append([]string{}, entryColumns...)
+	for _, field := range t.Fields {
+		columns = append(columns, field.Column)
+	}
+	return columns
+}
+
+// TransferColumns columns of CSV export and import
+func (t *Type) TransferColumns() []transfer.Column {
+	columns := []transfer.Column{
+		{Name: "id"},
+		{Name: "title"},
+		{Name: "content"},
+		{Name: "tags", List: true},
+		{Name: "created_at"},
+	}
+	for _, field := range t.Fields {
+		columns = append(columns, transfer.Column{Name: field.Column, List: field.List})
+	}
+	return columns
+}
@@ -0,0 +1,30 @@
+//go:generate mockgen -source delivery.go -destination mock/handlers_mock.go -package mock
+package content
+
+import "github.com/labstack/echo/v4"
+
+// Handlers content HTTP Handlers interface
+type Handlers interface {
+	Create() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	GetAll() echo.HandlerFunc
+	Export() echo.HandlerFunc
+	Import() echo.HandlerFunc
+	GetTranslations() echo.HandlerFunc
+	PutTranslation() echo.HandlerFunc
+	DeleteTranslation() echo.HandlerFunc
+}
+
+// HandlersV2 content HTTP v2 Handlers interface, responses are wrapped in models.Envelope
+type HandlersV2 interface {
+	Create() echo.HandlerFunc
+	Update() echo.HandlerFunc
+	Delete() echo.HandlerFunc
+	GetByID() echo.HandlerFunc
+	GetAll() echo.HandlerFunc
+	GetTranslations() echo.HandlerFunc
+	PutTranslation() echo.HandlerFunc
+	DeleteTranslation() echo.HandlerFunc
+}
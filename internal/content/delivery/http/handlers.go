@@ -0,0 +1,378 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/content"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// content handlers
+type contentHandlers[T any, P content.Model[T]] struct {
+	cfg    *config.Config
+	t      *content.Type
+	uc     content.UseCase[T]
+	logger logger.Logger
+}
+
+// NewHandlers content handlers constructor
+func NewHandlers[T any, P content.Model[T]](cfg *config.Config, t *content.Type, uc content.UseCase[T], logger logger.Logger) content.Handlers {
+	return &contentHandlers[T, P]{cfg: cfg, t: t, uc: uc, logger: logger}
+}
+
+// Create
+// @Summary Create content
+// @Description create blog or news, body is sanitized and validated by rules of content type
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param body body models.NewsSwagger true "body"
+// @Param Idempotency-Key header string false "client generated key, retries with the same key and body replay the first response"
+// @Success 201 {object} models.New
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 500 {object} httpErrors.RestError
+// @Failure 409 {object} httpErrors.RestError "key reused with different request or still in progress"
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type} [post]
+func (h *contentHandlers[T, P]) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		item := new(T)
+		if err := utils.SanitizeRequest(c, item); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		created, err := h.uc.Create(c.Request().Context(), item)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusCreated, created)
+	}
+}
+
+// Update
+// @Summary Update content
+// @Description update blog or news, revision is bumped when title or content changes
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Param body body models.NewsSwagger true "body"
+// @Success 200 {object} models.New
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 500 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type}/{id} [put]
+func (h *contentHandlers[T, P]) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		item := new(T)
+		if err = utils.SanitizeRequest(c, item); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		P(item).Base().ID = id
+
+		updated, err := h.uc.Update(c.Request().Context(), item)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusOK, updated)
+	}
+}
+
+// Delete
+// @Summary Delete content
+// @Description delete blog or news
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Success 200 {object} string
+// @Failure 500 {object} string
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type}/{id} [delete]
+func (h *contentHandlers[T, P]) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err := h.uc.Delete(c.Request().Context(), id); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// GetByID
+// @Summary Get content by ID
+// @Description get blog or news by ID, localized by lang query or Accept-Language
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Success 200 {object} models.New
+// @Failure 500 {object} string
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type}/{id} [get]
+func (h *contentHandlers[T, P]) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		item, err := h.uc.GetByID(c.Request().Context(), id)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		if loc := P(item).Base().Locale; loc != "" {
+			c.Response().Header().Set("Content-Language", loc)
+		}
+
+		return utils.Respond(c, http.StatusOK, item)
+	}
+}
+
+// GetAll
+// @Summary Get all content
+// @Description get page of blogs or news, items are listed under the content type key
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param title query string false "title"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param orderBy query int false "filter name" Format(orderBy)
+// @Success 200 {object} models.NewsList
+// @Failure 500 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type} [get]
+func (h *contentHandlers[T, P]) GetAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		list, err := h.uc.GetAll(c.Request().Context(), c.QueryParam("title"), pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusOK, list)
+	}
+}
+
+// Export
+// @Summary Export content
+// @Description stream blogs or news as JSON lines or CSV ordered by creation time, admin only
+// @Tags content
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param format query string false "ndjson (default) or csv"
+// @Param title query string false "title contains"
+// @Param tags query string false "comma separated tags, records with any of them"
+// @Param from query string false "created at or after, RFC 3339 time or date"
+// @Param to query string false "created before, RFC 3339 time or date"
+// @Success 200 {array} models.New
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type}/export [get]
+func (h *contentHandlers[T, P]) Export() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		format, err := transfer.ParseFormat(c.QueryParam("format"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil))
+		}
+		filter, err := models.ParseExportFilter(c.QueryParam("title"), c.QueryParam("tags"), c.QueryParam("from"), c.QueryParam("to"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil))
+		}
+
+		res := c.Response()
+		// server write timeout would cut large export
+		if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil {
+			return errors.Wrap(err, "contentHandlers.Export.SetWriteDeadline")
+		}
+		res.Header().Set(echo.HeaderContentType, format.ContentType())
+		res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", h.t.Plural+"."+string(format)))
+		res.WriteHeader(http.StatusOK)
+
+		// status is already sent, failed export shows up as truncated body
+		if _, err := h.uc.Export(c.Request().Context(), filter, res, format); err != nil {
+			utils.LogResponseError(c, h.logger, err)
+		}
+		return nil
+	}
+}
+
+// Import
+// @Summary Import content
+// @Description create or update blogs or news by ID from JSON lines or CSV body, admin only.
+// @Description Records are sanitized and validated as in create request, invalid lines are reported and skipped.
+// @Tags content
+// @Accept application/x-ndjson
+// @Accept text/csv
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param format query string false "ndjson (default) or csv"
+// @Param dry_run query bool false "validate and count without writing"
+// @Param Idempotency-Key header string false "client generated key, retries with the same key and body replay the first response"
+// @Success 200 {object} models.ImportResult
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 401 {object} httpErrors.RestError
+// @Failure 409 {object} httpErrors.RestError "key reused with different request or still in progress"
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type}/import [post]
+func (h *contentHandlers[T, P]) Import() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		format, err := transfer.ParseFormat(c.QueryParam("format"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewRestError(http.StatusBadRequest, err.Error(), nil))
+		}
+		dryRun := false
+		if param := c.QueryParam("dry_run"); param != "" {
+			if dryRun, err = strconv.ParseBool(param); err != nil {
+				return utils.ErrResponseWithLog(c, h.logger, httpErrors.NewRestError(http.StatusBadRequest, "invalid dry_run", nil))
+			}
+		}
+
+		// server read timeout would cut large upload
+		if err := http.NewResponseController(c.Response()).SetReadDeadline(time.Time{}); err != nil {
+			return errors.Wrap(err, "contentHandlers.Import.SetReadDeadline")
+		}
+
+		result, err := h.uc.Import(c.Request().Context(), c.Request().Body, format, dryRun)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusOK, result)
+	}
+}
+
+// GetTranslations
+// @Summary Get content translations
+// @Description translation status of blog or news in every supported locale: current, outdated (made from older revision) or missing
+// @Tags content
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Success 200 {object} models.TranslationsList
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type}/{id}/translations [get]
+func (h *contentHandlers[T, P]) GetTranslations() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		translations, err := h.uc.GetTranslations(c.Request().Context(), id)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusOK, translations)
+	}
+}
+
+// PutTranslation
+// @Summary Create or replace content translation
+// @Description translation is marked as made from current revision of blog or news
+// @Tags content
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Param locale path string true "supported locale other than the source one"
+// @Param body body models.TranslationSwagger true "body"
+// @Success 200 {object} models.Translation
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type}/{id}/translations/{locale} [put]
+func (h *contentHandlers[T, P]) PutTranslation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		translation := &models.Translation{}
+		if err := utils.SanitizeRequest(c, translation); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		translation.EntityID = id
+		translation.Locale = c.Param("locale")
+
+		saved, err := h.uc.PutTranslation(c.Request().Context(), translation)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusOK, saved)
+	}
+}
+
+// DeleteTranslation
+// @Summary Delete content translation
+// @Description requests for the locale fall back along the locale chain
+// @Tags content
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Param locale path string true "locale"
+// @Success 200
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v1/{type}/{id}/translations/{locale} [delete]
+func (h *contentHandlers[T, P]) DeleteTranslation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err := h.uc.DeleteTranslation(c.Request().Context(), id, c.Param("locale")); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
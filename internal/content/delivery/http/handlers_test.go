@@ -0,0 +1,192 @@
+package http
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dostonlv/task-del/internal/content"
+	"github.com/Dostonlv/task-del/internal/content/mock"
+	"github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.uber.org/mock/gomock"
+)
+
+// newsType same declaration as news registration, which imports this package
+var newsType = &content.Type{Name: "news", Plural: "news", Table: "news"}
+
+func TestHandlers_ContentNegotiation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+	h := NewHandlers[models.New](nil, newsType, mockNewsUC, logger.NewApiLogger(nil))
+	mw := middleware.NewMiddlewareManager(nil, logger.NewApiLogger(nil))
+	e := echo.New()
+
+	newsID := uuid.New()
+	serve := func(method, path, body string, headers map[string]string, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(newsID.String())
+		require.NoError(t, handler(c))
+		return rec
+	}
+
+	t.Run("XML", func(t *testing.T) {
+		mockNewsUC.EXPECT().GetByID(gomock.Any(), newsID).Return(&models.New{Entry: models.Entry{ID: newsID, Title: "title", Tags: models.Tags{"go", "sql"}}}, nil)
+
+		rec := serve(http.MethodGet, "/", "", map[string]string{echo.HeaderAccept: "text/html;q=0.9, application/xml"}, h.GetByID())
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "application/xml", rec.Header().Get(echo.HeaderContentType))
+		require.Contains(t, rec.Body.String(), "<title>title</title>")
+		require.Contains(t, rec.Body.String(), "<tags><item>go</item><item>sql</item></tags>")
+	})
+
+	t.Run("MessagePack", func(t *testing.T) {
+		mockNewsUC.EXPECT().GetByID(gomock.Any(), newsID).Return(&models.New{Entry: models.Entry{ID: newsID, Title: "title", Revision: 2}}, nil)
+
+		rec := serve(http.MethodGet, "/", "", map[string]string{echo.HeaderAccept: "application/msgpack"}, h.GetByID())
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var body map[string]interface{}
+		require.NoError(t, msgpack.Unmarshal(rec.Body.Bytes(), &body))
+		require.Equal(t, newsID.String(), body["id"])
+		require.EqualValues(t, 2, body["revision"])
+	})
+
+	t.Run("NotAcceptable", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/", "", map[string]string{echo.HeaderAccept: "text/html"}, mw.ContentNegotiationMiddleware(h.GetByID()))
+		require.Equal(t, http.StatusNotAcceptable, rec.Code)
+	})
+
+	t.Run("YAMLRequest", func(t *testing.T) {
+		mockNewsUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, n *models.New) (*models.New, error) {
+			require.Equal(t, "yaml title", n.Title)
+			require.Equal(t, models.Tags{"go"}, n.Tags)
+			return n, nil
+		})
+
+		body := "title: yaml title\ncontent: content from yaml\ntags:\n  - go\n"
+		rec := serve(http.MethodPost, "/", body, map[string]string{echo.HeaderContentType: "application/yaml"}, h.Create())
+		require.Equal(t, http.StatusCreated, rec.Code)
+		require.Equal(t, "application/json", rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("XMLRequest", func(t *testing.T) {
+		mockNewsUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, n *models.New) (*models.New, error) {
+			require.Equal(t, "xml title", n.Title)
+			require.Equal(t, models.Tags{"go", "sql"}, n.Tags)
+			return n, nil
+		})
+
+		body := `<news><title>xml title</title><content>content from xml</content><tags><item>go</item><item>sql</item></tags></news>`
+		rec := serve(http.MethodPost, "/", body, map[string]string{echo.HeaderContentType: "application/xml; charset=utf-8"}, h.Create())
+		require.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("UnsupportedMediaType", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/", "title", map[string]string{echo.HeaderContentType: "text/plain"}, mw.ContentNegotiationMiddleware(h.Create()))
+		require.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+}
+
+func TestHandlers_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+	h := NewHandlers[models.New](nil, newsType, mockNewsUC, logger.NewApiLogger(nil))
+	e := echo.New()
+
+	serve := func(id, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderAccept, accept)
+		rec := httptest.NewRecorder()
+		rec.Header().Set(echo.HeaderXRequestID, "request-id")
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		require.NoError(t, h.GetByID()(c))
+		return rec
+	}
+
+	t.Run("Problem", func(t *testing.T) {
+		newsID := uuid.New()
+		mockNewsUC.EXPECT().GetByID(gomock.Any(), newsID).Return(nil, appErrors.Wrap(sql.ErrNoRows, appErrors.CodeContentNotFound, "news not found"))
+
+		rec := serve(newsID.String(), httpErrors.ProblemMediaType)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Equal(t, httpErrors.ProblemMediaType, rec.Header().Get(echo.HeaderContentType))
+
+		var problem httpErrors.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		require.Equal(t, httpErrors.Problem{
+			Type:     "urn:task-del:problem:content-not-found",
+			Title:    "Content not found",
+			Status:   http.StatusNotFound,
+			Detail:   "news not found",
+			Instance: "request-id",
+			Code:     "CONTENT_NOT_FOUND",
+		}, problem)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"title":"ab","tags":["go",""]}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("Accept-Language", "ru-RU, en;q=0.8")
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.Create()(e.NewContext(req, rec)))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body httpErrors.RestError
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Equal(t, "VALIDATION_FAILED", body.ErrCode)
+		require.Equal(t, []httpErrors.FieldError{
+			{Field: "title", Rule: "gte", Param: "3", Message: "поле title должно содержать не менее 3 символов"},
+			{Field: "content", Rule: "required", Message: "поле content обязательно"},
+			{Field: "tags[1]", Rule: "gte", Param: "1", Message: "поле tags[1] должно содержать не менее 1 символов"},
+		}, body.ErrFields)
+	})
+
+	// problem+json with q=0 or as parameter of another type is not an opt-in
+	t.Run("Problem declined", func(t *testing.T) {
+		for _, accept := range []string{
+			httpErrors.ProblemMediaType + ";q=0, " + echo.MIMEApplicationJSON,
+			"text/plain; note=" + httpErrors.ProblemMediaType + ", " + echo.MIMEApplicationJSON,
+		} {
+			rec := serve("not-uuid", accept)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			require.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType), accept)
+		}
+	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		rec := serve("not-uuid", echo.MIMEApplicationJSON)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Equal(t, "INVALID_ID", body["code"])
+		require.EqualValues(t, http.StatusBadRequest, body["status"])
+	})
+}
@@ -0,0 +1,344 @@
+package http
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/content"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// content v2 handlers, same use cases as v1 with responses wrapped in models.Envelope
+type contentHandlersV2[T any, P content.Model[T]] struct {
+	cfg    *config.Config
+	t      *content.Type
+	uc     content.UseCase[T]
+	logger logger.Logger
+}
+
+// NewHandlersV2 content v2 handlers constructor
+func NewHandlersV2[T any, P content.Model[T]](cfg *config.Config, t *content.Type, uc content.UseCase[T], logger logger.Logger) content.HandlersV2 {
+	return &contentHandlersV2[T, P]{cfg: cfg, t: t, uc: uc, logger: logger}
+}
+
+// Create
+// @Summary Create content
+// @Description create blog or news, Location header points to created item
+// @Tags content v2
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param body body models.NewsSwagger true "body"
+// @Param Idempotency-Key header string false "client generated key, retries with the same key and body replay the first response"
+// @Success 201 {object} models.Envelope{data=models.New}
+// @Header 201 {string} Location "created item"
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 500 {object} httpErrors.RestError
+// @Failure 409 {object} httpErrors.RestError "key reused with different request or still in progress"
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v2/{type} [post]
+func (h *contentHandlersV2[T, P]) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		item := new(T)
+		if err := utils.SanitizeRequest(c, item); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		created, err := h.uc.Create(c.Request().Context(), item)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		location := c.Request().URL.Path + "/" + P(created).Base().ID.String()
+		c.Response().Header().Set(echo.HeaderLocation, location)
+		return utils.Respond(c, http.StatusCreated, h.envelope(c, created, location))
+	}
+}
+
+// Update
+// @Summary Update content
+// @Description update blog or news, revision is bumped when title or content changes
+// @Tags content v2
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Param body body models.NewsSwagger true "body"
+// @Success 200 {object} models.Envelope{data=models.New}
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v2/{type}/{id} [put]
+func (h *contentHandlersV2[T, P]) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		item := new(T)
+		if err = utils.SanitizeRequest(c, item); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		P(item).Base().ID = id
+
+		updated, err := h.uc.Update(c.Request().Context(), item)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusOK, h.envelope(c, updated, c.Request().URL.Path))
+	}
+}
+
+// Delete
+// @Summary Delete content
+// @Description delete blog or news
+// @Tags content v2
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Success 204
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v2/{type}/{id} [delete]
+func (h *contentHandlersV2[T, P]) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err := h.uc.Delete(c.Request().Context(), id); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// GetByID
+// @Summary Get content by ID
+// @Description get blog or news by ID, localized by lang query or Accept-Language
+// @Tags content v2
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Param lang query string false "preferred locale, overrides Accept-Language"
+// @Param Accept-Language header string false "preferred locales"
+// @Success 200 {object} models.Envelope{data=models.New}
+// @Header 200 {string} Content-Language "locale of title and content"
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v2/{type}/{id} [get]
+func (h *contentHandlersV2[T, P]) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		item, err := h.uc.GetByID(c.Request().Context(), id)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		if loc := P(item).Base().Locale; loc != "" {
+			c.Response().Header().Set("Content-Language", loc)
+		}
+
+		return utils.Respond(c, http.StatusOK, h.envelope(c, item, c.Request().URL.RequestURI()))
+	}
+}
+
+// GetAll
+// @Summary Get all content
+// @Description get page of blogs or news, links keep title and lang filters
+// @Tags content v2
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param title query string false "title"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Param orderBy query int false "filter name" Format(orderBy)
+// @Param lang query string false "preferred locale, overrides Accept-Language"
+// @Param Accept-Language header string false "preferred locales"
+// @Success 200 {object} models.Envelope{data=[]models.New} "meta.page and first, prev, next and last links describe pagination"
+// @Failure 400 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v2/{type} [get]
+func (h *contentHandlersV2[T, P]) GetAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		list, err := h.uc.GetAll(c.Request().Context(), c.QueryParam("title"), pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		items := list.Items
+		if items == nil {
+			items = make([]*T, 0)
+		}
+		return utils.Respond(c, http.StatusOK, models.Envelope{
+			Data: items,
+			Meta: models.Meta{
+				RequestID: utils.GetRequestID(c),
+				Page: &models.PageMeta{
+					TotalCount: list.TotalCount,
+					TotalPages: list.TotalPages,
+					Page:       max(list.Page, 1),
+					Size:       list.Size,
+				},
+			},
+			Links: pageLinks(c, pq, list.TotalPages),
+		})
+	}
+}
+
+// GetTranslations
+// @Summary Get content translations
+// @Description translation status of blog or news in every supported locale: current, outdated (made from older revision) or missing
+// @Tags content v2
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Success 200 {object} models.Envelope{data=models.TranslationsList}
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v2/{type}/{id}/translations [get]
+func (h *contentHandlersV2[T, P]) GetTranslations() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		translations, err := h.uc.GetTranslations(c.Request().Context(), id)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusOK, h.envelope(c, translations, c.Request().URL.Path))
+	}
+}
+
+// PutTranslation
+// @Summary Create or replace content translation
+// @Description translation is marked as made from current revision of blog or news
+// @Tags content v2
+// @Accept json
+// @Produce json
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Param locale path string true "supported locale other than the source one"
+// @Param body body models.TranslationSwagger true "body"
+// @Success 200 {object} models.Envelope{data=models.Translation}
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v2/{type}/{id}/translations/{locale} [put]
+func (h *contentHandlersV2[T, P]) PutTranslation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		translation := &models.Translation{}
+		if err := utils.SanitizeRequest(c, translation); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		translation.EntityID = id
+		translation.Locale = c.Param("locale")
+
+		saved, err := h.uc.PutTranslation(c.Request().Context(), translation)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return utils.Respond(c, http.StatusOK, h.envelope(c, saved, c.Request().URL.Path))
+	}
+}
+
+// DeleteTranslation
+// @Summary Delete content translation
+// @Description requests for the locale fall back along the locale chain
+// @Tags content v2
+// @Param type path string true "content type" Enums(blogs, news)
+// @Param id path string true "id"
+// @Param locale path string true "locale"
+// @Success 204
+// @Failure 404 {object} httpErrors.RestError
+// @Failure default {object} httpErrors.Problem "same error as RFC 7807 problem details when Accept is application/problem+json"
+// @Router /v2/{type}/{id}/translations/{locale} [delete]
+func (h *contentHandlersV2[T, P]) DeleteTranslation() echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		id, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err := h.uc.DeleteTranslation(c.Request().Context(), id, c.Param("locale")); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// envelope single resource envelope linking to itself
+func (h *contentHandlersV2[T, P]) envelope(c echo.Context, data interface{}, self string) models.Envelope {
+	return models.Envelope{
+		Data:  data,
+		Meta:  models.Meta{RequestID: utils.GetRequestID(c)},
+		Links: models.Links{Self: self},
+	}
+}
+
+// pageLinks links of list pages built with PaginationQuery.GetQueryString, other query params such as title and lang are kept
+func pageLinks(c echo.Context, pq *utils.PaginationQuery, totalPages int) models.Links {
+	filters := url.Values{}
+	for key, values := range c.QueryParams() {
+		switch key {
+		case "page", "size", "orderBy":
+		default:
+			filters[key] = values
+		}
+	}
+
+	link := func(page int) string {
+		query := *pq
+		query.Page = page
+		href := c.Request().URL.Path + "?" + query.GetQueryString()
+		if len(filters) > 0 {
+			href += "&" + filters.Encode()
+		}
+		return href
+	}
+
+	// page 0 is the first page
+	page, last := max(pq.GetPage(), 1), max(totalPages, 1)
+
+	links := models.Links{Self: link(page), First: link(1), Last: link(last)}
+	if page > 1 {
+		links.Prev = link(min(page-1, last))
+	}
+	if page < last {
+		links.Next = link(page + 1)
+	}
+	return links
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dostonlv/task-del/internal/content/mock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandlersV2(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+	h := NewHandlersV2[models.New](nil, newsType, mockNewsUC, logger.NewApiLogger(nil))
+	e := echo.New()
+
+	newsID := uuid.New()
+	serve := func(method, target, body string, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		rec.Header().Set(echo.HeaderXRequestID, "request-id")
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(newsID.String())
+		require.NoError(t, handler(c))
+		return rec
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		mockNewsUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, n *models.New) (*models.New, error) {
+			n.ID = newsID
+			return n, nil
+		})
+
+		rec := serve(http.MethodPost, "/v2/news", `{"title":"title","content":"content of news"}`, h.Create())
+		require.Equal(t, http.StatusCreated, rec.Code)
+		require.Equal(t, "/v2/news/"+newsID.String(), rec.Header().Get(echo.HeaderLocation))
+
+		var body struct {
+			Data  models.New   `json:"data"`
+			Meta  models.Meta  `json:"meta"`
+			Links models.Links `json:"links"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Equal(t, newsID, body.Data.ID)
+		require.Equal(t, models.Meta{RequestID: "request-id"}, body.Meta)
+		require.Equal(t, models.Links{Self: "/v2/news/" + newsID.String()}, body.Links)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mockNewsUC.EXPECT().Delete(gomock.Any(), newsID).Return(nil)
+
+		rec := serve(http.MethodDelete, "/v2/news/"+newsID.String(), "", h.Delete())
+		require.Equal(t, http.StatusNoContent, rec.Code)
+		require.Empty(t, rec.Body.String())
+	})
+
+	t.Run("GetAll", func(t *testing.T) {
+		mockNewsUC.EXPECT().GetAll(gomock.Any(), "go", &utils.PaginationQuery{Page: 2, Size: 10, OrderBy: "title"}).Return(&models.NewsList{
+			TotalCount: 35,
+			TotalPages: 4,
+			Page:       2,
+			Size:       10,
+			Items:      []*models.New{{Entry: models.Entry{ID: newsID}}},
+		}, nil)
+
+		rec := serve(http.MethodGet, "/v2/news?page=2&size=10&orderBy=title&title=go", "", h.GetAll())
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var body struct {
+			Data  []models.New `json:"data"`
+			Meta  models.Meta  `json:"meta"`
+			Links models.Links `json:"links"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Data, 1)
+		require.Equal(t, &models.PageMeta{TotalCount: 35, TotalPages: 4, Page: 2, Size: 10}, body.Meta.Page)
+		require.Equal(t, models.Links{
+			Self:  "/v2/news?page=2&size=10&orderBy=title&title=go",
+			First: "/v2/news?page=1&size=10&orderBy=title&title=go",
+			Prev:  "/v2/news?page=1&size=10&orderBy=title&title=go",
+			Next:  "/v2/news?page=3&size=10&orderBy=title&title=go",
+			Last:  "/v2/news?page=4&size=10&orderBy=title&title=go",
+		}, body.Links)
+	})
+
+	t.Run("GetAllEmpty", func(t *testing.T) {
+		mockNewsUC.EXPECT().GetAll(gomock.Any(), "", gomock.Any()).Return(&models.NewsList{Size: 10}, nil)
+
+		rec := serve(http.MethodGet, "/v2/news", "", h.GetAll())
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"data":[]`)
+
+		var body models.Envelope
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Equal(t, &models.PageMeta{Page: 1, Size: 10}, body.Meta.Page)
+		require.Equal(t, models.Links{
+			Self:  "/v2/news?page=1&size=10&orderBy=",
+			First: "/v2/news?page=1&size=10&orderBy=",
+			Last:  "/v2/news?page=1&size=10&orderBy=",
+		}, body.Links)
+	})
+}
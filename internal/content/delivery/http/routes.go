@@ -0,0 +1,37 @@
+package http
+
+import (
+	"github.com/Dostonlv/task-del/internal/content"
+	"github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// importBodyLimit import uploads are bulk, default body limit is skipped for them
+const importBodyLimit = "256M"
+
+// Map content routes
+func MapRoutes(group *echo.Group, h content.Handlers, mw *middleware.MiddlewareManager) {
+	group.POST("", h.Create(), mw.ContentNegotiationMiddleware, mw.IdempotencyMiddleware)
+	group.GET("", h.GetAll(), mw.ContentNegotiationMiddleware, mw.LocaleMiddleware)
+	group.DELETE("/:id", h.Delete(), mw.ContentNegotiationMiddleware)
+	group.PUT("/:id", h.Update(), mw.ContentNegotiationMiddleware)
+	group.GET("/:id", h.GetByID(), mw.ContentNegotiationMiddleware, mw.LocaleMiddleware)
+	group.GET("/:id/translations", h.GetTranslations(), mw.ContentNegotiationMiddleware)
+	group.PUT("/:id/translations/:locale", h.PutTranslation(), mw.ContentNegotiationMiddleware)
+	group.DELETE("/:id/translations/:locale", h.DeleteTranslation(), mw.ContentNegotiationMiddleware)
+	group.GET("/export", h.Export(), mw.AdminAuthMiddleware)
+	group.POST("/import", h.Import(), mw.AdminAuthMiddleware, echoMiddleware.BodyLimit(importBodyLimit), mw.IdempotencyMiddleware)
+}
+
+// Map content v2 routes, bulk import and export stay in v1
+func MapRoutesV2(group *echo.Group, h content.HandlersV2, mw *middleware.MiddlewareManager) {
+	group.POST("", h.Create(), mw.ContentNegotiationMiddleware, mw.IdempotencyMiddleware)
+	group.GET("", h.GetAll(), mw.ContentNegotiationMiddleware, mw.LocaleMiddleware)
+	group.DELETE("/:id", h.Delete(), mw.ContentNegotiationMiddleware)
+	group.PUT("/:id", h.Update(), mw.ContentNegotiationMiddleware)
+	group.GET("/:id", h.GetByID(), mw.ContentNegotiationMiddleware, mw.LocaleMiddleware)
+	group.GET("/:id/translations", h.GetTranslations(), mw.ContentNegotiationMiddleware)
+	group.PUT("/:id/translations/:locale", h.PutTranslation(), mw.ContentNegotiationMiddleware)
+	group.DELETE("/:id/translations/:locale", h.DeleteTranslation(), mw.ContentNegotiationMiddleware)
+}
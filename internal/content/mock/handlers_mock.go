@@ -0,0 +1,315 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+//
+// Generated by this command:
+//
+//	mockgen -source=delivery.go -destination=mock/handlers_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHandlers is a mock of Handlers interface.
+type MockHandlers struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlersMockRecorder
+}
+
+// MockHandlersMockRecorder is the mock recorder for MockHandlers.
+type MockHandlersMockRecorder struct {
+	mock *MockHandlers
+}
+
+// NewMockHandlers creates a new mock instance.
+func NewMockHandlers(ctrl *gomock.Controller) *MockHandlers {
+	mock := &MockHandlers{ctrl: ctrl}
+	mock.recorder = &MockHandlersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandlers) EXPECT() *MockHandlersMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHandlers) Create() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHandlersMockRecorder) Create() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHandlers)(nil).Create))
+}
+
+// Delete mocks base method.
+func (m *MockHandlers) Delete() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHandlersMockRecorder) Delete() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHandlers)(nil).Delete))
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockHandlers) DeleteTranslation() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockHandlersMockRecorder) DeleteTranslation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockHandlers)(nil).DeleteTranslation))
+}
+
+// Export mocks base method.
+func (m *MockHandlers) Export() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockHandlersMockRecorder) Export() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockHandlers)(nil).Export))
+}
+
+// GetAll mocks base method.
+func (m *MockHandlers) GetAll() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockHandlersMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockHandlers)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockHandlers) GetByID() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockHandlersMockRecorder) GetByID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockHandlers)(nil).GetByID))
+}
+
+// GetTranslations mocks base method.
+func (m *MockHandlers) GetTranslations() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslations")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// GetTranslations indicates an expected call of GetTranslations.
+func (mr *MockHandlersMockRecorder) GetTranslations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslations", reflect.TypeOf((*MockHandlers)(nil).GetTranslations))
+}
+
+// Import mocks base method.
+func (m *MockHandlers) Import() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockHandlersMockRecorder) Import() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockHandlers)(nil).Import))
+}
+
+// PutTranslation mocks base method.
+func (m *MockHandlers) PutTranslation() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTranslation")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// PutTranslation indicates an expected call of PutTranslation.
+func (mr *MockHandlersMockRecorder) PutTranslation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTranslation", reflect.TypeOf((*MockHandlers)(nil).PutTranslation))
+}
+
+// Update mocks base method.
+func (m *MockHandlers) Update() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHandlersMockRecorder) Update() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHandlers)(nil).Update))
+}
+
+// MockHandlersV2 is a mock of HandlersV2 interface.
+type MockHandlersV2 struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlersV2MockRecorder
+}
+
+// MockHandlersV2MockRecorder is the mock recorder for MockHandlersV2.
+type MockHandlersV2MockRecorder struct {
+	mock *MockHandlersV2
+}
+
+// NewMockHandlersV2 creates a new mock instance.
+func NewMockHandlersV2(ctrl *gomock.Controller) *MockHandlersV2 {
+	mock := &MockHandlersV2{ctrl: ctrl}
+	mock.recorder = &MockHandlersV2MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandlersV2) EXPECT() *MockHandlersV2MockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockHandlersV2) Create() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockHandlersV2MockRecorder) Create() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockHandlersV2)(nil).Create))
+}
+
+// Delete mocks base method.
+func (m *MockHandlersV2) Delete() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHandlersV2MockRecorder) Delete() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHandlersV2)(nil).Delete))
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockHandlersV2) DeleteTranslation() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockHandlersV2MockRecorder) DeleteTranslation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockHandlersV2)(nil).DeleteTranslation))
+}
+
+// GetAll mocks base method.
+func (m *MockHandlersV2) GetAll() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockHandlersV2MockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockHandlersV2)(nil).GetAll))
+}
+
+// GetByID mocks base method.
+func (m *MockHandlersV2) GetByID() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockHandlersV2MockRecorder) GetByID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockHandlersV2)(nil).GetByID))
+}
+
+// GetTranslations mocks base method.
+func (m *MockHandlersV2) GetTranslations() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslations")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// GetTranslations indicates an expected call of GetTranslations.
+func (mr *MockHandlersV2MockRecorder) GetTranslations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslations", reflect.TypeOf((*MockHandlersV2)(nil).GetTranslations))
+}
+
+// PutTranslation mocks base method.
+func (m *MockHandlersV2) PutTranslation() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTranslation")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// PutTranslation indicates an expected call of PutTranslation.
+func (mr *MockHandlersV2MockRecorder) PutTranslation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTranslation", reflect.TypeOf((*MockHandlersV2)(nil).PutTranslation))
+}
+
+// Update mocks base method.
+func (m *MockHandlersV2) Update() echo.HandlerFunc {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update")
+	ret0, _ := ret[0].(echo.HandlerFunc)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockHandlersV2MockRecorder) Update() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockHandlersV2)(nil).Update))
+}
@@ -0,0 +1,221 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pg_repository.go -destination=mock/pg_repository_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/Dostonlv/task-del/internal/models"
+	utils "github.com/Dostonlv/task-del/pkg/utils"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder[T]
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder[T any] struct {
+	mock *MockRepository[T]
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository[T any](ctrl *gomock.Controller) *MockRepository[T] {
+	mock := &MockRepository[T]{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository[T]) EXPECT() *MockRepositoryMockRecorder[T] {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository[T]) Create(ctx context.Context, item *T) (*T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, item)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder[T]) Create(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository[T])(nil).Create), ctx, item)
+}
+
+// Delete mocks base method.
+func (m *MockRepository[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder[T]) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository[T])(nil).Delete), ctx, id)
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockRepository[T]) DeleteTranslation(ctx context.Context, id uuid.UUID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", ctx, id, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockRepositoryMockRecorder[T]) DeleteTranslation(ctx, id, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockRepository[T])(nil).DeleteTranslation), ctx, id, locale)
+}
+
+// GetAll mocks base method.
+func (m *MockRepository[T]) GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.List[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, title, query)
+	ret0, _ := ret[0].(*models.List[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockRepositoryMockRecorder[T]) GetAll(ctx, title, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockRepository[T])(nil).GetAll), ctx, title, query)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository[T]) GetByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder[T]) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository[T])(nil).GetByID), ctx, id)
+}
+
+// GetByTags mocks base method.
+func (m *MockRepository[T]) GetByTags(ctx context.Context, tags []string, limit int) ([]*T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTags", ctx, tags, limit)
+	ret0, _ := ret[0].([]*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTags indicates an expected call of GetByTags.
+func (mr *MockRepositoryMockRecorder[T]) GetByTags(ctx, tags, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTags", reflect.TypeOf((*MockRepository[T])(nil).GetByTags), ctx, tags, limit)
+}
+
+// GetTranslations mocks base method.
+func (m *MockRepository[T]) GetTranslations(ctx context.Context, id uuid.UUID) ([]*models.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslations", ctx, id)
+	ret0, _ := ret[0].([]*models.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslations indicates an expected call of GetTranslations.
+func (mr *MockRepositoryMockRecorder[T]) GetTranslations(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslations", reflect.TypeOf((*MockRepository[T])(nil).GetTranslations), ctx, id)
+}
+
+// GetTranslationsByLocales mocks base method.
+func (m *MockRepository[T]) GetTranslationsByLocales(ctx context.Context, ids []uuid.UUID, locales []string) ([]*models.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslationsByLocales", ctx, ids, locales)
+	ret0, _ := ret[0].([]*models.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslationsByLocales indicates an expected call of GetTranslationsByLocales.
+func (mr *MockRepositoryMockRecorder[T]) GetTranslationsByLocales(ctx, ids, locales any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslationsByLocales", reflect.TypeOf((*MockRepository[T])(nil).GetTranslationsByLocales), ctx, ids, locales)
+}
+
+// Stream mocks base method.
+func (m *MockRepository[T]) Stream(ctx context.Context, filter *models.ExportFilter, fn func(*T) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockRepositoryMockRecorder[T]) Stream(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockRepository[T])(nil).Stream), ctx, filter, fn)
+}
+
+// Update mocks base method.
+func (m *MockRepository[T]) Update(ctx context.Context, item *T) (*T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, item)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRepositoryMockRecorder[T]) Update(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository[T])(nil).Update), ctx, item)
+}
+
+// Upsert mocks base method.
+func (m *MockRepository[T]) Upsert(ctx context.Context, item *T) (*T, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, item)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockRepositoryMockRecorder[T]) Upsert(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockRepository[T])(nil).Upsert), ctx, item)
+}
+
+// UpsertTranslation mocks base method.
+func (m *MockRepository[T]) UpsertTranslation(ctx context.Context, translation *models.Translation) (*models.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTranslation", ctx, translation)
+	ret0, _ := ret[0].(*models.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTranslation indicates an expected call of UpsertTranslation.
+func (mr *MockRepositoryMockRecorder[T]) UpsertTranslation(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTranslation", reflect.TypeOf((*MockRepository[T])(nil).UpsertTranslation), ctx, translation)
+}
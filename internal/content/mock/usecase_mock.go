@@ -0,0 +1,208 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+//
+// Generated by this command:
+//
+//	mockgen -source=usecase.go -destination=mock/usecase_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	models "github.com/Dostonlv/task-del/internal/models"
+	transfer "github.com/Dostonlv/task-del/pkg/transfer"
+	utils "github.com/Dostonlv/task-del/pkg/utils"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUseCase is a mock of UseCase interface.
+type MockUseCase[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockUseCaseMockRecorder[T]
+}
+
+// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
+type MockUseCaseMockRecorder[T any] struct {
+	mock *MockUseCase[T]
+}
+
+// NewMockUseCase creates a new mock instance.
+func NewMockUseCase[T any](ctrl *gomock.Controller) *MockUseCase[T] {
+	mock := &MockUseCase[T]{ctrl: ctrl}
+	mock.recorder = &MockUseCaseMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUseCase[T]) EXPECT() *MockUseCaseMockRecorder[T] {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUseCase[T]) Create(ctx context.Context, item *T) (*T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, item)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUseCaseMockRecorder[T]) Create(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUseCase[T])(nil).Create), ctx, item)
+}
+
+// Delete mocks base method.
+func (m *MockUseCase[T]) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUseCaseMockRecorder[T]) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase[T])(nil).Delete), ctx, id)
+}
+
+// DeleteTranslation mocks base method.
+func (m *MockUseCase[T]) DeleteTranslation(ctx context.Context, id uuid.UUID, locale string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTranslation", ctx, id, locale)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTranslation indicates an expected call of DeleteTranslation.
+func (mr *MockUseCaseMockRecorder[T]) DeleteTranslation(ctx, id, locale any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTranslation", reflect.TypeOf((*MockUseCase[T])(nil).DeleteTranslation), ctx, id, locale)
+}
+
+// Export mocks base method.
+func (m *MockUseCase[T]) Export(ctx context.Context, filter *models.ExportFilter, w io.Writer, format transfer.Format) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, w, format)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUseCaseMockRecorder[T]) Export(ctx, filter, w, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUseCase[T])(nil).Export), ctx, filter, w, format)
+}
+
+// GetAll mocks base method.
+func (m *MockUseCase[T]) GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.List[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, title, query)
+	ret0, _ := ret[0].(*models.List[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockUseCaseMockRecorder[T]) GetAll(ctx, title, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUseCase[T])(nil).GetAll), ctx, title, query)
+}
+
+// GetByID mocks base method.
+func (m *MockUseCase[T]) GetByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUseCaseMockRecorder[T]) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUseCase[T])(nil).GetByID), ctx, id)
+}
+
+// GetByTags mocks base method.
+func (m *MockUseCase[T]) GetByTags(ctx context.Context, tags []string, limit int) ([]*T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTags", ctx, tags, limit)
+	ret0, _ := ret[0].([]*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTags indicates an expected call of GetByTags.
+func (mr *MockUseCaseMockRecorder[T]) GetByTags(ctx, tags, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTags", reflect.TypeOf((*MockUseCase[T])(nil).GetByTags), ctx, tags, limit)
+}
+
+// GetTranslations mocks base method.
+func (m *MockUseCase[T]) GetTranslations(ctx context.Context, id uuid.UUID) (*models.TranslationsList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslations", ctx, id)
+	ret0, _ := ret[0].(*models.TranslationsList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslations indicates an expected call of GetTranslations.
+func (mr *MockUseCaseMockRecorder[T]) GetTranslations(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslations", reflect.TypeOf((*MockUseCase[T])(nil).GetTranslations), ctx, id)
+}
+
+// Import mocks base method.
+func (m *MockUseCase[T]) Import(ctx context.Context, r io.Reader, format transfer.Format, dryRun bool) (*models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, r, format, dryRun)
+	ret0, _ := ret[0].(*models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUseCaseMockRecorder[T]) Import(ctx, r, format, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUseCase[T])(nil).Import), ctx, r, format, dryRun)
+}
+
+// PutTranslation mocks base method.
+func (m *MockUseCase[T]) PutTranslation(ctx context.Context, translation *models.Translation) (*models.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutTranslation", ctx, translation)
+	ret0, _ := ret[0].(*models.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutTranslation indicates an expected call of PutTranslation.
+func (mr *MockUseCaseMockRecorder[T]) PutTranslation(ctx, translation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutTranslation", reflect.TypeOf((*MockUseCase[T])(nil).PutTranslation), ctx, translation)
+}
+
+// Update mocks base method.
+func (m *MockUseCase[T]) Update(ctx context.Context, item *T) (*T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, item)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUseCaseMockRecorder[T]) Update(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUseCase[T])(nil).Update), ctx, item)
+}
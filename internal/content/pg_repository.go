@@ -0,0 +1,26 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository_mock.go -package mock
+package content
+
+import (
+	"context"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Repository content repository interface
+type Repository[T any] interface {
+	Create(ctx context.Context, item *T) (*T, error)
+	Update(ctx context.Context, item *T) (*T, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*T, error)
+	GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.List[T], error)
+	GetByTags(ctx context.Context, tags []string, limit int) ([]*T, error)
+	Upsert(ctx context.Context, item *T) (*T, bool, error)
+	Stream(ctx context.Context, filter *models.ExportFilter, fn func(item *T) error) error
+	GetTranslations(ctx context.Context, id uuid.UUID) ([]*models.Translation, error)
+	GetTranslationsByLocales(ctx context.Context, ids []uuid.UUID, locales []string) ([]*models.Translation, error)
+	UpsertTranslation(ctx context.Context, translation *models.Translation) (*models.Translation, error)
+	DeleteTranslation(ctx context.Context, id uuid.UUID, locale string) error
+}
@@ -0,0 +1,548 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	auditRepository "github.com/Dostonlv/task-del/internal/audit/repository"
+	"github.com/Dostonlv/task-del/internal/content"
+	"github.com/Dostonlv/task-del/internal/models"
+	outboxRepository "github.com/Dostonlv/task-del/internal/outbox/repository"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/pkg/errors"
+)
+
+// content Repository, queries are built once from content type columns
+type contentRepo[T any, P content.Model[T]] struct {
+	db *sqlx.DB
+	t  *content.Type
+
+	columns         []string
+	writeColumns    []string
+	traversals      [][]int
+	writeTraversals [][]int
+
+	createQuery  string
+	updateQuery  string
+	deleteQuery  string
+	getQuery     string
+	lockQuery    string
+	byTagsQuery  string
+	upsertQuery  string
+	selectQuery  string
+	countQuery   string
+	translations string
+}
+
+// NewRepository content Repository constructor, panics when model has no field for a column of content type
+func NewRepository[T any, P content.Model[T]](db *sqlx.DB, t *content.Type) content.Repository[T] {
+	r := &contentRepo[T, P]{db: db, t: t, columns: t.Columns()}
+	r.writeColumns = []string{"id", "title", "content", "tags"}
+	for _, field := range t.Fields {
+		r.writeColumns = append(r.writeColumns, field.Column)
+	}
+
+	r.traversals = db.Mapper.TraversalsByName(reflect.TypeOf((*T)(nil)).Elem(), r.columns)
+	for i, traversal := range r.traversals {
+		if len(traversal) == 0 {
+			panic(fmt.Sprintf("content type %s: model %T has no field for column %q", t.Name, *new(T), r.columns[i]))
+		}
+	}
+
+	r.writeTraversals = db.Mapper.TraversalsByName(reflect.TypeOf((*T)(nil)).Elem(), r.writeColumns)
+
+	columns := strings.Join(r.columns, ", ")
+	sets := make([]string, 0, len(r.writeColumns))
+	for i, column := range r.writeColumns[1:] {
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, i+1))
+	}
+	excluded := make([]string, 0, len(r.writeColumns))
+	for _, column := range r.writeColumns[1:] {
+		excluded = append(excluded, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+
+	r.createQuery = fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s) RETURNING %s`,
+		t.Table, strings.Join(r.writeColumns, ", "), placeholders(1, len(r.writeColumns)), columns)
+	r.updateQuery = fmt.Sprintf(`UPDATE %s SET
+		%s,
+		revision = CASE WHEN title IS DISTINCT FROM $1 OR content IS DISTINCT FROM $2 THEN revision + 1 ELSE revision END
+	WHERE id = $%d
+	RETURNING %s`, t.Table, strings.Join(sets, ",\n\t\t"), len(r.writeColumns), columns)
+	r.deleteQuery = fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, t.Table)
+	r.getQuery = fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, columns, t.Table)
+	r.lockQuery = r.getQuery + " FOR UPDATE"
+	r.byTagsQuery = fmt.Sprintf(`SELECT %s
+	FROM %s
+	WHERE tags && $1
+	ORDER BY created_at DESC
+	LIMIT $2`, columns, t.Table)
+	r.upsertQuery = fmt.Sprintf(`INSERT INTO %s (%s, created_at)
+	VALUES (%s, COALESCE($%d, CURRENT_TIMESTAMP))
+	ON CONFLICT (id) DO UPDATE SET
+		%s,
+		revision = CASE WHEN %s.title IS DISTINCT FROM EXCLUDED.title OR %s.content IS DISTINCT FROM EXCLUDED.content
+			THEN %s.revision + 1 ELSE %s.revision END
+	RETURNING %s, (xmax = 0) AS inserted`,
+		t.Table, strings.Join(r.writeColumns, ", "), placeholders(1, len(r.writeColumns)), len(r.writeColumns)+1,
+		strings.Join(excluded, ",\n\t\t"), t.Table, t.Table, t.Table, t.Table, columns)
+	r.selectQuery = fmt.Sprintf(`SELECT %s FROM %s`, columns, t.Table)
+	r.countQuery = fmt.Sprintf(`SELECT COUNT(id) FROM %s`, t.Table)
+	r.translations = fmt.Sprintf(`SELECT %s AS entity_id, locale, title, content, source_revision, updated_at
+	FROM %s`, t.TranslationsKey, t.TranslationsTable)
+
+	return r
+}
+
+// placeholders comma separated $from..$from+n-1
+func placeholders(from, n int) string {
+	res := make([]string, 0, n)
+	for i := from; i < from+n; i++ {
+		res = append(res, fmt.Sprintf("$%d", i))
+	}
+	return strings.Join(res, ", ")
+}
+
+// op span and error name of repository method, e.g. newsRepo.Create
+func (r *contentRepo[T, P]) op(name string) string {
+	return r.t.Plural + "Repo." + name
+}
+
+// notFound catalogue error of missing content, other errors are returned as is
+func (r *contentRepo[T, P]) notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return appErrors.Wrap(err, appErrors.CodeContentNotFound, r.t.Name+" not found")
+	}
+	return err
+}
+
+// titleContains condition of title containing pattern argument n, list and export filter titles alike
+func titleContains(n int) string {
+	return fmt.Sprintf("title LIKE $%d", n)
+}
+
+// args values of write columns of item
+func (r *contentRepo[T, P]) args(item *T) []interface{} {
+	v := reflect.ValueOf(item).Elem()
+	args := make([]interface{}, 0, len(r.writeColumns))
+	for _, traversal := range r.writeTraversals {
+		args = append(args, reflectx.FieldByIndexesReadOnly(v, traversal).Interface())
+	}
+	return args
+}
+
+// targets scan destinations of all columns of item
+func (r *contentRepo[T, P]) targets(item *T) []interface{} {
+	v := reflect.ValueOf(item).Elem()
+	targets := make([]interface{}, 0, len(r.traversals))
+	for _, traversal := range r.traversals {
+		targets = append(targets, reflectx.FieldByIndexes(v, traversal).Addr().Interface())
+	}
+	return targets
+}
+
+// Create content
+func (r *contentRepo[T, P]) Create(ctx context.Context, item *T) (*T, error) {
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("Create"), r.createQuery)
+	defer span.End()
+
+	row := *item
+	P(&row).Base().ID = uuid.New()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Create.BeginTxx")))
+	}
+	defer tx.Rollback()
+
+	res := new(T)
+	if err := tx.QueryRowxContext(ctx, r.createQuery, r.args(&row)...).StructScan(res); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Create.StructScan")))
+	}
+
+	if err := outboxRepository.AddEvent(ctx, tx, r.t.Aggregate, P(res).Base().ID, r.t.EventCreated, res); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Create.AddEvent")))
+	}
+	if err := auditRepository.AddEntry(ctx, tx, models.AuditActionCreate, r.t.Name, P(res).Base().ID, nil, res); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Create.AddEntry")))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Create.Commit")))
+	}
+
+	return res, nil
+}
+
+// Update content, revision is bumped when title or content changes
+func (r *contentRepo[T, P]) Update(ctx context.Context, item *T) (*T, error) {
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("Update"), r.updateQuery)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Update.BeginTxx")))
+	}
+	defer tx.Rollback()
+
+	// previous state for audit log, row stays locked until commit
+	before := new(T)
+	if err := tx.QueryRowxContext(ctx, r.lockQuery, P(item).Base().ID).StructScan(before); err != nil {
+		return nil, tracing.RecordError(span, r.notFound(errors.Wrap(err, r.op("Update.lock"))))
+	}
+
+	// id goes last, after SET values
+	args := r.args(item)
+	args = append(args[1:], args[0])
+
+	res := new(T)
+	if err := tx.QueryRowxContext(ctx, r.updateQuery, args...).StructScan(res); err != nil {
+		return nil, tracing.RecordError(span, r.notFound(errors.Wrap(err, r.op("Update.QueryRowxContext"))))
+	}
+
+	if err := outboxRepository.AddEvent(ctx, tx, r.t.Aggregate, P(res).Base().ID, r.t.EventUpdated, res); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Update.AddEvent")))
+	}
+	if err := auditRepository.AddEntry(ctx, tx, models.AuditActionUpdate, r.t.Name, P(res).Base().ID, before, res); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Update.AddEntry")))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("Update.Commit")))
+	}
+
+	return res, nil
+}
+
+// Delete content
+func (r *contentRepo[T, P]) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("Delete"), r.deleteQuery)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("Delete.BeginTxx")))
+	}
+	defer tx.Rollback()
+
+	// deleted state for audit log, row stays locked until commit
+	before := new(T)
+	if err := tx.QueryRowxContext(ctx, r.lockQuery, id).StructScan(before); err != nil {
+		return tracing.RecordError(span, r.notFound(errors.Wrap(err, r.op("Delete.lock"))))
+	}
+
+	result, err := tx.ExecContext(ctx, r.deleteQuery, id)
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("Delete.ExecContext")))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("Delete.RowsAffected")))
+	}
+	if rowsAffected == 0 {
+		logger.FromContext(ctx).Debugf("%s, no rows affected, ID: %s", r.op("Delete"), id)
+		return tracing.RecordError(span, r.notFound(errors.Wrap(sql.ErrNoRows, r.op("Delete.rowsAffected"))))
+	}
+
+	if err := outboxRepository.AddEvent(ctx, tx, r.t.Aggregate, id, r.t.EventDeleted, map[string]interface{}{"id": id}); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("Delete.AddEvent")))
+	}
+	if err := auditRepository.AddEntry(ctx, tx, models.AuditActionDelete, r.t.Name, id, before, nil); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("Delete.AddEntry")))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("Delete.Commit")))
+	}
+
+	return nil
+}
+
+// GetByID content
+func (r *contentRepo[T, P]) GetByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("GetByID"), r.getQuery)
+	defer span.End()
+
+	res := new(T)
+	if err := r.db.GetContext(ctx, res, r.getQuery, id); err != nil {
+		return nil, tracing.RecordError(span, r.notFound(errors.Wrap(err, r.op("GetByID.GetContext"))))
+	}
+
+	return res, nil
+}
+
+// GetAll content page, optionally with title containing given one
+func (r *contentRepo[T, P]) GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.List[T], error) {
+	var (
+		totalCount    int
+		getTotalCount = r.countQuery
+		getAll        = r.selectQuery
+		args          = make([]interface{}, 0, 3)
+	)
+	if title != "" {
+		args = append(args, "%"+title+"%")
+		getTotalCount += " WHERE " + titleContains(1)
+		getAll += " WHERE " + titleContains(1)
+	}
+	getAll += fmt.Sprintf(" ORDER BY created_at OFFSET $%d LIMIT $%d", len(args)+1, len(args)+2)
+
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("GetAll"), getAll)
+	defer span.End()
+
+	if err := r.db.QueryRowContext(ctx, getTotalCount, args...).Scan(&totalCount); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("GetAll.QueryRowContext")))
+	}
+
+	logger.FromContext(ctx).Debugf("%s, Title: %q, Page: %d, Size: %d, TotalCount: %d", r.op("GetAll"), title, query.GetPage(), query.GetSize(), totalCount)
+
+	list := &models.List[T]{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		Items:      make([]*T, 0),
+		Key:        r.t.Plural,
+	}
+	if totalCount == 0 {
+		return list, nil
+	}
+
+	rows, err := r.db.QueryxContext(ctx, getAll, append(args, query.GetOffset(), query.GetLimit())...)
+	if err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("GetAll.QueryxContext")))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := new(T)
+		if err := rows.StructScan(item); err != nil {
+			return nil, tracing.RecordError(span, errors.Wrap(err, r.op("GetAll.StructScan")))
+		}
+		list.Items = append(list.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("GetAll.rows.Err")))
+	}
+
+	return list, nil
+}
+
+// GetByTags latest content having any of given tags
+func (r *contentRepo[T, P]) GetByTags(ctx context.Context, tags []string, limit int) ([]*T, error) {
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("GetByTags"), r.byTagsQuery)
+	defer span.End()
+
+	items := make([]*T, 0, limit)
+	if err := r.db.SelectContext(ctx, &items, r.byTagsQuery, models.Tags(tags), limit); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("GetByTags.SelectContext")))
+	}
+
+	return items, nil
+}
+
+// Upsert content by ID, missing ID creates new content. Returns true when content was created.
+func (r *contentRepo[T, P]) Upsert(ctx context.Context, item *T) (*T, bool, error) {
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("Upsert"), r.upsertQuery)
+	defer span.End()
+
+	row := *item
+	base := P(&row).Base()
+	if base.ID == uuid.Nil {
+		base.ID = uuid.New()
+	}
+	var createdAt *time.Time
+	if !base.CreatedAt.IsZero() {
+		createdAt = &base.CreatedAt
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, tracing.RecordError(span, errors.Wrap(err, r.op("Upsert.BeginTxx")))
+	}
+	defer tx.Rollback()
+
+	// previous state for audit log, missing row is created
+	var before interface{}
+	current := new(T)
+	if err := tx.QueryRowxContext(ctx, r.lockQuery, base.ID).StructScan(current); err == nil {
+		before = current
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, tracing.RecordError(span, errors.Wrap(err, r.op("Upsert.lock")))
+	}
+
+	res := new(T)
+	inserted := false
+	if err := tx.QueryRowxContext(ctx, r.upsertQuery, append(r.args(&row), createdAt)...).Scan(append(r.targets(res), &inserted)...); err != nil {
+		return nil, false, tracing.RecordError(span, errors.Wrap(err, r.op("Upsert.QueryRowxContext")))
+	}
+
+	event, action := r.t.EventUpdated, models.AuditActionUpdate
+	if inserted {
+		event, action = r.t.EventCreated, models.AuditActionCreate
+	}
+	if err := outboxRepository.AddEvent(ctx, tx, r.t.Aggregate, P(res).Base().ID, event, res); err != nil {
+		return nil, false, tracing.RecordError(span, errors.Wrap(err, r.op("Upsert.AddEvent")))
+	}
+	if err := auditRepository.AddEntry(ctx, tx, action, r.t.Name, P(res).Base().ID, before, res); err != nil {
+		return nil, false, tracing.RecordError(span, errors.Wrap(err, r.op("Upsert.AddEntry")))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, tracing.RecordError(span, errors.Wrap(err, r.op("Upsert.Commit")))
+	}
+
+	return res, inserted, nil
+}
+
+// Stream content matching filter ordered by creation time, rows are passed to fn one by one as they are read
+func (r *contentRepo[T, P]) Stream(ctx context.Context, filter *models.ExportFilter, fn func(item *T) error) error {
+	stream := r.selectQuery
+
+	conditions := make([]string, 0, 4)
+	args := make([]interface{}, 0, 4)
+	if filter.Title != "" {
+		args = append(args, "%"+filter.Title+"%")
+		conditions = append(conditions, titleContains(len(args)))
+	}
+	if len(filter.Tags) > 0 {
+		args = append(args, models.Tags(filter.Tags))
+		conditions = append(conditions, fmt.Sprintf("tags && $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if len(conditions) > 0 {
+		stream += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	stream += " ORDER BY created_at, id"
+
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("Stream"), stream)
+	defer span.End()
+
+	rows, err := r.db.QueryxContext(ctx, stream, args...)
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("Stream.QueryxContext")))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := new(T)
+		if err := rows.StructScan(item); err != nil {
+			return tracing.RecordError(span, errors.Wrap(err, r.op("Stream.StructScan")))
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("Stream.rows.Err")))
+	}
+
+	return nil
+}
+
+// GetTranslations all translations of content
+func (r *contentRepo[T, P]) GetTranslations(ctx context.Context, id uuid.UUID) ([]*models.Translation, error) {
+	getTranslations := fmt.Sprintf("%s\n\tWHERE %s = $1\n\tORDER BY locale", r.translations, r.t.TranslationsKey)
+
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("GetTranslations"), getTranslations)
+	defer span.End()
+
+	translations := make([]*models.Translation, 0)
+	if err := r.db.SelectContext(ctx, &translations, getTranslations, id); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("GetTranslations.SelectContext")))
+	}
+
+	return translations, nil
+}
+
+// GetTranslationsByLocales translations of content in given locales
+func (r *contentRepo[T, P]) GetTranslationsByLocales(ctx context.Context, ids []uuid.UUID, locales []string) ([]*models.Translation, error) {
+	translations := make([]*models.Translation, 0)
+	if len(ids) == 0 || len(locales) == 0 {
+		return translations, nil
+	}
+
+	getTranslations, args, err := sqlx.In(fmt.Sprintf("%s\n\tWHERE %s IN (?) AND locale IN (?)", r.translations, r.t.TranslationsKey), ids, locales)
+	if err != nil {
+		return nil, errors.Wrap(err, r.op("GetTranslationsByLocales.In"))
+	}
+	getTranslations = r.db.Rebind(getTranslations)
+
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("GetTranslationsByLocales"), getTranslations)
+	defer span.End()
+
+	if err := r.db.SelectContext(ctx, &translations, getTranslations, args...); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, r.op("GetTranslationsByLocales.SelectContext")))
+	}
+
+	return translations, nil
+}
+
+// UpsertTranslation create or replace translation, it is marked as made from current revision of content
+func (r *contentRepo[T, P]) UpsertTranslation(ctx context.Context, translation *models.Translation) (*models.Translation, error) {
+	upsertTranslation := fmt.Sprintf(`INSERT INTO %[1]s (%[2]s, locale, title, content, source_revision)
+	SELECT id, $2, $3, $4, revision FROM %[3]s WHERE id = $1
+	ON CONFLICT (%[2]s, locale) DO UPDATE SET
+		title = EXCLUDED.title,
+		content = EXCLUDED.content,
+		source_revision = EXCLUDED.source_revision,
+		updated_at = CURRENT_TIMESTAMP
+	RETURNING %[2]s AS entity_id, locale, title, content, source_revision, updated_at`, r.t.TranslationsTable, r.t.TranslationsKey, r.t.Table)
+
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("UpsertTranslation"), upsertTranslation)
+	defer span.End()
+
+	res := &models.Translation{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		upsertTranslation,
+		translation.EntityID,
+		translation.Locale,
+		translation.Title,
+		translation.Content,
+	).StructScan(res); err != nil {
+		// no row is inserted when content does not exist
+		return nil, tracing.RecordError(span, r.notFound(errors.Wrap(err, r.op("UpsertTranslation.QueryRowxContext"))))
+	}
+
+	return res, nil
+}
+
+// DeleteTranslation delete translation of content in locale
+func (r *contentRepo[T, P]) DeleteTranslation(ctx context.Context, id uuid.UUID, locale string) error {
+	deleteTranslation := fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND locale = $2`, r.t.TranslationsTable, r.t.TranslationsKey)
+
+	ctx, span := tracing.StartSQLSpan(ctx, r.op("DeleteTranslation"), deleteTranslation)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteTranslation, id, locale)
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("DeleteTranslation.ExecContext")))
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, r.op("DeleteTranslation.RowsAffected")))
+	}
+	if rowsAffected == 0 {
+		return tracing.RecordError(span, appErrors.Wrap(errors.Wrap(sql.ErrNoRows, r.op("DeleteTranslation.rowsAffected")), appErrors.CodeTranslationNotFound, fmt.Sprintf("%s translation %q not found", r.t.Name, locale)))
+	}
+
+	return nil
+}
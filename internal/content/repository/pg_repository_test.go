@@ -0,0 +1,818 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/internal/audit"
+	"github.com/Dostonlv/task-del/internal/content"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+// newsType same declaration as news registration, which imports this package
+var newsType = &content.Type{
+	Name:              "news",
+	Plural:            "news",
+	Table:             "news",
+	TranslationsTable: "news_translations",
+	TranslationsKey:   "news_id",
+	Aggregate:         models.AggregateNews,
+	EventCreated:      models.EventNewsCreated,
+	EventUpdated:      models.EventNewsUpdated,
+	EventDeleted:      models.EventNewsDeleted,
+}
+
+// event content type with extra columns
+type event struct {
+	models.Entry
+	Venue    string      `json:"venue" db:"venue" validate:"required"`
+	Speakers models.Tags `json:"speakers" db:"speakers"`
+}
+
+var eventType = &content.Type{
+	Name:              "event",
+	Plural:            "events",
+	Table:             "events",
+	TranslationsTable: "event_translations",
+	TranslationsKey:   "event_id",
+	Fields:            []content.Field{{Column: "venue"}, {Column: "speakers", List: true}},
+	Aggregate:         "event",
+	EventCreated:      "event.created",
+	EventUpdated:      "event.updated",
+	EventDeleted:      "event.deleted",
+}
+
+const (
+	// lockNews previous state of news read for audit log
+	lockNews = `SELECT id, title, content, tags, created_at, revision FROM news WHERE id = $1 FOR UPDATE`
+	// addAuditEntry audit entry written in the same transaction as the change
+	addAuditEntry = `INSERT INTO audit_log (actor, ip, request_id, action, entity, entity_id, before, after) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+)
+
+// TestRepo_Create tests Create method.
+func TestRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	// create mock db
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// create sqlx db with mock db
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	// new's repository
+	repo := NewRepository[models.New](sqlxDB, newsType)
+
+	// Create new success case
+	t.Run("Create", func(t *testing.T) {
+		uuid1 := uuid.New()
+		// temprorary new
+		new := &models.New{
+			Entry: models.Entry{
+				ID:      uuid1,
+				Title:   "test-title",
+				Content: "test-content",
+			},
+		}
+
+		// mock rows
+		rows := sqlmock.NewRows(
+			[]string{"id", "title", "content"},
+		).AddRow(
+			new.ID,
+			new.Title,
+			new.Content,
+		)
+
+		mock.ExpectBegin()
+		// mock query with args and return rows
+		mock.ExpectQuery(
+			`INSERT INTO news (id, title, content, tags) VALUES ($1, $2, $3, $4) RETURNING id, title, content, tags, created_at, revision`,
+		).WithArgs(
+			sqlmock.AnyArg(),
+			new.Title,
+			new.Content,
+			new.Tags,
+		).WillReturnRows(rows)
+
+		// event is written into outbox in the same transaction
+		mock.ExpectExec(
+			`INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)`,
+		).WithArgs(
+			sqlmock.AnyArg(),
+			models.AggregateNews,
+			sqlmock.AnyArg(),
+			models.EventNewsCreated,
+			sqlmock.AnyArg(),
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		// audit entry of request actor without previous state
+		mock.ExpectExec(addAuditEntry).WithArgs(
+			audit.ActorAdmin,
+			"127.0.0.1",
+			"request-id",
+			models.AuditActionCreate,
+			"news",
+			new.ID,
+			nil,
+			sqlmock.AnyArg(),
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// call Create method
+		ctx := audit.WithActor(context.Background(), audit.Actor{Name: audit.ActorAdmin, IP: "127.0.0.1", RequestID: "request-id"})
+		createdNew, err := repo.Create(ctx, new)
+
+		// check error and result
+		require.NoError(t, err)
+		require.NotNil(t, createdNew)
+		require.Equal(t, new.ID, createdNew.ID)
+		require.Equal(t, new.Title, createdNew.Title)
+		require.Equal(t, new.Content, createdNew.Content)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Create New error case
+	t.Run("Create Error", func(t *testing.T) {
+
+		// temprorary new
+		new := &models.New{
+			Entry: models.Entry{
+				ID:      uuid.New(),
+				Title:   "test-title",
+				Content: "test-content",
+			},
+		}
+
+		mock.ExpectBegin()
+		// mock query with args and return error
+		mock.ExpectQuery(
+			`INSERT INTO news (id, title, content, tags) VALUES ($1, $2, $3, $4) RETURNING id, title, content, tags, created_at, revision`,
+		).WithArgs(
+			sqlmock.AnyArg(),
+			new.Title,
+			new.Content,
+			new.Tags,
+		).WillReturnError(sqlmock.ErrCancelled)
+		mock.ExpectRollback()
+
+		// call Create method
+		createdNew, err := repo.Create(context.Background(), new)
+
+		// check error and result
+		require.Error(t, err)
+		require.Nil(t, createdNew)
+	})
+}
+
+func TestRepo_Update(t *testing.T) {
+	t.Parallel()
+
+	// create mock db
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// create sqlx db with mock db
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	// new repository
+	repo := NewRepository[models.New](sqlxDB, newsType)
+
+	// Update new success case
+	t.Run("Update", func(t *testing.T) {
+
+		// temprorary new
+		new := &models.New{
+			Entry: models.Entry{
+				ID:      uuid.New(),
+				Title:   "test-title",
+				Content: "test-content",
+			},
+		}
+
+		// mock rows
+		rows := sqlmock.NewRows(
+			[]string{"id", "title", "content"},
+		).AddRow(
+			new.ID,
+			new.Title,
+			new.Content,
+		)
+
+		mock.ExpectBegin()
+		// previous state is locked for audit log
+		mock.ExpectQuery(lockNews).WithArgs(new.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content"}).AddRow(new.ID, "old-title", "old-content"))
+		// mock query with args and return rows
+		mock.ExpectQuery(
+			`UPDATE news SET title = $1, content = $2, tags = $3, revision = CASE WHEN title IS DISTINCT FROM $1 OR content IS DISTINCT FROM $2 THEN revision + 1 ELSE revision END WHERE id = $4 RETURNING id, title, content, tags, created_at, revision`,
+		).WithArgs(
+			new.Title,
+			new.Content,
+			new.Tags,
+			new.ID,
+		).WillReturnRows(rows)
+
+		// event is written into outbox in the same transaction
+		mock.ExpectExec(
+			`INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)`,
+		).WithArgs(
+			sqlmock.AnyArg(),
+			models.AggregateNews,
+			sqlmock.AnyArg(),
+			models.EventNewsUpdated,
+			sqlmock.AnyArg(),
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(addAuditEntry).WithArgs(
+			audit.ActorSystem,
+			"",
+			"",
+			models.AuditActionUpdate,
+			"news",
+			new.ID,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// call Update method
+		updatedNew, err := repo.Update(context.Background(), new)
+
+		// check error and result
+		require.NoError(t, err)
+		require.NotNil(t, updatedNew)
+		require.Equal(t, new.ID, updatedNew.ID)
+		require.Equal(t, new.Title, updatedNew.Title)
+		require.Equal(t, new.Content, updatedNew.Content)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Update New error case
+	t.Run("Update Error", func(t *testing.T) {
+		uuid1 := uuid.New()
+		// temprorary New
+		new := &models.New{
+			Entry: models.Entry{
+				ID:      uuid1,
+				Title:   "test-title",
+				Content: "test-content",
+			},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockNews).WithArgs(new.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content"}).AddRow(new.ID, "old-title", "old-content"))
+		// mock query with args and return error
+		mock.ExpectQuery(
+			`UPDATE news SET title = $1, content = $2, tags = $3, revision = CASE WHEN title IS DISTINCT FROM $1 OR content IS DISTINCT FROM $2 THEN revision + 1 ELSE revision END WHERE id = $4 RETURNING id, title, content, tags, created_at, revision`,
+		).WithArgs(
+			new.Title,
+			new.Content,
+			new.Tags,
+			new.ID,
+		).WillReturnError(sqlmock.ErrCancelled)
+		mock.ExpectRollback()
+
+		// call Update method
+		updatedNew, err := repo.Update(context.Background(), new)
+
+		// check error and result
+		require.Error(t, err)
+		require.Nil(t, updatedNew)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Update missing new case
+	t.Run("Update Not Found", func(t *testing.T) {
+		new := &models.New{Entry: models.Entry{ID: uuid.New(), Title: "test-title", Content: "test-content"}}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockNews).WithArgs(new.ID).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+
+		updatedNew, err := repo.Update(context.Background(), new)
+		require.Equal(t, appErrors.CodeContentNotFound, appErrors.CodeOf(err))
+		require.Nil(t, updatedNew)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestRepo_Delete tests Delete method.
+func TestRepo_Delete(t *testing.T) {
+	t.Parallel()
+
+	// create mock db
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// create sqlx db with mock db
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	// new repository
+	repo := NewRepository[models.New](sqlxDB, newsType)
+
+	// Delete newNew success case
+	t.Run("Delete", func(t *testing.T) {
+
+		// delete new id
+		newID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockNews).WithArgs(newID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content"}).AddRow(newID, "test-title", "test-content"))
+		// mock query with args and return result
+		mock.ExpectExec(
+			`DELETE FROM news WHERE id = $1`,
+		).WithArgs(
+			newID,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		// event is written into outbox in the same transaction
+		mock.ExpectExec(
+			`INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)`,
+		).WithArgs(
+			sqlmock.AnyArg(),
+			models.AggregateNews,
+			sqlmock.AnyArg(),
+			models.EventNewsDeleted,
+			sqlmock.AnyArg(),
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		// deleted state is kept in audit log
+		mock.ExpectExec(addAuditEntry).WithArgs(
+			audit.ActorAnonymous,
+			"10.0.0.1",
+			"request-id",
+			models.AuditActionDelete,
+			"news",
+			newID,
+			sqlmock.AnyArg(),
+			nil,
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		// call Delete method
+		ctx := audit.WithActor(context.Background(), audit.Actor{Name: audit.ActorAnonymous, IP: "10.0.0.1", RequestID: "request-id"})
+		err := repo.Delete(ctx, newID)
+
+		// check error
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Delete new error case
+	t.Run("Delete Error", func(t *testing.T) {
+
+		// delete new id
+		newID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockNews).WithArgs(newID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content"}).AddRow(newID, "test-title", "test-content"))
+		// mock query with args and return error
+		mock.ExpectExec(
+			`DELETE FROM news WHERE id = $1`,
+		).WithArgs(
+			newID,
+		).WillReturnError(sqlmock.ErrCancelled)
+		mock.ExpectRollback()
+
+		// call Delete method
+		err := repo.Delete(context.Background(), newID)
+
+		// check error
+		require.Error(t, err)
+	})
+
+	// Delete new RowsAffected equal to zero case
+	t.Run("Delete RowsAffected equal to zero", func(t *testing.T) {
+
+		// delete new id
+		newID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockNews).WithArgs(newID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content"}).AddRow(newID, "test-title", "test-content"))
+		// mock query with args and return result, but rows affected equal to zero
+		mock.ExpectExec(
+			`DELETE FROM news WHERE id = $1`,
+		).WithArgs(
+			newID,
+		).WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectRollback()
+
+		// call Delete method
+		err := repo.Delete(context.Background(), newID)
+
+		// check error
+		require.Error(t, err)
+	})
+
+	// Delete new RowsAffected error case
+	t.Run("Delete RowsAffected Error", func(t *testing.T) {
+
+		// delete new id
+		newID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockNews).WithArgs(newID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content"}).AddRow(newID, "test-title", "test-content"))
+		// mock query with args and return error which rows affected
+		mock.ExpectExec(
+			`DELETE FROM news WHERE id = $1`,
+		).WithArgs(
+			newID,
+		).WillReturnResult(sqlmock.NewErrorResult(fmt.Errorf("rows affected error")))
+		mock.ExpectRollback()
+
+		// call Delete method
+		err := repo.Delete(context.Background(), newID)
+
+		// check error
+		require.Error(t, err)
+	})
+}
+
+// TestRepo_GetByID tests GetByID method.
+func TestRepo_GetByID(t *testing.T) {
+	t.Parallel()
+
+	// create mock db
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	// create sqlx db with mock db
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	// new repository
+	repo := NewRepository[models.New](sqlxDB, newsType)
+
+	// GetByID success case
+	t.Run("GetByID", func(t *testing.T) {
+
+		// new id
+		newID := uuid.New()
+
+		// mock rows
+		rows := sqlmock.NewRows(
+			[]string{"id", "title", "content"},
+		).AddRow(
+			newID,
+			"test-title",
+			"test-content",
+		)
+
+		// mock query with args and return rows
+		mock.ExpectQuery(
+			`SELECT id, title, content, tags, created_at, revision FROM news WHERE id = $1`,
+		).WithArgs(
+			newID,
+		).WillReturnRows(rows)
+
+		// call GetByID method
+		new, err := repo.GetByID(context.Background(), newID)
+
+		// check error and result
+		require.NoError(t, err)
+		require.NotNil(t, new)
+		require.Equal(t, newID, new.ID)
+		require.Equal(t, "test-title", new.Title)
+		require.Equal(t, "test-content", new.Content)
+	})
+
+	// GetByID error case
+	t.Run("GetByID Error", func(t *testing.T) {
+
+		// new id
+		newID := uuid.New()
+
+		// mock query with args and return error
+		mock.ExpectQuery(
+			`SELECT id, title, content, tags, created_at, revision FROM news WHERE id = $1`,
+		).WithArgs(
+			newID,
+		).WillReturnError(sqlmock.ErrCancelled)
+
+		// call GetByID method
+		new, err := repo.GetByID(context.Background(), newID)
+
+		// check error and result
+		require.Error(t, err)
+		require.Nil(t, new)
+	})
+}
+
+// TestRepo_GetAll tests GetAll method.
+func TestRepo_GetAll(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewRepository[models.New](sqlxDB, newsType)
+	query := &utils.PaginationQuery{Size: 10, Page: 1}
+
+	// title is passed as argument, not spliced into query
+	title := "go' OR '1'='1"
+	mock.ExpectQuery(`SELECT COUNT(id) FROM news WHERE title LIKE $1`).
+		WithArgs("%" + title + "%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`SELECT id, title, content, tags, created_at, revision FROM news WHERE title LIKE $1 ORDER BY created_at OFFSET $2 LIMIT $3`).
+		WithArgs("%"+title+"%", 0, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "content"}).AddRow(uuid.New(), "go", "test-content"))
+
+	list, err := repo.GetAll(context.Background(), title, query)
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, 1, list.TotalCount)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	body, err := json.Marshal(list)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `"has_more":false,"news":[{`)
+}
+
+// TestRepo_Upsert tests Upsert method.
+func TestRepo_Upsert(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewRepository[models.New](sqlxDB, newsType)
+
+	upsertNews := `INSERT INTO news (id, title, content, tags, created_at)
+	VALUES ($1, $2, $3, $4, COALESCE($5, CURRENT_TIMESTAMP))
+	ON CONFLICT (id) DO UPDATE SET
+		title = EXCLUDED.title,
+		content = EXCLUDED.content,
+		tags = EXCLUDED.tags,
+		revision = CASE WHEN news.title IS DISTINCT FROM EXCLUDED.title OR news.content IS DISTINCT FROM EXCLUDED.content
+			THEN news.revision + 1 ELSE news.revision END
+	RETURNING id, title, content, tags, created_at, revision, (xmax = 0) AS inserted`
+
+	for _, tc := range []struct {
+		name     string
+		inserted bool
+		event    string
+		action   string
+	}{
+		{name: "Created", inserted: true, event: models.EventNewsCreated, action: models.AuditActionCreate},
+		{name: "Updated", inserted: false, event: models.EventNewsUpdated, action: models.AuditActionUpdate},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			news := &models.New{Entry: models.Entry{ID: uuid.New(), Title: "test-title", Content: "test-content"}}
+
+			rows := sqlmock.NewRows(
+				[]string{"id", "title", "content", "tags", "created_at", "revision", "inserted"},
+			).AddRow(news.ID, news.Title, news.Content, news.Tags, time.Now(), 1, tc.inserted)
+
+			// created news has no previous state
+			current := sqlmock.NewRows([]string{"id", "title", "content"})
+			if !tc.inserted {
+				current.AddRow(news.ID, "old-title", "old-content")
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(lockNews).WithArgs(news.ID).WillReturnRows(current)
+			mock.ExpectQuery(upsertNews).WithArgs(
+				news.ID,
+				news.Title,
+				news.Content,
+				news.Tags,
+				nil,
+			).WillReturnRows(rows)
+			mock.ExpectExec(
+				`INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)`,
+			).WithArgs(
+				sqlmock.AnyArg(),
+				models.AggregateNews,
+				news.ID,
+				tc.event,
+				sqlmock.AnyArg(),
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectExec(addAuditEntry).WithArgs(
+				audit.ActorSystem,
+				"",
+				"",
+				tc.action,
+				"news",
+				news.ID,
+				sqlmock.AnyArg(),
+				sqlmock.AnyArg(),
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+			mock.ExpectCommit()
+
+			upserted, created, err := repo.Upsert(context.Background(), news)
+			require.NoError(t, err)
+			require.Equal(t, tc.inserted, created)
+			require.Equal(t, news.ID, upserted.ID)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestRepo_Stream tests Stream method.
+func TestRepo_Stream(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewRepository[models.New](sqlxDB, newsType)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := &models.ExportFilter{Title: "go", Tags: []string{"go", "sql"}, From: &from}
+
+	rows := sqlmock.NewRows(
+		[]string{"id", "title", "content", "created_at"},
+	).AddRow(uuid.New(), "go news", "content", from).AddRow(uuid.New(), "more go news", "content", from)
+
+	mock.ExpectQuery(
+		`SELECT id, title, content, tags, created_at, revision FROM news WHERE title LIKE $1 AND tags && $2 AND created_at >= $3 ORDER BY created_at, id`,
+	).WithArgs("%go%", models.Tags{"go", "sql"}, from).WillReturnRows(rows)
+
+	titles := make([]string, 0)
+	err = repo.Stream(context.Background(), filter, func(news *models.New) error {
+		titles = append(titles, news.Title)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go news", "more go news"}, titles)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestRepo_UpsertTranslation tests UpsertTranslation method.
+func TestRepo_UpsertTranslation(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewRepository[models.New](sqlxDB, newsType)
+
+	const upsertTranslation = `INSERT INTO news_translations (news_id, locale, title, content, source_revision) SELECT id, $2, $3, $4, revision FROM news WHERE id = $1 ON CONFLICT (news_id, locale) DO UPDATE SET title = EXCLUDED.title, content = EXCLUDED.content, source_revision = EXCLUDED.source_revision, updated_at = CURRENT_TIMESTAMP RETURNING news_id AS entity_id, locale, title, content, source_revision, updated_at`
+
+	t.Run("UpsertTranslation", func(t *testing.T) {
+		translation := &models.Translation{EntityID: uuid.New(), Locale: "uz", Title: "sarlavha", Content: "matn"}
+
+		mock.ExpectQuery(upsertTranslation).WithArgs(
+			translation.EntityID,
+			translation.Locale,
+			translation.Title,
+			translation.Content,
+		).WillReturnRows(sqlmock.NewRows(
+			[]string{"entity_id", "locale", "title", "content", "source_revision", "updated_at"},
+		).AddRow(translation.EntityID, translation.Locale, translation.Title, translation.Content, 3, time.Now()))
+
+		saved, err := repo.UpsertTranslation(context.Background(), translation)
+		require.NoError(t, err)
+		require.Equal(t, translation.EntityID, saved.EntityID)
+		require.Equal(t, 3, saved.SourceRevision)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// nothing is inserted for missing news
+	t.Run("Missing news", func(t *testing.T) {
+		translation := &models.Translation{EntityID: uuid.New(), Locale: "uz", Title: "sarlavha", Content: "matn"}
+
+		mock.ExpectQuery(upsertTranslation).WithArgs(
+			translation.EntityID,
+			translation.Locale,
+			translation.Title,
+			translation.Content,
+		).WillReturnRows(sqlmock.NewRows([]string{"entity_id", "locale", "title", "content", "source_revision", "updated_at"}))
+
+		saved, err := repo.UpsertTranslation(context.Background(), translation)
+		require.Nil(t, saved)
+		require.Equal(t, appErrors.CodeContentNotFound, appErrors.CodeOf(err))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestRepo_ExtraFields tests queries of content type with extra columns.
+func TestRepo_ExtraFields(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewRepository[event](sqlxDB, eventType)
+
+	item := &event{
+		Entry:    models.Entry{ID: uuid.New(), Title: "gophercon", Content: "test-content"},
+		Venue:    "Tashkent",
+		Speakers: models.Tags{"rob", "ken"},
+	}
+	columns := []string{"id", "title", "content", "tags", "created_at", "revision", "venue", "speakers"}
+	expectAuditEntry := func(action string) {
+		mock.ExpectExec(addAuditEntry).WithArgs(
+			audit.ActorSystem, "", "", action, "event", item.ID, sqlmock.AnyArg(), sqlmock.AnyArg(),
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(
+			`INSERT INTO events (id, title, content, tags, venue, speakers) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, title, content, tags, created_at, revision, venue, speakers`,
+		).WithArgs(
+			sqlmock.AnyArg(),
+			item.Title,
+			item.Content,
+			item.Tags,
+			item.Venue,
+			item.Speakers,
+		).WillReturnRows(sqlmock.NewRows(columns).AddRow(item.ID, item.Title, item.Content, item.Tags, time.Now(), 1, item.Venue, item.Speakers))
+		mock.ExpectExec(
+			`INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)`,
+		).WithArgs(
+			sqlmock.AnyArg(),
+			"event",
+			item.ID,
+			"event.created",
+			sqlmock.AnyArg(),
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectAuditEntry(models.AuditActionCreate)
+		mock.ExpectCommit()
+
+		created, err := repo.Create(context.Background(), item)
+		require.NoError(t, err)
+		require.Equal(t, "Tashkent", created.Venue)
+		require.Equal(t, models.Tags{"rob", "ken"}, created.Speakers)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(
+			`SELECT id, title, content, tags, created_at, revision, venue, speakers FROM events WHERE id = $1 FOR UPDATE`,
+		).WithArgs(item.ID).WillReturnRows(sqlmock.NewRows(columns).AddRow(item.ID, item.Title, "old-content", item.Tags, time.Now(), 1, item.Venue, item.Speakers))
+		mock.ExpectQuery(
+			`UPDATE events SET title = $1, content = $2, tags = $3, venue = $4, speakers = $5, revision = CASE WHEN title IS DISTINCT FROM $1 OR content IS DISTINCT FROM $2 THEN revision + 1 ELSE revision END WHERE id = $6 RETURNING id, title, content, tags, created_at, revision, venue, speakers`,
+		).WithArgs(
+			item.Title,
+			item.Content,
+			item.Tags,
+			item.Venue,
+			item.Speakers,
+			item.ID,
+		).WillReturnRows(sqlmock.NewRows(columns).AddRow(item.ID, item.Title, item.Content, item.Tags, time.Now(), 2, item.Venue, item.Speakers))
+		mock.ExpectExec(
+			`INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)`,
+		).WithArgs(
+			sqlmock.AnyArg(),
+			"event",
+			item.ID,
+			"event.updated",
+			sqlmock.AnyArg(),
+		).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectAuditEntry(models.AuditActionUpdate)
+		mock.ExpectCommit()
+
+		updated, err := repo.Update(context.Background(), item)
+		require.NoError(t, err)
+		require.Equal(t, 2, updated.Revision)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Missing field", func(t *testing.T) {
+		require.Panics(t, func() {
+			NewRepository[models.New](sqlxDB, eventType)
+		})
+	})
+}
@@ -0,0 +1,27 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase_mock.go -package mock
+package content
+
+import (
+	"context"
+	"io"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// UseCase content use case interface
+type UseCase[T any] interface {
+	Create(ctx context.Context, item *T) (*T, error)
+	Update(ctx context.Context, item *T) (*T, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*T, error)
+	GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.List[T], error)
+	GetByTags(ctx context.Context, tags []string, limit int) ([]*T, error)
+	Export(ctx context.Context, filter *models.ExportFilter, w io.Writer, format transfer.Format) (int, error)
+	Import(ctx context.Context, r io.Reader, format transfer.Format, dryRun bool) (*models.ImportResult, error)
+	GetTranslations(ctx context.Context, id uuid.UUID) (*models.TranslationsList, error)
+	PutTranslation(ctx context.Context, translation *models.Translation) (*models.Translation, error)
+	DeleteTranslation(ctx context.Context, id uuid.UUID, locale string) error
+}
@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"io"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/content"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/locale"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// content use case
+type contentUC[T any, P content.Model[T]] struct {
+	t       *content.Type
+	repo    content.Repository[T]
+	locales *locale.Locales
+	logger  logger.Logger
+	cfg     *config.Config
+}
+
+// NewUseCase content use case constructor
+func NewUseCase[T any, P content.Model[T]](cfg *config.Config, t *content.Type, repo content.Repository[T], logger logger.Logger) content.UseCase[T] {
+	return &contentUC[T, P]{t: t, repo: repo, locales: locale.NewLocales(cfg), logger: logger, cfg: cfg}
+}
+
+// op span name of use case method, e.g. newsUC.Create
+func (u *contentUC[T, P]) op(name string) string {
+	return u.t.Plural + "UC." + name
+}
+
+// Create content
+func (u *contentUC[T, P]) Create(ctx context.Context, item *T) (*T, error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("Create"))
+	defer span.End()
+
+	if err := u.validate(ctx, item); err != nil {
+		return nil, err
+	}
+	created, err := u.repo.Create(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.FromContext(ctx).Infof("Content created, Type: %s, ID: %s", u.t.Name, P(created).Base().ID)
+	return created, nil
+}
+
+// Update content
+func (u *contentUC[T, P]) Update(ctx context.Context, item *T) (*T, error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("Update"))
+	defer span.End()
+
+	if err := u.validate(ctx, item); err != nil {
+		return nil, err
+	}
+	updated, err := u.repo.Update(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.FromContext(ctx).Infof("Content updated, Type: %s, ID: %s", u.t.Name, P(updated).Base().ID)
+	return updated, nil
+}
+
+// Delete content
+func (u *contentUC[T, P]) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.StartSpan(ctx, u.op("Delete"))
+	defer span.End()
+
+	if err := u.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	u.logger.FromContext(ctx).Infof("Content deleted, Type: %s, ID: %s", u.t.Name, id)
+	return nil
+}
+
+// GetByID content
+func (u *contentUC[T, P]) GetByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("GetByID"))
+	defer span.End()
+
+	item, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.localize(ctx, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// GetAll content
+func (u *contentUC[T, P]) GetAll(ctx context.Context, title string, query *utils.PaginationQuery) (*models.List[T], error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("GetAll"))
+	defer span.End()
+
+	list, err := u.repo.GetAll(ctx, title, query)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.localize(ctx, list.Items...); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetByTags latest content having any of given tags
+func (u *contentUC[T, P]) GetByTags(ctx context.Context, tags []string, limit int) ([]*T, error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("GetByTags"))
+	defer span.End()
+
+	if len(tags) == 0 {
+		return []*T{}, nil
+	}
+
+	items, err := u.repo.GetByTags(ctx, tags, limit)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.localize(ctx, items...); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Export write content matching filter to w, rows are streamed from database one by one
+func (u *contentUC[T, P]) Export(ctx context.Context, filter *models.ExportFilter, w io.Writer, format transfer.Format) (int, error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("Export"))
+	defer span.End()
+
+	encoder := transfer.NewEncoder(w, format, u.t.TransferColumns())
+	exported := 0
+	if err := u.repo.Stream(ctx, filter, func(item *T) error {
+		exported++
+		return encoder.Encode(item)
+	}); err != nil {
+		return exported, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return exported, errors.Wrap(err, u.op("Export.Flush"))
+	}
+
+	u.logger.FromContext(ctx).Infof("Content exported, Type: %s, Count: %d, Format: %s", u.t.Name, exported, format)
+	return exported, nil
+}
+
+// Import upsert content read from r by ID, invalid records are reported by line and skipped.
+// In dry run mode records are validated and classified without being written.
+func (u *contentUC[T, P]) Import(ctx context.Context, r io.Reader, format transfer.Format, dryRun bool) (*models.ImportResult, error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("Import"))
+	defer span.End()
+
+	decoder := transfer.NewDecoder(r, format, u.t.TransferColumns())
+	result := &models.ImportResult{DryRun: dryRun, Errors: make([]*models.ImportLineError, 0)}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		line, doc, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var lineErr *transfer.LineError
+			if errors.As(err, &lineErr) {
+				result.AddError(lineErr.Line, lineErr.Err)
+				continue
+			}
+			return nil, errors.Wrap(err, u.op("Import.Next"))
+		}
+
+		item := new(T)
+		if err := utils.SanitizeJSON(ctx, doc, item); err != nil {
+			result.AddError(line, err)
+			continue
+		}
+		if err := u.validate(ctx, item); err != nil {
+			result.AddError(line, err)
+			continue
+		}
+		action, err := u.importItem(ctx, item, dryRun)
+		if err != nil {
+			result.AddError(line, err)
+			continue
+		}
+		result.Add(action)
+	}
+
+	u.logger.FromContext(ctx).Infof("Content imported, Type: %s, DryRun: %t, Created: %d, Updated: %d, Failed: %d", u.t.Name, dryRun, result.Created, result.Updated, result.Failed)
+	return result, nil
+}
+
+func (u *contentUC[T, P]) importItem(ctx context.Context, item *T, dryRun bool) (string, error) {
+	if !dryRun {
+		_, created, err := u.repo.Upsert(ctx, item)
+		if err != nil {
+			return "", err
+		}
+		if created {
+			return models.ImportActionCreated, nil
+		}
+		return models.ImportActionUpdated, nil
+	}
+
+	id := P(item).Base().ID
+	if id == uuid.Nil {
+		return models.ImportActionCreated, nil
+	}
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.ImportActionCreated, nil
+		}
+		return "", err
+	}
+	return models.ImportActionUpdated, nil
+}
+
+// GetTranslations translation status of content in every supported locale except the source one
+func (u *contentUC[T, P]) GetTranslations(ctx context.Context, id uuid.UUID) (*models.TranslationsList, error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("GetTranslations"))
+	defer span.End()
+
+	item, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := u.repo.GetTranslations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	byLocale := make(map[string]*models.Translation, len(translations))
+	for _, translation := range translations {
+		byLocale[translation.Locale] = translation
+	}
+
+	base := P(item).Base()
+	list := &models.TranslationsList{
+		EntityID:     base.ID,
+		SourceLocale: u.locales.Default(),
+		Revision:     base.Revision,
+		Translations: make([]*models.TranslationStatus, 0, len(u.locales.Supported())),
+	}
+	for _, loc := range u.locales.Supported() {
+		if loc == u.locales.Default() {
+			continue
+		}
+		list.Translations = append(list.Translations, models.NewTranslationStatus(loc, base.Revision, byLocale[loc]))
+	}
+
+	return list, nil
+}
+
+// PutTranslation create or replace translation of content into supported locale other than the source one
+func (u *contentUC[T, P]) PutTranslation(ctx context.Context, translation *models.Translation) (*models.Translation, error) {
+	ctx, span := tracing.StartSpan(ctx, u.op("PutTranslation"))
+	defer span.End()
+
+	translation.Locale = locale.Normalize(translation.Locale)
+	if err := u.validateLocale(translation.Locale); err != nil {
+		return nil, err
+	}
+
+	saved, err := u.repo.UpsertTranslation(ctx, translation)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.FromContext(ctx).Infof("Content translation saved, Type: %s, ID: %s, Locale: %s", u.t.Name, saved.EntityID, saved.Locale)
+	return saved, nil
+}
+
+// DeleteTranslation delete translation of content, source locale content falls back along the chain
+func (u *contentUC[T, P]) DeleteTranslation(ctx context.Context, id uuid.UUID, loc string) error {
+	ctx, span := tracing.StartSpan(ctx, u.op("DeleteTranslation"))
+	defer span.End()
+
+	loc = locale.Normalize(loc)
+	if err := u.validateLocale(loc); err != nil {
+		return err
+	}
+	if err := u.repo.DeleteTranslation(ctx, id, loc); err != nil {
+		return err
+	}
+
+	u.logger.FromContext(ctx).Infof("Content translation deleted, Type: %s, ID: %s, Locale: %s", u.t.Name, id, loc)
+	return nil
+}
+
+// validate rules of content type beyond struct tags, checked by models implementing content.Validator
+func (u *contentUC[T, P]) validate(ctx context.Context, item *T) error {
+	validator, ok := any(item).(content.Validator)
+	if !ok {
+		return nil
+	}
+	if err := validator.Validate(ctx); err != nil {
+		return appErrors.Wrap(err, appErrors.CodeValidation, err.Error())
+	}
+	return nil
+}
+
+func (u *contentUC[T, P]) validateLocale(loc string) error {
+	if !u.locales.IsSupported(loc) {
+		return appErrors.Newf(appErrors.CodeUnsupportedLocale, "unsupported locale %q", loc)
+	}
+	if loc == u.locales.Default() {
+		return appErrors.Newf(appErrors.CodeSourceLocale, "%q is source locale, update %s instead", loc, u.t.Name)
+	}
+	return nil
+}
+
+// localize replace title and content with translations in the first locale of requested fallback chain that has them,
+// locales after source locale are never used because source content always exists
+func (u *contentUC[T, P]) localize(ctx context.Context, items ...*T) error {
+	chain := locale.FromContext(ctx)
+	if len(chain) == 0 || len(items) == 0 {
+		return nil
+	}
+
+	source := u.locales.Default()
+	locales := make([]string, 0, len(chain))
+	for _, loc := range chain {
+		if loc == source {
+			break
+		}
+		locales = append(locales, loc)
+	}
+	if len(locales) == 0 {
+		for _, item := range items {
+			P(item).Base().Locale = source
+		}
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, P(item).Base().ID)
+	}
+
+	translations, err := u.repo.GetTranslationsByLocales(ctx, ids, locales)
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]*models.Translation, len(translations))
+	for _, translation := range translations {
+		byKey[translation.EntityID.String()+"/"+translation.Locale] = translation
+	}
+
+	for _, item := range items {
+		base := P(item).Base()
+		base.Locale = source
+		for _, loc := range locales {
+			translation, ok := byKey[base.ID.String()+"/"+loc]
+			if !ok {
+				continue
+			}
+			base.Title = translation.Title
+			base.Content = translation.Content
+			base.Locale = loc
+			base.TranslationOutdated = translation.SourceRevision < base.Revision
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,460 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/content"
+	"github.com/Dostonlv/task-del/internal/content/mock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/locale"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/transfer"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newsType same declaration as news registration, which imports this package
+var newsType = &content.Type{Name: "news", Plural: "news", Table: "news"}
+
+// event content type with extra fields and rules beyond struct tags
+type event struct {
+	models.Entry
+	Venue string `json:"venue" db:"venue" validate:"required"`
+	Mode  string `json:"mode" db:"mode"`
+}
+
+func (e *event) Validate(ctx context.Context) error {
+	if e.Mode == "online" && e.Venue != "online" {
+		return errors.New("online event venue must be online")
+	}
+	return nil
+}
+
+var eventType = &content.Type{
+	Name:   "event",
+	Plural: "events",
+	Table:  "events",
+	Fields: []content.Field{{Column: "venue"}, {Column: "mode"}},
+}
+
+func TestUC_Create(t *testing.T) {
+	t.Parallel()
+
+	// Create a new instance of the gomock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// logger, repository, usecase of new
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](nil, newsType, mockNewRepo, logger)
+
+	// model of new
+	new := models.New{}
+
+	// context
+	ctx := context.Background()
+
+	// mock the Create method of the repository
+	mockNewRepo.EXPECT().Create(
+		gomock.Any(),
+		gomock.Eq(&new),
+	).Return(&new, nil)
+
+	// call the Create method of the usecase
+	createdNew, err := newUC.Create(ctx, &new)
+
+	// check the result
+	require.NoError(t, err)
+	require.NotNil(t, createdNew)
+}
+
+func TestUC_Update(t *testing.T) {
+	t.Parallel()
+
+	// Create a new instance of the gomock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// logger, repository, usecase of new
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](nil, newsType, mockNewRepo, logger)
+
+	// model of new
+	new := models.New{
+		Entry: models.Entry{
+			ID:    uuid.New(),
+			Title: "update-title",
+		},
+	}
+
+	// mock the Update method of the repository
+	mockNewRepo.EXPECT().Update(
+		gomock.Any(),
+		gomock.Eq(&new),
+	).Return(&new, nil)
+
+	// call the Update method of the usecase
+	updatedNew, err := newUC.Update(context.Background(), &new)
+
+	// check the result
+	require.NoError(t, err)
+	require.NotNil(t, updatedNew)
+}
+
+func TestUC_Delete(t *testing.T) {
+	t.Parallel()
+
+	// Create a new instance of the gomock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// logger, repository, usecase of new
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](nil, newsType, mockNewRepo, logger)
+
+	// new id
+	newID := uuid.New()
+
+	// mock the Delete method of the repository
+	mockNewRepo.EXPECT().Delete(
+		gomock.Any(),
+		gomock.Eq(newID),
+	).Return(nil)
+
+	// call the Delete method of the usecase
+	err := newUC.Delete(context.Background(), newID)
+
+	// check the result
+	require.NoError(t, err)
+}
+
+func TestUC_GetByID(t *testing.T) {
+	t.Parallel()
+
+	// Create a new instance of the gomock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// logger, repository, usecase of new
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](nil, newsType, mockNewRepo, logger)
+
+	// new id
+	newID := uuid.New()
+
+	// mock the GetByID method of the repository
+	mockNewRepo.EXPECT().GetByID(
+		gomock.Any(),
+		gomock.Eq(newID),
+	).Return(&models.New{}, nil)
+
+	// call the GetByID method of the usecase
+	new, err := newUC.GetByID(context.Background(), newID)
+
+	// check the result
+	require.NoError(t, err)
+	require.NotNil(t, new)
+}
+
+func TestUC_GetAll(t *testing.T) {
+	t.Parallel()
+
+	// Create a new instance of the gomock controller
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// logger, repository, usecase of new
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](nil, newsType, mockNewRepo, logger)
+
+	// entity of NEW list, context, query
+	entity := models.NewsList{}
+	ctx := context.Background()
+	query := utils.PaginationQuery{
+		Page: 1,
+		Size: 10,
+	}
+
+	// mock the GetAll method of the repository
+	mockNewRepo.EXPECT().GetAll(
+		gomock.Any(),
+		"",
+		&query,
+	).Return(&entity, nil)
+
+	// call the GetAll method of the usecase
+	newList, err := newUC.GetAll(ctx, "", &query)
+
+	// check the result
+	require.NoError(t, err)
+	require.NotNil(t, newList)
+}
+
+func TestUC_Import(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](nil, newsType, mockNewRepo, logger)
+
+	existingID := uuid.New()
+	input := strings.Join([]string{
+		fmt.Sprintf(`{"id":"%s","title":"existing news","content":"updated content"}`, existingID),
+		`{"title":"new news","content":"fresh content","tags":["go"]}`,
+		``,
+		`{"title":"x","content":"too short title"}`,
+		`not json`,
+	}, "\n")
+
+	t.Run("Upsert", func(t *testing.T) {
+		mockNewRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, n *models.New) (*models.New, bool, error) {
+				return n, n.ID != existingID, nil
+			},
+		).Times(2)
+
+		result, err := newUC.Import(context.Background(), strings.NewReader(input), transfer.FormatNDJSON, false)
+		require.NoError(t, err)
+		require.False(t, result.DryRun)
+		require.Equal(t, 1, result.Created)
+		require.Equal(t, 1, result.Updated)
+		require.Equal(t, 2, result.Failed)
+		require.Equal(t, 4, result.Errors[0].Line)
+		require.Equal(t, 5, result.Errors[1].Line)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		mockNewRepo.EXPECT().GetByID(gomock.Any(), existingID).Return(&models.New{Entry: models.Entry{ID: existingID}}, nil)
+
+		result, err := newUC.Import(context.Background(), strings.NewReader(input), transfer.FormatNDJSON, true)
+		require.NoError(t, err)
+		require.True(t, result.DryRun)
+		require.Equal(t, 1, result.Created)
+		require.Equal(t, 1, result.Updated)
+		require.Equal(t, 2, result.Failed)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		mockNewRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, n *models.New) (*models.New, bool, error) {
+				require.Equal(t, models.Tags{"go", "sql"}, n.Tags)
+				return n, true, nil
+			},
+		)
+
+		csvInput := "title,content,tags\nnews title,news content,go;sql\nbroken row\n"
+		result, err := newUC.Import(context.Background(), strings.NewReader(csvInput), transfer.FormatCSV, false)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Created)
+		require.Equal(t, 1, result.Failed)
+		require.Equal(t, 3, result.Errors[0].Line)
+	})
+}
+
+func TestUC_Export(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](nil, newsType, mockNewRepo, logger)
+
+	filter := &models.ExportFilter{Tags: []string{"go"}}
+	mockNewRepo.EXPECT().Stream(gomock.Any(), filter, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, filter *models.ExportFilter, fn func(n *models.New) error) error {
+			for _, title := range []string{"first", "second"} {
+				if err := fn(&models.New{Entry: models.Entry{Title: title, Content: "content", Tags: models.Tags{"go", "sql"}}}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+
+	var out bytes.Buffer
+	exported, err := newUC.Export(context.Background(), filter, &out, transfer.FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, 2, exported)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, "id,title,content,tags,created_at", lines[0])
+	require.Contains(t, lines[1], ",first,content,go;sql,")
+}
+
+func TestUC_GetByIDLocalized(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{Locales: config.LocalesConfig{
+		Default:   "en",
+		Supported: []string{"en", "ru", "uz"},
+		Fallbacks: map[string][]string{"uz": {"ru"}},
+	}}
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](cfg, newsType, mockNewRepo, logger)
+
+	newsID := uuid.New()
+	source := func() *models.New {
+		return &models.New{Entry: models.Entry{ID: newsID, Title: "source title", Content: "source content", Revision: 3}}
+	}
+
+	t.Run("Fallback", func(t *testing.T) {
+		mockNewRepo.EXPECT().GetByID(gomock.Any(), newsID).Return(source(), nil)
+		mockNewRepo.EXPECT().GetTranslationsByLocales(gomock.Any(), []uuid.UUID{newsID}, []string{"uz", "ru"}).Return([]*models.Translation{
+			{EntityID: newsID, Locale: "ru", Title: "ru title", Content: "ru content", SourceRevision: 2},
+		}, nil)
+
+		ctx := locale.WithContext(context.Background(), []string{"uz", "ru", "en"})
+		n, err := newUC.GetByID(ctx, newsID)
+		require.NoError(t, err)
+		require.Equal(t, "ru", n.Locale)
+		require.Equal(t, "ru title", n.Title)
+		require.True(t, n.TranslationOutdated)
+	})
+
+	t.Run("Source", func(t *testing.T) {
+		mockNewRepo.EXPECT().GetByID(gomock.Any(), newsID).Return(source(), nil)
+		mockNewRepo.EXPECT().GetTranslationsByLocales(gomock.Any(), []uuid.UUID{newsID}, []string{"ru"}).Return([]*models.Translation{}, nil)
+
+		ctx := locale.WithContext(context.Background(), []string{"ru", "en"})
+		n, err := newUC.GetByID(ctx, newsID)
+		require.NoError(t, err)
+		require.Equal(t, "en", n.Locale)
+		require.Equal(t, "source title", n.Title)
+		require.False(t, n.TranslationOutdated)
+	})
+
+	// source locale preferred over translations is returned without loading them
+	t.Run("SourceFirst", func(t *testing.T) {
+		mockNewRepo.EXPECT().GetByID(gomock.Any(), newsID).Return(source(), nil)
+
+		ctx := locale.WithContext(context.Background(), []string{"en", "uz", "ru"})
+		n, err := newUC.GetByID(ctx, newsID)
+		require.NoError(t, err)
+		require.Equal(t, "en", n.Locale)
+		require.Equal(t, "source title", n.Title)
+	})
+
+	t.Run("SourceBeforeFallback", func(t *testing.T) {
+		mockNewRepo.EXPECT().GetByID(gomock.Any(), newsID).Return(source(), nil)
+		mockNewRepo.EXPECT().GetTranslationsByLocales(gomock.Any(), []uuid.UUID{newsID}, []string{"uz"}).Return([]*models.Translation{}, nil)
+
+		ctx := locale.WithContext(context.Background(), []string{"uz", "en", "ru"})
+		n, err := newUC.GetByID(ctx, newsID)
+		require.NoError(t, err)
+		require.Equal(t, "en", n.Locale)
+		require.Equal(t, "source title", n.Title)
+	})
+
+	t.Run("NotRequested", func(t *testing.T) {
+		mockNewRepo.EXPECT().GetByID(gomock.Any(), newsID).Return(source(), nil)
+
+		n, err := newUC.GetByID(context.Background(), newsID)
+		require.NoError(t, err)
+		require.Empty(t, n.Locale)
+	})
+}
+
+func TestUC_GetTranslations(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{Locales: config.LocalesConfig{Default: "en", Supported: []string{"en", "ru", "uz"}}}
+	logger := logger.NewApiLogger(nil)
+	mockNewRepo := mock.NewMockRepository[models.New](ctrl)
+	newUC := NewUseCase[models.New](cfg, newsType, mockNewRepo, logger)
+
+	newsID := uuid.New()
+	mockNewRepo.EXPECT().GetByID(gomock.Any(), newsID).Return(&models.New{Entry: models.Entry{ID: newsID, Revision: 2}}, nil)
+	mockNewRepo.EXPECT().GetTranslations(gomock.Any(), newsID).Return([]*models.Translation{
+		{EntityID: newsID, Locale: "ru", SourceRevision: 1},
+	}, nil)
+
+	list, err := newUC.GetTranslations(context.Background(), newsID)
+	require.NoError(t, err)
+	require.Equal(t, "en", list.SourceLocale)
+	require.Len(t, list.Translations, 2)
+	require.Equal(t, models.TranslationOutdated, list.Translations[0].Status)
+	require.Equal(t, models.TranslationMissing, list.Translations[1].Status)
+
+	_, err = newUC.PutTranslation(context.Background(), &models.Translation{EntityID: newsID, Locale: "EN"})
+	require.Error(t, err)
+	_, err = newUC.PutTranslation(context.Background(), &models.Translation{EntityID: newsID, Locale: "de"})
+	require.Error(t, err)
+}
+
+func TestUC_ExtraFields(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	logger := logger.NewApiLogger(nil)
+	mockEventRepo := mock.NewMockRepository[event](ctrl)
+	eventUC := NewUseCase[event](nil, eventType, mockEventRepo, logger)
+
+	t.Run("Validate", func(t *testing.T) {
+		_, err := eventUC.Create(context.Background(), &event{Venue: "Tashkent", Mode: "online"})
+		require.Error(t, err)
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		mockEventRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, e *event) (*event, bool, error) {
+				require.Equal(t, "Tashkent", e.Venue)
+				require.Equal(t, "offline", e.Mode)
+				return e, true, nil
+			},
+		)
+
+		input := "id,title,content,tags,created_at,venue,mode\n" +
+			",gophercon,talks about go,go,,Tashkent,offline\n" +
+			",meetup,talks about sql,sql,,,offline\n" +
+			",workshop,hands on go,go,,Tashkent,online\n"
+		result, err := eventUC.Import(context.Background(), strings.NewReader(input), transfer.FormatCSV, false)
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Created)
+		require.Equal(t, 2, result.Failed)
+		require.Equal(t, 3, result.Errors[0].Line)
+		require.Equal(t, 4, result.Errors[1].Line)
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		mockEventRepo.EXPECT().Stream(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, filter *models.ExportFilter, fn func(*event) error) error {
+				return fn(&event{Entry: models.Entry{Title: "gophercon", Content: "talks about go"}, Venue: "Tashkent", Mode: "offline"})
+			},
+		)
+
+		buf := &bytes.Buffer{}
+		exported, err := eventUC.Export(context.Background(), &models.ExportFilter{}, buf, transfer.FormatCSV)
+		require.NoError(t, err)
+		require.Equal(t, 1, exported)
+		require.True(t, strings.HasPrefix(buf.String(), "id,title,content,tags,created_at,venue,mode\n"))
+		require.Contains(t, buf.String(), ",Tashkent,offline\n")
+	})
+}
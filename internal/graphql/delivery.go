@@ -0,0 +1,9 @@
+package graphql
+
+import "github.com/labstack/echo/v4"
+
+// Handlers GraphQL HTTP Handlers interface
+type Handlers interface {
+	Query() echo.HandlerFunc
+	GraphiQL() echo.HandlerFunc
+}
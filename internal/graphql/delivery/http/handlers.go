@@ -0,0 +1,170 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/Dostonlv/task-del/config"
+	gql "github.com/Dostonlv/task-del/internal/graphql"
+	"github.com/Dostonlv/task-del/internal/graphql/schema"
+	"github.com/Dostonlv/task-del/internal/news"
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// graphql handlers
+type graphqlHandlers struct {
+	cfg    *config.Config
+	schema graphql.Schema
+	newsUC news.UseCase
+	limits schema.Limits
+	logger logger.Logger
+}
+
+// NewGraphQLHandlers GraphQL handlers constructor
+func NewGraphQLHandlers(cfg *config.Config, gqlSchema graphql.Schema, newsUC news.UseCase, logger logger.Logger) gql.Handlers {
+	return &graphqlHandlers{
+		cfg:    cfg,
+		schema: gqlSchema,
+		newsUC: newsUC,
+		limits: schema.Limits{MaxDepth: cfg.GraphQL.MaxDepth, MaxComplexity: cfg.GraphQL.MaxComplexity},
+		logger: logger,
+	}
+}
+
+// graphqlRequest GraphQL request body
+type graphqlRequest struct {
+	Query         string                 `json:"query" query:"query"`
+	OperationName string                 `json:"operationName" query:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphqlResponse GraphQL result as documented in swagger, same shape as graphql.Result
+type graphqlResponse struct {
+	Data   interface{}    `json:"data"`
+	Errors []graphqlError `json:"errors,omitempty"`
+}
+
+// graphqlError GraphQL error as documented in swagger, same shape as gqlerrors.FormattedError
+type graphqlError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// Query
+// @Summary Execute GraphQL query
+// @Description execute query against blogs and news schema, depth and complexity are limited
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Param body body graphqlRequest true "query"
+// @Success 200 {object} graphqlResponse
+// @Failure 400 {object} graphqlResponse
+// @Router /v1/graphql [post]
+func (h *graphqlHandlers) Query() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := &graphqlRequest{}
+		if err := c.Bind(req); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		if req.Query == "" {
+			return c.JSON(http.StatusBadRequest, errorResult(errors.New("query must not be empty")))
+		}
+
+		doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, errorResult(err))
+		}
+
+		if err := h.limits.Check(doc, req.OperationName, req.Variables); err != nil {
+			return c.JSON(http.StatusBadRequest, errorResult(err))
+		}
+
+		if validation := graphql.ValidateDocument(&h.schema, doc, nil); !validation.IsValid {
+			return c.JSON(http.StatusBadRequest, &graphql.Result{Errors: validation.Errors})
+		}
+
+		ctx := schema.WithLoaders(c.Request().Context(), h.newsUC)
+		result := graphql.Execute(graphql.ExecuteParams{
+			Schema:        h.schema,
+			AST:           doc,
+			OperationName: req.OperationName,
+			Args:          req.Variables,
+			Context:       ctx,
+		})
+		result.Errors = h.maskErrors(c, result.Errors)
+
+		return c.JSON(http.StatusOK, result)
+	}
+}
+
+// GraphiQL
+// @Summary GraphiQL playground
+// @Description in-browser IDE for exploring the schema, disabled in production
+// @Tags GraphQL
+// @Produce html
+// @Success 200 {string} string
+// @Router /v1/graphiql [get]
+func (h *graphqlHandlers) GraphiQL() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.HTML(http.StatusOK, graphiqlPage)
+	}
+}
+
+// maskErrors translate resolver errors with httpErrors rules, internal details are logged and not returned
+func (h *graphqlHandlers) maskErrors(c echo.Context, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	for i, formatted := range errs {
+		original := formatted.OriginalError()
+		if located, ok := original.(*gqlerrors.Error); ok && located.OriginalError != nil {
+			original = located.OriginalError
+		}
+		if original == nil {
+			continue
+		}
+
+		restErr := httpErrors.ParseErrors(original)
+		if restErr.Status() >= http.StatusInternalServerError {
+			utils.LogResponseError(c, h.logger, original)
+		}
+
+		errs[i].Message = restErr.Error()
+		errs[i].Extensions = map[string]interface{}{"status": restErr.Status(), "code": restErr.Code()}
+		if re, ok := httpErrors.Localize(restErr, c.Request().Header.Get("Accept-Language")).(httpErrors.RestError); ok {
+			errs[i].Message = re.ErrError
+			if len(re.ErrFields) > 0 {
+				errs[i].Extensions["fields"] = re.ErrFields
+			}
+		}
+	}
+
+	return errs
+}
+
+func errorResult(err error) *graphql.Result {
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{gqlerrors.FormatError(err)}}
+}
+
+const graphiqlPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>GraphiQL</title>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css" />
+</head>
+<body style="margin: 0;">
+  <div id="graphiql" style="height: 100vh;"></div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: window.location.pathname.replace(/graphiql$/, 'graphql') });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(React.createElement(GraphiQL, { fetcher }));
+  </script>
+</body>
+</html>`
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/graphql"
+	"github.com/labstack/echo/v4"
+)
+
+// Map graphql routes, playground is not exposed in production
+func MapGraphQLRoutes(group *echo.Group, h graphql.Handlers, mode string) {
+	group.POST("/graphql", h.Query())
+	group.GET("/graphql", h.Query())
+	if mode != config.ModeProduction {
+		group.GET("/graphiql", h.GraphiQL())
+	}
+}
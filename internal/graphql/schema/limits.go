@@ -0,0 +1,122 @@
+package schema
+
+import (
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultListMultiplier = 10
+)
+
+// listSizeArgs arguments which bound the number of returned items of a list field
+var listSizeArgs = map[string]bool{"size": true, "limit": true}
+
+// Limits query depth and complexity limits, zero disables the limit
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Check reject operations exceeding configured depth or complexity.
+// Every field costs 1, fields bounded by size or limit argument multiply the cost of their selections.
+func (l Limits) Check(doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operations []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch d := def.(type) {
+		case *ast.FragmentDefinition:
+			fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operations = append(operations, d)
+			}
+		}
+	}
+
+	for _, op := range operations {
+		w := &limitsWalker{fragments: fragments, variables: variables, visiting: make(map[string]bool)}
+		depth, complexity := w.selectionSet(op.SelectionSet)
+		if l.MaxDepth > 0 && depth > l.MaxDepth {
+			return errors.Errorf("query depth %d exceeds maximum allowed depth %d", depth, l.MaxDepth)
+		}
+		if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+			return errors.Errorf("query complexity %d exceeds maximum allowed complexity %d", complexity, l.MaxComplexity)
+		}
+	}
+
+	return nil
+}
+
+type limitsWalker struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+func (w *limitsWalker) selectionSet(set *ast.SelectionSet) (depth int, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, sel := range set.Selections {
+		var d, c int
+		switch s := sel.(type) {
+		case *ast.Field:
+			childDepth, childComplexity := w.selectionSet(s.SelectionSet)
+			d = childDepth + 1
+			c = 1 + childComplexity*w.multiplier(s)
+		case *ast.InlineFragment:
+			d, c = w.selectionSet(s.SelectionSet)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			frag, ok := w.fragments[name]
+			// cycles are rejected by validation, guard anyway
+			if !ok || w.visiting[name] {
+				continue
+			}
+			w.visiting[name] = true
+			d, c = w.selectionSet(frag.SelectionSet)
+			w.visiting[name] = false
+		}
+		if d > depth {
+			depth = d
+		}
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+func (w *limitsWalker) multiplier(field *ast.Field) int {
+	if field.SelectionSet == nil {
+		return 1
+	}
+	for _, arg := range field.Arguments {
+		if !listSizeArgs[arg.Name.Value] {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := w.variables[v.Name.Value].(type) {
+			case float64:
+				if n > 0 {
+					return int(n)
+				}
+			case int:
+				if n > 0 {
+					return n
+				}
+			}
+		}
+		return defaultListMultiplier
+	}
+
+	return 1
+}
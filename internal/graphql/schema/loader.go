@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/news"
+)
+
+// loadersCtxKey is a key used for the request scoped loaders in context
+type loadersCtxKey struct{}
+
+// Loaders request scoped batch loaders
+type Loaders struct {
+	relatedNews *relatedNewsLoader
+}
+
+// WithLoaders returns copy of ctx carrying fresh request scoped loaders
+func WithLoaders(ctx context.Context, newsUC news.UseCase) context.Context {
+	return context.WithValue(ctx, loadersCtxKey{}, &Loaders{
+		relatedNews: &relatedNewsLoader{newsUC: newsUC, pending: make(map[string]relatedNewsKey)},
+	})
+}
+
+func loadersFromContext(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersCtxKey{}).(*Loaders)
+	return loaders
+}
+
+type relatedNewsKey struct {
+	tags  []string
+	limit int
+}
+
+type relatedNewsResult struct {
+	news []*models.New
+	err  error
+}
+
+// relatedNewsLoader collect related news lookups of all blogs on the same query level
+// and load them with a single news use case call
+type relatedNewsLoader struct {
+	newsUC news.UseCase
+
+	mu      sync.Mutex
+	pending map[string]relatedNewsKey
+	results map[string]relatedNewsResult
+}
+
+// Load register lookup and return thunk resolved by graphql executor after all fields of the level are resolved
+func (l *relatedNewsLoader) Load(ctx context.Context, blog *models.Blog, limit int) func() (interface{}, error) {
+	key := blog.ID.String()
+
+	l.mu.Lock()
+	l.pending[key] = relatedNewsKey{tags: blog.Tags, limit: limit}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.dispatch(ctx)
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		res := l.results[key]
+		return res.news, res.err
+	}
+}
+
+func (l *relatedNewsLoader) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.pending) == 0 {
+		return
+	}
+	if l.results == nil {
+		l.results = make(map[string]relatedNewsResult, len(l.pending))
+	}
+
+	seen := make(map[string]bool)
+	tags := make([]string, 0)
+	total := 0
+	for _, k := range l.pending {
+		total += k.limit
+		for _, tag := range k.tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	newsList, err := l.newsUC.GetByTags(ctx, tags, total)
+	for blogID, k := range l.pending {
+		if err != nil {
+			l.results[blogID] = relatedNewsResult{err: err}
+			continue
+		}
+		l.results[blogID] = relatedNewsResult{news: filterByTags(newsList, k.tags, k.limit)}
+	}
+	l.pending = make(map[string]relatedNewsKey)
+}
+
+func filterByTags(newsList []*models.New, tags []string, limit int) []*models.New {
+	wanted := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		wanted[tag] = true
+	}
+
+	res := make([]*models.New, 0, limit)
+	for _, n := range newsList {
+		if len(res) >= limit {
+			break
+		}
+		for _, tag := range n.Tags {
+			if wanted[tag] {
+				res = append(res, n)
+				break
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,182 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/blogs"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/news"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+const defaultRelatedNewsLimit = 5
+
+// NewSchema build graphql schema, resolvers delegate to blogs and news use cases
+func NewSchema(blogsUC blogs.UseCase, newsUC news.UseCase) (graphql.Schema, error) {
+	newsType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "News",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolveNews(func(n *models.New) interface{} { return n.ID.String() })},
+			"title":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolveNews(func(n *models.New) interface{} { return n.Title })},
+			"content":   &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolveNews(func(n *models.New) interface{} { return n.Content })},
+			"tags":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), Resolve: resolveNews(func(n *models.New) interface{} { return tagsOrEmpty(n.Tags) })},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: resolveNews(func(n *models.New) interface{} { return n.CreatedAt.UTC().Format(time.RFC3339) })},
+		},
+	})
+
+	blogType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Blog",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolveBlog(func(b *models.Blog) interface{} { return b.ID.String() })},
+			"title":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolveBlog(func(b *models.Blog) interface{} { return b.Title })},
+			"content":   &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolveBlog(func(b *models.Blog) interface{} { return b.Content })},
+			"tags":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), Resolve: resolveBlog(func(b *models.Blog) interface{} { return tagsOrEmpty(b.Tags) })},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: resolveBlog(func(b *models.Blog) interface{} { return b.CreatedAt.UTC().Format(time.RFC3339) })},
+			"relatedNews": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(newsType))),
+				Description: "latest news sharing at least one tag with the blog",
+				Args: graphql.FieldConfigArgument{
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultRelatedNewsLimit},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					blog, _ := p.Source.(*models.Blog)
+					limit, _ := p.Args["limit"].(int)
+					if blog == nil || len(blog.Tags) == 0 || limit <= 0 {
+						return []*models.New{}, nil
+					}
+
+					if loaders := loadersFromContext(p.Context); loaders != nil {
+						return loaders.relatedNews.Load(p.Context, blog, limit), nil
+					}
+					return newsUC.GetByTags(p.Context, blog.Tags, limit)
+				},
+			},
+		},
+	})
+
+	paginationFields := func(items string, itemType graphql.Output, resolveItems graphql.FieldResolveFn) graphql.Fields {
+		return graphql.Fields{
+			"totalCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"totalPages": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"page":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"size":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"hasMore":    &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			items:        &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(itemType))), Resolve: resolveItems},
+		}
+	}
+
+	blogsListType := graphql.NewObject(graphql.ObjectConfig{Name: "BlogsList", Fields: paginationFields("blogs", blogType, func(p graphql.ResolveParams) (interface{}, error) {
+		if list, ok := p.Source.(*models.BlogsList); ok {
+			return list.Items, nil
+		}
+		return nil, nil
+	})})
+	newsListType := graphql.NewObject(graphql.ObjectConfig{Name: "NewsList", Fields: paginationFields("news", newsType, func(p graphql.ResolveParams) (interface{}, error) {
+		if list, ok := p.Source.(*models.NewsList); ok {
+			return list.Items, nil
+		}
+		return nil, nil
+	})})
+
+	listArgs := graphql.FieldConfigArgument{
+		"title":   &graphql.ArgumentConfig{Type: graphql.String},
+		"page":    &graphql.ArgumentConfig{Type: graphql.Int},
+		"size":    &graphql.ArgumentConfig{Type: graphql.Int},
+		"orderBy": &graphql.ArgumentConfig{Type: graphql.String},
+	}
+	idArgs := graphql.FieldConfigArgument{
+		"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"blog": &graphql.Field{
+				Type: blogType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					blogID, err := parseID(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return blogsUC.GetByID(p.Context, blogID)
+				},
+			},
+			"blogs": &graphql.Field{
+				Type: graphql.NewNonNull(blogsListType),
+				Args: listArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					title, _ := p.Args["title"].(string)
+					return blogsUC.GetAll(p.Context, title, paginationFromArgs(p.Args))
+				},
+			},
+			"news": &graphql.Field{
+				Type: newsType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					newsID, err := parseID(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return newsUC.GetByID(p.Context, newsID)
+				},
+			},
+			"newsList": &graphql.Field{
+				Type: graphql.NewNonNull(newsListType),
+				Args: listArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					title, _ := p.Args["title"].(string)
+					return newsUC.GetAll(p.Context, title, paginationFromArgs(p.Args))
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func resolveBlog(field func(b *models.Blog) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		blog, ok := p.Source.(*models.Blog)
+		if !ok || blog == nil {
+			return nil, nil
+		}
+		return field(blog), nil
+	}
+}
+
+func resolveNews(field func(n *models.New) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		n, ok := p.Source.(*models.New)
+		if !ok || n == nil {
+			return nil, nil
+		}
+		return field(n), nil
+	}
+}
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func parseID(args map[string]interface{}) (uuid.UUID, error) {
+	id, _ := args["id"].(string)
+	parsed, err := utils.ParseID(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return parsed, nil
+}
+
+// paginationFromArgs mirror REST pagination query params
+func paginationFromArgs(args map[string]interface{}) *utils.PaginationQuery {
+	page, _ := args["page"].(int)
+	size, _ := args["size"].(int)
+	orderBy, _ := args["orderBy"].(string)
+	return utils.NewPaginationQuery(page, size, orderBy)
+}
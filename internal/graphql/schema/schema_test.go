@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dostonlv/task-del/internal/content/mock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSchema_RelatedNewsBatched(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBlogsUC := mock.NewMockUseCase[models.Blog](ctrl)
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+
+	gqlSchema, err := NewSchema(mockBlogsUC, mockNewsUC)
+	require.NoError(t, err)
+
+	goBlog := &models.Blog{Entry: models.Entry{ID: uuid.New(), Title: "go", Tags: models.Tags{"go"}}}
+	dbBlog := &models.Blog{Entry: models.Entry{ID: uuid.New(), Title: "db", Tags: models.Tags{"postgres"}}}
+	goNews := &models.New{Entry: models.Entry{ID: uuid.New(), Title: "go news", Tags: models.Tags{"go"}}}
+	dbNews := &models.New{Entry: models.Entry{ID: uuid.New(), Title: "db news", Tags: models.Tags{"postgres"}}}
+
+	mockBlogsUC.EXPECT().GetAll(gomock.Any(), "", gomock.Any()).Return(&models.BlogsList{
+		TotalCount: 2,
+		Items:      []*models.Blog{goBlog, dbBlog},
+	}, nil)
+	// related news of every blog are loaded with a single call
+	mockNewsUC.EXPECT().GetByTags(gomock.Any(), gomock.InAnyOrder([]string{"go", "postgres"}), 4).
+		Return([]*models.New{goNews, dbNews}, nil).Times(1)
+
+	result := graphql.Do(graphql.Params{
+		Schema:        gqlSchema,
+		RequestString: `{ blogs { totalCount blogs { title relatedNews(limit: 2) { title } } } }`,
+		Context:       WithLoaders(context.Background(), mockNewsUC),
+	})
+	require.Empty(t, result.Errors)
+
+	blogsList := result.Data.(map[string]interface{})["blogs"].(map[string]interface{})
+	require.Equal(t, 2, blogsList["totalCount"])
+
+	items := blogsList["blogs"].([]interface{})
+	require.Len(t, items, 2)
+	related := items[0].(map[string]interface{})["relatedNews"].([]interface{})
+	require.Len(t, related, 1)
+	require.Equal(t, "go news", related[0].(map[string]interface{})["title"])
+}
+
+func TestLimits_Check(t *testing.T) {
+	t.Parallel()
+
+	parse := func(query string) *ast.Document {
+		doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query)})})
+		require.NoError(t, err)
+		return doc
+	}
+
+	t.Run("Depth exceeded", func(t *testing.T) {
+		doc := parse(`{ blogs { blogs { relatedNews { title } } } }`)
+		require.Error(t, Limits{MaxDepth: 3}.Check(doc, "", nil))
+		require.NoError(t, Limits{MaxDepth: 4}.Check(doc, "", nil))
+	})
+
+	t.Run("Complexity exceeded", func(t *testing.T) {
+		doc := parse(`query($size: Int) { blogs(size: $size) { blogs { relatedNews(limit: 50) { title content } } } }`)
+		require.Error(t, Limits{MaxComplexity: 1000}.Check(doc, "", map[string]interface{}{"size": 100}))
+		require.NoError(t, Limits{MaxComplexity: 1000}.Check(doc, "", map[string]interface{}{"size": 2}))
+	})
+}
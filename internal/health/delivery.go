@@ -0,0 +1,10 @@
+package health
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Health HTTP Handlers interface
+type Handlers interface {
+	Livez() echo.HandlerFunc
+	Readyz() echo.HandlerFunc
+	Version() echo.HandlerFunc
+}
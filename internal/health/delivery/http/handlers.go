@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/health"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/buildinfo"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/labstack/echo/v4"
+)
+
+// health handlers
+type healthHandlers struct {
+	cfg      *config.Config
+	healthUC health.UseCase
+	logger   logger.Logger
+}
+
+// NewHealthHandlers Health handlers constructor
+func NewHealthHandlers(cfg *config.Config, healthUC health.UseCase, logger logger.Logger) health.Handlers {
+	return &healthHandlers{cfg: cfg, healthUC: healthUC, logger: logger}
+}
+
+// Livez
+// @Summary Liveness probe
+// @Description process is running and able to serve requests
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /livez [get]
+func (h *healthHandlers) Livez() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": models.HealthStatusOK})
+	}
+}
+
+// Readyz
+// @Summary Readiness probe
+// @Description run dependency checks, returns 503 if any of them fails
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthReport
+// @Failure 503 {object} models.HealthReport
+// @Router /readyz [get]
+func (h *healthHandlers) Readyz() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		report := h.healthUC.Readiness(c.Request().Context())
+		if report.Status != models.HealthStatusOK {
+			return c.JSON(http.StatusServiceUnavailable, report)
+		}
+
+		return c.JSON(http.StatusOK, report)
+	}
+}
+
+// Version
+// @Summary Build info
+// @Description version, commit and build time of running binary
+// @Tags Health
+// @Produce json
+// @Success 200 {object} buildinfo.Info
+// @Router /version [get]
+func (h *healthHandlers) Version() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, buildinfo.Get(h.cfg.Server.AppVersion))
+	}
+}
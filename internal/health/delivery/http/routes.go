@@ -0,0 +1,13 @@
+package http
+
+import (
+	"github.com/Dostonlv/task-del/internal/health"
+	"github.com/labstack/echo/v4"
+)
+
+// Map health routes
+func MapHealthRoutes(e *echo.Echo, h health.Handlers) {
+	e.GET("/livez", h.Livez())
+	e.GET("/readyz", h.Readyz())
+	e.GET("/version", h.Version())
+}
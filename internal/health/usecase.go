@@ -0,0 +1,17 @@
+package health
+
+import (
+	"context"
+
+	"github.com/Dostonlv/task-del/internal/models"
+)
+
+// Check dependency check, returns nil when dependency is healthy
+type Check func(ctx context.Context) error
+
+// health use case interface
+type UseCase interface {
+	Register(name string, check Check)
+	Readiness(ctx context.Context) *models.HealthReport
+	SetShuttingDown()
+}
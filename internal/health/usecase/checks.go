@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"io/fs"
+	"regexp"
+	"strconv"
+
+	"github.com/Dostonlv/task-del/internal/health"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_.*\.up\.sql$`)
+
+// PostgresCheck ping database
+func PostgresCheck(db *sqlx.DB) health.Check {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
+
+// MigrationsCheck compare applied schema version with the latest migration in migrations
+func MigrationsCheck(db *sqlx.DB, migrations fs.FS) health.Check {
+	return func(ctx context.Context) error {
+		latest, err := LatestMigrationVersion(migrations)
+		if err != nil {
+			return err
+		}
+
+		var (
+			version int64
+			dirty   bool
+		)
+		if err := db.QueryRowxContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty); err != nil {
+			return errors.Wrap(err, "MigrationsCheck.QueryRowxContext")
+		}
+		if dirty {
+			return errors.Errorf("schema version %d is dirty", version)
+		}
+		if version != latest {
+			return errors.Errorf("schema version %d, expected %d", version, latest)
+		}
+
+		return nil
+	}
+}
+
+// LatestMigrationVersion returns highest up migration version in migrations root
+func LatestMigrationVersion(migrations fs.FS) (int64, error) {
+	entries, err := fs.ReadDir(migrations, ".")
+	if err != nil {
+		return 0, errors.Wrap(err, "LatestMigrationVersion.ReadDir")
+	}
+
+	var latest int64
+	for _, e := range entries {
+		m := migrationFileRe.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		v, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "LatestMigrationVersion.ParseInt")
+		}
+		if v > latest {
+			latest = v
+		}
+	}
+
+	return latest, nil
+}
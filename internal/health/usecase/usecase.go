@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/health"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultCheckTimeout = 2 * time.Second
+	shutdownCheckName   = "shutdown"
+)
+
+var errShuttingDown = errors.New("server is shutting down")
+
+// health UseCase
+type healthUC struct {
+	cfg          *config.Config
+	logger       logger.Logger
+	mu           sync.RWMutex
+	checks       map[string]health.Check
+	shuttingDown atomic.Bool
+}
+
+// NewHealthUseCase Health UseCase constructor
+func NewHealthUseCase(cfg *config.Config, logger logger.Logger) health.UseCase {
+	return &healthUC{cfg: cfg, logger: logger, checks: make(map[string]health.Check)}
+}
+
+// Register dependency check, checks with same name are replaced
+func (u *healthUC) Register(name string, check health.Check) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.checks[name] = check
+}
+
+// SetShuttingDown mark service as unready until process exits
+func (u *healthUC) SetShuttingDown() {
+	u.shuttingDown.Store(true)
+}
+
+// Readiness run all registered checks concurrently and collect report
+func (u *healthUC) Readiness(ctx context.Context) *models.HealthReport {
+	u.mu.RLock()
+	names := make([]string, 0, len(u.checks))
+	for name := range u.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checks := make([]health.Check, 0, len(names))
+	for _, name := range names {
+		checks = append(checks, u.checks[name])
+	}
+	u.mu.RUnlock()
+
+	report := &models.HealthReport{
+		Status: models.HealthStatusOK,
+		Checks: make(map[string]*models.HealthCheck, len(names)+1),
+	}
+
+	results := make([]*models.HealthCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check health.Check) {
+			defer wg.Done()
+			results[i] = u.runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		report.Checks[name] = results[i]
+		if results[i].Status != models.HealthStatusOK {
+			report.Status = models.HealthStatusFail
+			u.logger.FromContext(ctx).Warnf("Readiness check failed, Name: %s, Error: %s", name, results[i].Error)
+		}
+	}
+
+	if u.shuttingDown.Load() {
+		report.Status = models.HealthStatusFail
+		report.Checks[shutdownCheckName] = &models.HealthCheck{Status: models.HealthStatusFail, Error: errShuttingDown.Error()}
+	}
+
+	return report
+}
+
+func (u *healthUC) runCheck(ctx context.Context, check health.Check) *models.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, u.checkTimeout())
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := &models.HealthCheck{
+		Status:    models.HealthStatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = models.HealthStatusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+func (u *healthUC) checkTimeout() time.Duration {
+	if u.cfg == nil || u.cfg.Server.HealthCheckTimeout <= 0 {
+		return defaultCheckTimeout
+	}
+	return u.cfg.Server.HealthCheckTimeout * time.Second
+}
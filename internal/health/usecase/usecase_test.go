@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthUC_Readiness(t *testing.T) {
+	t.Parallel()
+
+	// all checks pass
+	t.Run("Ready", func(t *testing.T) {
+		healthUC := NewHealthUseCase(nil, logger.NewApiLogger(nil))
+		healthUC.Register("postgres", func(ctx context.Context) error { return nil })
+		healthUC.Register("cache", func(ctx context.Context) error { return nil })
+
+		report := healthUC.Readiness(context.Background())
+
+		require.Equal(t, models.HealthStatusOK, report.Status)
+		require.Len(t, report.Checks, 2)
+		require.Equal(t, models.HealthStatusOK, report.Checks["postgres"].Status)
+	})
+
+	// one check fails
+	t.Run("Check Error", func(t *testing.T) {
+		healthUC := NewHealthUseCase(nil, logger.NewApiLogger(nil))
+		healthUC.Register("postgres", func(ctx context.Context) error { return nil })
+		healthUC.Register("storage", func(ctx context.Context) error { return errors.New("connection refused") })
+
+		report := healthUC.Readiness(context.Background())
+
+		require.Equal(t, models.HealthStatusFail, report.Status)
+		require.Equal(t, models.HealthStatusOK, report.Checks["postgres"].Status)
+		require.Equal(t, models.HealthStatusFail, report.Checks["storage"].Status)
+		require.Equal(t, "connection refused", report.Checks["storage"].Error)
+	})
+
+	// unready during graceful shutdown
+	t.Run("Shutting Down", func(t *testing.T) {
+		healthUC := NewHealthUseCase(nil, logger.NewApiLogger(nil))
+		healthUC.Register("postgres", func(ctx context.Context) error { return nil })
+		healthUC.SetShuttingDown()
+
+		report := healthUC.Readiness(context.Background())
+
+		require.Equal(t, models.HealthStatusFail, report.Status)
+		require.Equal(t, models.HealthStatusFail, report.Checks[shutdownCheckName].Status)
+	})
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	t.Parallel()
+
+	// in memory migrations dir
+	migrations := fstest.MapFS{}
+	for _, name := range []string{"01_create_tables.up.sql", "01_create_tables.down.sql", "02_add_index.up.sql", "README.md"} {
+		migrations[name] = &fstest.MapFile{}
+	}
+
+	version, err := LatestMigrationVersion(migrations)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(2), version)
+}
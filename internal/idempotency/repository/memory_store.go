@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/idempotency"
+	"github.com/Dostonlv/task-del/internal/models"
+)
+
+// In memory idempotency store, keys are not shared between API instances
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]models.IdempotencyRecord
+}
+
+// NewMemoryStore in memory idempotency store constructor, for single instance deployments and tests
+func NewMemoryStore() idempotency.Store {
+	return &memoryStore{records: make(map[string]models.IdempotencyRecord)}
+}
+
+// Lock add record in flight unless unexpired record of key exists
+func (s *memoryStore) Lock(ctx context.Context, key string, fingerprint string, lockTTL time.Duration) (*models.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if record, ok := s.records[key]; ok && record.ExpiresAt.After(now) {
+		return &record, nil
+	}
+
+	s.records[key] = models.IdempotencyRecord{Key: key, Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: now.Add(lockTTL)}
+	return nil, nil
+}
+
+// Refresh extend expiry of key in flight
+func (s *memoryStore) Refresh(ctx context.Context, key string, fingerprint string, lockTTL time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || record.Completed || record.Fingerprint != fingerprint {
+		return nil
+	}
+	record.ExpiresAt = time.Now().Add(lockTTL)
+	s.records[key] = record
+	return nil
+}
+
+// Complete store response of key
+func (s *memoryStore) Complete(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.records[record.Key]
+	if !ok || stored.Fingerprint != record.Fingerprint {
+		return nil
+	}
+	stored.Completed, stored.Status, stored.Header, stored.Body = true, record.Status, record.Header, record.Body
+	stored.ExpiresAt = time.Now().Add(ttl)
+	s.records[record.Key] = stored
+	return nil
+}
+
+// Release delete key in flight, completed record is kept
+func (s *memoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok && !record.Completed {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+// DeleteExpired delete records expired before given time
+func (s *memoryStore) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for key, record := range s.records {
+		if record.ExpiresAt.Before(before) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/idempotency"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// lockAttempts record of key may be deleted between failed insert and select, lock is retried then
+const lockAttempts = 3
+
+// Postgres idempotency store
+type pgStore struct {
+	db *sqlx.DB
+}
+
+// NewPostgresStore Postgres idempotency store constructor
+func NewPostgresStore(db *sqlx.DB) idempotency.Store {
+	return &pgStore{db: db}
+}
+
+// Lock insert record in flight, conflicting expired record is replaced in the same statement
+func (s *pgStore) Lock(ctx context.Context, key string, fingerprint string, lockTTL time.Duration) (*models.IdempotencyRecord, error) {
+	lockKey := `INSERT INTO idempotency_keys (key, fingerprint, expires_at) VALUES ($1, $2, CURRENT_TIMESTAMP + $3 * INTERVAL '1 second')
+	ON CONFLICT (key) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, completed = FALSE, status = 0, header = '{}', body = NULL,
+		created_at = CURRENT_TIMESTAMP, expires_at = EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+	RETURNING key`
+	getRecord := `SELECT key, fingerprint, completed, status, header, body, created_at, expires_at FROM idempotency_keys WHERE key = $1`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "pgStore.Lock", lockKey)
+	defer span.End()
+
+	for attempt := 0; attempt < lockAttempts; attempt++ {
+		var locked string
+		err := s.db.QueryRowxContext(ctx, lockKey, key, fingerprint, lockTTL.Seconds()).Scan(&locked)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, tracing.RecordError(span, errors.Wrap(err, "pgStore.Lock.QueryRowxContext"))
+		}
+
+		record := &models.IdempotencyRecord{}
+		err = s.db.QueryRowxContext(ctx, getRecord, key).StructScan(record)
+		if err == nil {
+			return record, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, tracing.RecordError(span, errors.Wrap(err, "pgStore.Lock.StructScan"))
+		}
+	}
+
+	return nil, tracing.RecordError(span, errors.Errorf("pgStore.Lock: key %q changed concurrently", key))
+}
+
+// Refresh extend expiry of key in flight
+func (s *pgStore) Refresh(ctx context.Context, key string, fingerprint string, lockTTL time.Duration) error {
+	refreshKey := `UPDATE idempotency_keys SET expires_at = CURRENT_TIMESTAMP + $1 * INTERVAL '1 second'
+	WHERE key = $2 AND fingerprint = $3 AND completed = FALSE`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "pgStore.Refresh", refreshKey)
+	defer span.End()
+
+	if _, err := s.db.ExecContext(ctx, refreshKey, lockTTL.Seconds(), key, fingerprint); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "pgStore.Refresh.ExecContext"))
+	}
+
+	return nil
+}
+
+// Complete store response of key
+func (s *pgStore) Complete(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) error {
+	completeKey := `UPDATE idempotency_keys SET completed = TRUE, status = $1, header = $2, body = $3,
+		expires_at = CURRENT_TIMESTAMP + $4 * INTERVAL '1 second'
+	WHERE key = $5 AND fingerprint = $6`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "pgStore.Complete", completeKey)
+	defer span.End()
+
+	if _, err := s.db.ExecContext(ctx, completeKey, record.Status, record.Header, record.Body, ttl.Seconds(), record.Key, record.Fingerprint); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "pgStore.Complete.ExecContext"))
+	}
+
+	return nil
+}
+
+// Release delete key in flight, completed record is kept
+func (s *pgStore) Release(ctx context.Context, key string) error {
+	releaseKey := `DELETE FROM idempotency_keys WHERE key = $1 AND completed = FALSE`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "pgStore.Release", releaseKey)
+	defer span.End()
+
+	if _, err := s.db.ExecContext(ctx, releaseKey, key); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "pgStore.Release.ExecContext"))
+	}
+
+	return nil
+}
+
+// DeleteExpired delete records expired before given time
+func (s *pgStore) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	deleteExpired := `DELETE FROM idempotency_keys WHERE expires_at < $1`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "pgStore.DeleteExpired", deleteExpired)
+	defer span.End()
+
+	result, err := s.db.ExecContext(ctx, deleteExpired, before)
+	if err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "pgStore.DeleteExpired.ExecContext"))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "pgStore.DeleteExpired.RowsAffected"))
+	}
+
+	return rowsAffected, nil
+}
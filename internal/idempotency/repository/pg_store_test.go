@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPgStore_Lock(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	store := NewPostgresStore(sqlxDB)
+
+	lockKey := `INSERT INTO idempotency_keys (key, fingerprint, expires_at) VALUES ($1, $2, CURRENT_TIMESTAMP + $3 * INTERVAL '1 second')
+	ON CONFLICT (key) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, completed = FALSE, status = 0, header = '{}', body = NULL,
+		created_at = CURRENT_TIMESTAMP, expires_at = EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+	RETURNING key`
+	getRecord := `SELECT key, fingerprint, completed, status, header, body, created_at, expires_at FROM idempotency_keys WHERE key = $1`
+	columns := []string{"key", "fingerprint", "completed", "status", "header", "body", "created_at", "expires_at"}
+
+	// new or expired key is locked
+	t.Run("Locked", func(t *testing.T) {
+		mock.ExpectQuery(lockKey).WithArgs("POST /v1/news key", "fingerprint", float64(30)).
+			WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("POST /v1/news key"))
+
+		record, err := store.Lock(context.Background(), "POST /v1/news key", "fingerprint", 30*time.Second)
+		require.NoError(t, err)
+		require.Nil(t, record)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// unexpired key is returned and not locked
+	t.Run("Existing", func(t *testing.T) {
+		mock.ExpectQuery(lockKey).WithArgs("POST /v1/news key", "fingerprint", float64(30)).
+			WillReturnRows(sqlmock.NewRows([]string{"key"}))
+		mock.ExpectQuery(getRecord).WithArgs("POST /v1/news key").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("POST /v1/news key", "fingerprint", true, 201,
+				[]byte(`{"Location":"/v1/news/1"}`), []byte(`{}`), time.Now(), time.Now().Add(time.Hour)))
+
+		record, err := store.Lock(context.Background(), "POST /v1/news key", "fingerprint", 30*time.Second)
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		require.True(t, record.Completed)
+		require.Equal(t, 201, record.Status)
+		require.Equal(t, models.IdempotencyHeader{"Location": "/v1/news/1"}, record.Header)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestPgStore_Refresh(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	store := NewPostgresStore(sqlxDB)
+
+	// only lock of the same request still in flight is extended
+	refreshKey := `UPDATE idempotency_keys SET expires_at = CURRENT_TIMESTAMP + $1 * INTERVAL '1 second'
+	WHERE key = $2 AND fingerprint = $3 AND completed = FALSE`
+	mock.ExpectExec(refreshKey).WithArgs(float64(30), "POST /v1/news/import key", "fingerprint").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, store.Refresh(context.Background(), "POST /v1/news/import key", "fingerprint", 30*time.Second))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMemoryStore_Refresh(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	record, err := store.Lock(ctx, "key", "fingerprint", 50*time.Millisecond)
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	// refreshed lock outlives its first ttl, refresh of other request is ignored
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, store.Refresh(ctx, "key", "fingerprint", time.Hour))
+	require.NoError(t, store.Refresh(ctx, "key", "other", time.Millisecond))
+	time.Sleep(40 * time.Millisecond)
+
+	record, err = store.Lock(ctx, "key", "fingerprint", 50*time.Millisecond)
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	require.False(t, record.Completed)
+
+	// completed record keeps its ttl
+	require.NoError(t, store.Complete(ctx, &models.IdempotencyRecord{Key: "key", Fingerprint: "fingerprint", Status: 201}, time.Millisecond))
+	require.NoError(t, store.Refresh(ctx, "key", "fingerprint", time.Hour))
+	time.Sleep(5 * time.Millisecond)
+
+	record, err = store.Lock(ctx, "key", "fingerprint", 50*time.Millisecond)
+	require.NoError(t, err)
+	require.Nil(t, record)
+}
@@ -0,0 +1,23 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/models"
+)
+
+// Store Idempotency-Key records shared by API instances
+type Store interface {
+	// Lock create record of key in flight for lockTTL. When unexpired record of key exists it is returned
+	// and key is not locked, expired records are replaced.
+	Lock(ctx context.Context, key string, fingerprint string, lockTTL time.Duration) (*models.IdempotencyRecord, error)
+	// Refresh extend lock of key in flight by lockTTL, completed, released or taken over keys are left untouched
+	Refresh(ctx context.Context, key string, fingerprint string, lockTTL time.Duration) error
+	// Complete store response of locked key, record expires after ttl
+	Complete(ctx context.Context, record *models.IdempotencyRecord, ttl time.Duration) error
+	// Release delete key in flight, e.g. after failed request, so retries run again
+	Release(ctx context.Context, key string) error
+	// DeleteExpired delete records expired before given time
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
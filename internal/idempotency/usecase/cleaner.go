@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/idempotency"
+	"github.com/Dostonlv/task-del/pkg/logger"
+)
+
+const cleanupInterval = 10 * time.Minute
+
+// Cleaner delete expired Idempotency-Key records in background
+type Cleaner struct {
+	store  idempotency.Store
+	logger logger.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewCleaner idempotency cleaner constructor
+func NewCleaner(store idempotency.Store, logger logger.Logger) *Cleaner {
+	return &Cleaner{store: store, logger: logger}
+}
+
+// Start run cleanup loop in background
+func (c *Cleaner) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go c.run(runCtx)
+	return nil
+}
+
+// Stop wait for running cleanup
+func (c *Cleaner) Stop(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Cleaner) run(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := c.store.DeleteExpired(ctx, time.Now())
+		if err != nil {
+			if ctx.Err() == nil {
+				c.logger.Errorf("Cleaner.run.DeleteExpired: %s", err)
+			}
+			continue
+		}
+		if deleted > 0 {
+			c.logger.Debugf("Idempotency cleanup, Deleted: %d", deleted)
+		}
+	}
+}
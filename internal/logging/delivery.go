@@ -0,0 +1,9 @@
+package logging
+
+import "github.com/labstack/echo/v4"
+
+// Handlers Logging HTTP Handlers interface
+type Handlers interface {
+	GetLevel() echo.HandlerFunc
+	SetLevel() echo.HandlerFunc
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/logging"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// maxLevelDuration longest time boxed level change
+const maxLevelDuration = 24 * time.Hour
+
+// logging handlers
+type loggingHandlers struct {
+	cfg    *config.Config
+	logger logger.Logger
+}
+
+// NewLoggingHandlers Logging handlers constructor, levels of logger and all loggers derived from it are managed
+func NewLoggingHandlers(cfg *config.Config, logger logger.Logger) logging.Handlers {
+	return &loggingHandlers{cfg: cfg, logger: logger}
+}
+
+// GetLevel
+// @Summary Get log level
+// @Description current log level, package overrides and time of automatic revert if change is time boxed
+// @Tags Logging
+// @Produce json
+// @Success 200 {object} logger.LevelState
+// @Failure 401 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/log-level [get]
+func (h *loggingHandlers) GetLevel() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, h.logger.Levels().State())
+	}
+}
+
+// SetLevel
+// @Summary Set log level
+// @Description replace log level and package overrides of this instance, with duration previous levels are restored after it (max 24h)
+// @Tags Logging
+// @Accept json
+// @Produce json
+// @Param body body models.LogLevel true "body"
+// @Success 200 {object} logger.LevelState
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 401 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/log-level [put]
+func (h *loggingHandlers) SetLevel() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		logLevel := &models.LogLevel{}
+		if err := utils.ReadRequest(c, logLevel); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		var duration time.Duration
+		if logLevel.Duration != "" {
+			var err error
+			duration, err = time.ParseDuration(logLevel.Duration)
+			if err != nil || duration <= 0 || duration > maxLevelDuration {
+				return utils.ErrResponseWithLog(c, h.logger, appErrors.Newf(appErrors.CodeBadRequest, "invalid duration %q, must be positive and at most 24h", logLevel.Duration))
+			}
+		}
+
+		levels := h.logger.Levels()
+		if err := levels.Set(logger.LevelState{Level: logLevel.Level, Overrides: logLevel.Overrides}, duration); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, appErrors.Wrap(err, appErrors.CodeBadRequest, err.Error()))
+		}
+
+		state := levels.State()
+		h.logger.FromContext(c.Request().Context()).Warnf("Log level changed, Level: %s, Overrides: %v, RevertAt: %v, IPAddress: %s",
+			state.Level, state.Overrides, state.RevertAt, utils.GetIPAddress(c))
+		return c.JSON(http.StatusOK, state)
+	}
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingHandlers_LogLevel(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Server: config.ServerConfig{AdminToken: "admin-token"}}
+	appLogger := logger.NewApiLogger(cfg)
+	e := echo.New()
+	MapLoggingRoutes(e.Group("/admin/log-level"), NewLoggingHandlers(cfg, appLogger), middleware.NewMiddlewareManager(cfg, appLogger))
+
+	serve := func(method, body, token string) (*httptest.ResponseRecorder, logger.LevelState) {
+		req := httptest.NewRequest(method, "/admin/log-level", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		var state logger.LevelState
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &state))
+		}
+		return rec, state
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		rec, _ := serve(http.MethodGet, "", "")
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		rec, _ = serve(http.MethodPut, `{"level":"debug"}`, "wrong")
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		rec, _ := serve(http.MethodPut, `{"level":"verbose"}`, "admin-token")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		rec, _ = serve(http.MethodPut, `{"level":"debug","overrides":{"internal/webhooks":"loud"}}`, "admin-token")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		rec, _ = serve(http.MethodPut, `{"level":"debug","duration":"48h"}`, "admin-token")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		_, state := serve(http.MethodGet, "", "admin-token")
+		require.Equal(t, "info", state.Level)
+	})
+
+	t.Run("Time boxed change", func(t *testing.T) {
+		rec, state := serve(http.MethodPut, `{"level":"warn","overrides":{"internal/webhooks":"debug"}}`, "admin-token")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "warn", state.Level)
+		require.Nil(t, state.RevertAt)
+
+		_, state = serve(http.MethodPut, `{"level":"debug","duration":"100ms"}`, "admin-token")
+		require.Equal(t, "debug", state.Level)
+		require.Empty(t, state.Overrides)
+		require.NotNil(t, state.RevertAt)
+
+		// config reload during time boxed change takes effect on revert
+		require.NoError(t, appLogger.SetLevel("error"))
+		_, state = serve(http.MethodGet, "", "admin-token")
+		require.Equal(t, "debug", state.Level)
+
+		require.Eventually(t, func() bool {
+			_, state = serve(http.MethodGet, "", "admin-token")
+			return state.Level == "error"
+		}, 2*time.Second, 20*time.Millisecond)
+		require.Equal(t, map[string]string{"internal/webhooks": "debug"}, state.Overrides)
+		require.Nil(t, state.RevertAt)
+	})
+}
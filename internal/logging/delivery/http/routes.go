@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/Dostonlv/task-del/internal/logging"
+	"github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Map log level admin routes
+func MapLoggingRoutes(logLevelGroup *echo.Group, h logging.Handlers, mw *middleware.MiddlewareManager) {
+	logLevelGroup.Use(mw.AdminAuthMiddleware)
+	logLevelGroup.GET("", h.GetLevel())
+	logLevelGroup.PUT("", h.SetLevel())
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// AdminAuthMiddleware allow request only with configured admin bearer token, admin routes are closed when token is empty
+func (mw *MiddlewareManager) AdminAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !mw.isAdmin(c) {
+			mw.logger.FromContext(c.Request().Context()).Warnf("Admin auth failed, IPAddress: %s, Path: %s", utils.GetIPAddress(c), c.Request().URL.Path)
+			return utils.RespondError(c, appErrors.New(appErrors.CodeUnauthorized, httpErrors.Unauthorized.Error()))
+		}
+		return next(c)
+	}
+}
+
+// isAdmin request carries configured admin bearer token
+func (mw *MiddlewareManager) isAdmin(c echo.Context) bool {
+	token := bearerToken(c)
+	adminToken := mw.cfg.Server.AdminToken
+	return adminToken != "" && token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1
+}
+
+// bearerToken token of Authorization header, empty without bearer credentials
+func bearerToken(c echo.Context) string {
+	token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return token
+}
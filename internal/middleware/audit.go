@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"github.com/Dostonlv/task-del/internal/audit"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// AuditMiddleware store actor of request in its context for audit log of content changes. Requests with admin token
+// are made by admin:<token hash>, requests with valid JWT in bearer token or auth cookie by user:<subject>, others are anonymous.
+// IP comes from IPExtractor, so forwarded headers are trusted only from configured proxies.
+func (mw *MiddlewareManager) AuditMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		actor := audit.Actor{Name: audit.ActorAnonymous, IP: c.RealIP(), RequestID: utils.GetRequestID(c)}
+		if mw.isAdmin(c) {
+			actor.Name = audit.AdminActor(mw.cfg.Server.AdminToken)
+		} else if name, ok := mw.userActor(c); ok {
+			actor.Name = name
+		}
+		c.SetRequest(c.Request().WithContext(audit.WithActor(c.Request().Context(), actor)))
+		return next(c)
+	}
+}
+
+// userActor actor of JWT in bearer token or auth cookie
+func (mw *MiddlewareManager) userActor(c echo.Context) (string, bool) {
+	if name, ok := audit.UserActor(bearerToken(c), mw.cfg.Server.JwtSecretKey); ok {
+		return name, true
+	}
+	if mw.cfg.Server.CookieName == "" {
+		return "", false
+	}
+	cookie, err := c.Cookie(mw.cfg.Server.CookieName)
+	if err != nil {
+		return "", false
+	}
+	return audit.UserActor(cookie.Value, mw.cfg.Server.JwtSecretKey)
+}
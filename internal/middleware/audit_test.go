@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/audit"
+	auditRepository "github.com/Dostonlv/task-del/internal/audit/repository"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareManager_AuditMiddleware(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Server: config.ServerConfig{AdminToken: "admin-token", JwtSecretKey: "jwt-secret", CookieName: "jwt-token"}}
+	mw := NewMiddlewareManager(cfg, logger.NewApiLogger(nil))
+
+	userToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{Subject: "user-1"}).SignedString([]byte("jwt-secret"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		header string
+		cookie string
+		actor  string
+	}{
+		{name: "admin", header: "Bearer admin-token", actor: audit.AdminActor("admin-token")},
+		{name: "JWT", header: "Bearer " + userToken, actor: "user:user-1"},
+		{name: "cookie", cookie: userToken, actor: "user:user-1"},
+		{name: "invalid token", header: "Bearer invalid", actor: audit.ActorAnonymous},
+		// admin token is accepted as bearer token only
+		{name: "admin cookie", cookie: "admin-token", actor: audit.ActorAnonymous},
+		{name: "anonymous", actor: audit.ActorAnonymous},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var actor audit.Actor
+			e := echo.New()
+			e.IPExtractor = mw.IPExtractor()
+			e.Use(middleware.RequestID())
+			e.POST("/v1/news", func(c echo.Context) error {
+				actor = audit.ActorFromContext(c.Request().Context())
+				return c.NoContent(http.StatusCreated)
+			}, mw.AuditMiddleware)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/news", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			// forwarded address of client not behind trusted proxy is ignored
+			req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.1")
+			req.Header.Set(echo.HeaderXRealIP, "198.51.100.1")
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "jwt-token", Value: tt.cookie})
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusCreated, rec.Code)
+			require.Equal(t, tt.actor, actor.Name)
+			require.Equal(t, "192.0.2.1", actor.IP)
+			require.NotEmpty(t, actor.RequestID)
+		})
+	}
+
+	// oversized request ID of client is stored cut to column length and does not fail the change
+	t.Run("oversized request ID", func(t *testing.T) {
+		t.Parallel()
+
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.NoError(t, err)
+		defer db.Close()
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+
+		newsID := uuid.New()
+		requestID := strings.Repeat("r", 100)
+		mock.ExpectExec(`INSERT INTO audit_log (actor, ip, request_id, action, entity, entity_id, before, after) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`).
+			WithArgs(audit.ActorAnonymous, "192.0.2.1", requestID[:64], models.AuditActionDelete, "news", newsID, []byte(`{}`), nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		e := echo.New()
+		e.IPExtractor = mw.IPExtractor()
+		e.Use(middleware.RequestID())
+		e.DELETE("/v1/news", func(c echo.Context) error {
+			if err := auditRepository.AddEntry(c.Request().Context(), sqlxDB, models.AuditActionDelete, "news", newsID, struct{}{}, nil); err != nil {
+				return err
+			}
+			return c.NoContent(http.StatusOK)
+		}, mw.AuditMiddleware)
+
+		req := httptest.NewRequest(http.MethodDelete, "/v1/news", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set(echo.HeaderXForwardedFor, strings.Repeat("198.51.100.1, ", 10))
+		req.Header.Set(echo.HeaderXRequestID, requestID)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
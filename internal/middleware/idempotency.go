@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/idempotency"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// IdempotencyKeyHeader client generated key of request that may be retried
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader set on responses replayed from idempotency store
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	idempotencyPollInterval = 100 * time.Millisecond
+	// bodies above memoryBodyLimit are spooled to temporary file while fingerprinting
+	memoryBodyLimit = 1 << 20
+)
+
+// replayedHeaders response headers stored and replayed with response body
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, "Content-Language"}
+
+// SetIdempotencyStore enable IdempotencyMiddleware, without store requests pass through
+func (mw *MiddlewareManager) SetIdempotencyStore(store idempotency.Store) {
+	mw.idempotency = store
+}
+
+// IdempotencyMiddleware run request with Idempotency-Key once. Retries with the same key and request get stored response,
+// with a different request 409. Retries arriving while first request runs wait for its response up to LockTimeout.
+// Server errors are not stored, the request runs again on retry.
+func (mw *MiddlewareManager) IdempotencyMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(IdempotencyKeyHeader)
+		if key == "" || mw.idempotency == nil {
+			return next(c)
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return utils.RespondError(c, appErrors.Newf(appErrors.CodeBadRequest, "%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+		}
+
+		fingerprint, cleanup, err := fingerprintRequest(c.Request())
+		defer cleanup()
+		if err != nil {
+			return utils.ErrResponseWithLog(c, mw.logger, err)
+		}
+
+		ctx := c.Request().Context()
+		storeKey := c.Request().Method + " " + c.Request().URL.Path + " " + key
+		lockTimeout := mw.cfg.Idempotency.LockTimeout * time.Second
+		deadline := time.Now().Add(lockTimeout)
+		for {
+			record, err := mw.idempotency.Lock(ctx, storeKey, fingerprint, lockTimeout)
+			if err != nil {
+				return utils.ErrResponseWithLog(c, mw.logger, err)
+			}
+
+			switch {
+			case record == nil:
+				return mw.runIdempotent(c, next, storeKey, fingerprint, lockTimeout)
+			case record.Fingerprint != fingerprint:
+				return utils.RespondError(c, appErrors.Newf(appErrors.CodeIdempotencyKeyReused, "%s was already used with a different request", IdempotencyKeyHeader))
+			case record.Completed:
+				return replay(c, record)
+			}
+
+			// first request is in flight, its response is replayed once stored
+			if time.Now().After(deadline) {
+				return inProgress(c)
+			}
+			select {
+			case <-ctx.Done():
+				return inProgress(c)
+			case <-time.After(idempotencyPollInterval):
+			}
+		}
+	}
+}
+
+// runIdempotent run locked request and store its response, lock is released when request fails.
+// Lock is refreshed while request runs, so requests outliving LockTimeout, e.g. imports, are not run twice by retries.
+func (mw *MiddlewareManager) runIdempotent(c echo.Context, next echo.HandlerFunc, key string, fingerprint string, lockTimeout time.Duration) error {
+	// request context may be already cancelled by timeout
+	ctx := context.WithoutCancel(c.Request().Context())
+	stopRefresh := mw.refreshLock(c, ctx, key, fingerprint, lockTimeout)
+
+	res := c.Response()
+	recorder := &responseRecorder{ResponseWriter: res.Writer}
+	res.Writer = recorder
+	err := next(c)
+	res.Writer = recorder.ResponseWriter
+	stopRefresh()
+
+	if err != nil || !res.Committed || res.Status >= http.StatusInternalServerError {
+		if releaseErr := mw.idempotency.Release(ctx, key); releaseErr != nil {
+			utils.LogResponseError(c, mw.logger, releaseErr)
+		}
+		return err
+	}
+
+	header := models.IdempotencyHeader{}
+	for _, name := range replayedHeaders {
+		if value := res.Header().Get(name); value != "" {
+			header[name] = value
+		}
+	}
+	record := &models.IdempotencyRecord{Key: key, Fingerprint: fingerprint, Status: res.Status, Header: header, Body: recorder.body.Bytes()}
+	if err := mw.idempotency.Complete(ctx, record, mw.cfg.Idempotency.TTL*time.Hour); err != nil {
+		utils.LogResponseError(c, mw.logger, err)
+	}
+	return nil
+}
+
+// refreshLock extend lock every third of lockTimeout until returned stop is called, stop waits for refresh in progress
+func (mw *MiddlewareManager) refreshLock(c echo.Context, ctx context.Context, key string, fingerprint string, lockTimeout time.Duration) func() {
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(lockTimeout / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			if err := mw.idempotency.Refresh(ctx, key, fingerprint, lockTimeout); err != nil {
+				utils.LogResponseError(c, mw.logger, err)
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// replay write stored response
+func replay(c echo.Context, record *models.IdempotencyRecord) error {
+	for name, value := range record.Header {
+		c.Response().Header().Set(name, value)
+	}
+	c.Response().Header().Set(IdempotentReplayedHeader, "true")
+	c.Response().WriteHeader(record.Status)
+	_, err := c.Response().Write(record.Body)
+	return err
+}
+
+func inProgress(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderRetryAfter, "1")
+	return utils.RespondError(c, appErrors.Newf(appErrors.CodeIdempotencyKeyInProgress, "request with this %s is still in progress", IdempotencyKeyHeader))
+}
+
+// fingerprintRequest hash of method, path, query, content type and body, body is replaced with its copy.
+// Cleanup removes spooled body and must be called after request is done.
+func fingerprintRequest(req *http.Request) (string, func(), error) {
+	cleanup := func() {}
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n%s\n%s\n", req.Method, req.URL.Path, req.URL.RawQuery, req.Header.Get(echo.HeaderContentType))
+	if req.Body == nil || req.Body == http.NoBody {
+		return hex.EncodeToString(hash.Sum(nil)), cleanup, nil
+	}
+
+	head, err := io.ReadAll(io.LimitReader(req.Body, memoryBodyLimit+1))
+	if err != nil {
+		return "", cleanup, err
+	}
+	hash.Write(head)
+	if len(head) <= memoryBodyLimit {
+		req.Body = io.NopCloser(bytes.NewReader(head))
+		return hex.EncodeToString(hash.Sum(nil)), cleanup, nil
+	}
+
+	// bulk body is spooled to temporary file instead of memory
+	file, err := os.CreateTemp("", "idempotency-*")
+	if err != nil {
+		return "", cleanup, errors.Wrap(err, "fingerprintRequest.CreateTemp")
+	}
+	cleanup = func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}
+	if _, err := file.Write(head); err != nil {
+		return "", cleanup, errors.Wrap(err, "fingerprintRequest.Write")
+	}
+	if _, err := io.Copy(io.MultiWriter(file, hash), req.Body); err != nil {
+		return "", cleanup, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", cleanup, errors.Wrap(err, "fingerprintRequest.Seek")
+	}
+	req.Body = io.NopCloser(file)
+	return hex.EncodeToString(hash.Sum(nil)), cleanup, nil
+}
+
+// responseRecorder copy of response body written by handler
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap underlying writer for http.ResponseController
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
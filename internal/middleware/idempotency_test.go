@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/idempotency/repository"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareManager_IdempotencyMiddleware(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Idempotency: config.IdempotencyConfig{Enabled: true, TTL: 1, LockTimeout: 5}}
+	newServerWithConfig := func(cfg *config.Config, handler echo.HandlerFunc) *echo.Echo {
+		mw := NewMiddlewareManager(cfg, logger.NewApiLogger(nil))
+		mw.SetIdempotencyStore(repository.NewMemoryStore())
+		e := echo.New()
+		e.POST("/v1/news", handler, mw.IdempotencyMiddleware)
+		return e
+	}
+	newServer := func(handler echo.HandlerFunc) *echo.Echo {
+		return newServerWithConfig(cfg, handler)
+	}
+	serve := func(e *echo.Echo, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/news", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Replay", func(t *testing.T) {
+		var calls atomic.Int32
+		e := newServer(func(c echo.Context) error {
+			n := calls.Add(1)
+			c.Response().Header().Set(echo.HeaderLocation, "/v1/news/1")
+			return c.JSON(http.StatusCreated, map[string]int32{"call": n})
+		})
+
+		first := serve(e, "key-1", `{"title":"title"}`)
+		require.Equal(t, http.StatusCreated, first.Code)
+
+		retry := serve(e, "key-1", `{"title":"title"}`)
+		require.Equal(t, http.StatusCreated, retry.Code)
+		require.Equal(t, first.Body.String(), retry.Body.String())
+		require.Equal(t, "/v1/news/1", retry.Header().Get(echo.HeaderLocation))
+		require.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+		require.EqualValues(t, 1, calls.Load())
+
+		// requests without key are not deduplicated
+		require.Equal(t, http.StatusCreated, serve(e, "", `{"title":"title"}`).Code)
+		require.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("DifferentPayload", func(t *testing.T) {
+		e := newServer(func(c echo.Context) error {
+			return c.NoContent(http.StatusCreated)
+		})
+
+		require.Equal(t, http.StatusCreated, serve(e, "key-2", `{"title":"first"}`).Code)
+		rec := serve(e, "key-2", `{"title":"second"}`)
+		require.Equal(t, http.StatusConflict, rec.Code)
+		require.Contains(t, rec.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+	})
+
+	t.Run("ServerErrorIsNotStored", func(t *testing.T) {
+		var calls atomic.Int32
+		e := newServer(func(c echo.Context) error {
+			if calls.Add(1) == 1 {
+				return c.NoContent(http.StatusServiceUnavailable)
+			}
+			return c.NoContent(http.StatusCreated)
+		})
+
+		require.Equal(t, http.StatusServiceUnavailable, serve(e, "key-3", `{}`).Code)
+		require.Equal(t, http.StatusCreated, serve(e, "key-3", `{}`).Code)
+		require.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("ConcurrentRetry", func(t *testing.T) {
+		var calls atomic.Int32
+		started, release := make(chan struct{}), make(chan struct{})
+		e := newServer(func(c echo.Context) error {
+			calls.Add(1)
+			close(started)
+			<-release
+			return c.String(http.StatusCreated, "created")
+		})
+
+		var wg sync.WaitGroup
+		responses := make([]*httptest.ResponseRecorder, 2)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[0] = serve(e, "key-4", `{}`)
+		}()
+		<-started
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[1] = serve(e, "key-4", `{}`)
+		}()
+		// retry polls while first request is in flight
+		time.Sleep(2 * idempotencyPollInterval)
+		close(release)
+		wg.Wait()
+
+		require.EqualValues(t, 1, calls.Load())
+		for _, rec := range responses {
+			require.Equal(t, http.StatusCreated, rec.Code)
+			require.Equal(t, "created", rec.Body.String())
+		}
+	})
+	// lock of request running longer than LockTimeout is refreshed, retry waits instead of running it again
+	t.Run("LongRequest", func(t *testing.T) {
+		var calls atomic.Int32
+		started, release := make(chan struct{}), make(chan struct{})
+		shortLock := &config.Config{Idempotency: config.IdempotencyConfig{Enabled: true, TTL: 1, LockTimeout: 1}}
+		e := newServerWithConfig(shortLock, func(c echo.Context) error {
+			if calls.Add(1) == 1 {
+				close(started)
+				<-release
+			}
+			return c.String(http.StatusCreated, "imported")
+		})
+
+		done := make(chan *httptest.ResponseRecorder)
+		go func() {
+			done <- serve(e, "key-5", `{}`)
+		}()
+		<-started
+		time.Sleep(1500 * time.Millisecond)
+
+		retry := serve(e, "key-5", `{}`)
+		require.Equal(t, http.StatusConflict, retry.Code)
+		require.Contains(t, retry.Body.String(), "IDEMPOTENCY_KEY_IN_PROGRESS")
+
+		close(release)
+		require.Equal(t, http.StatusCreated, (<-done).Code)
+		require.EqualValues(t, 1, calls.Load())
+	})
+}
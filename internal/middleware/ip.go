@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor client IP for rate limit and audit log. X-Forwarded-For is used only when the connection comes from
+// server.TrustedProxies, otherwise it could be set by the client and the connection address is used.
+func (mw *MiddlewareManager) IPExtractor() echo.IPExtractor {
+	if len(mw.cfg.Server.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range mw.cfg.Server.TrustedProxies {
+		if _, ipNet, err := net.ParseCIDR(cidr); err == nil {
+			options = append(options, echo.TrustIPRange(ipNet))
+		}
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
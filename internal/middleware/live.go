@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// AllowOrigin CORS origin check against reloadable cors.AllowOrigins, * allows any origin
+func (mw *MiddlewareManager) AllowOrigin(origin string) (bool, error) {
+	origins := mw.origins.Load()
+	if origins == nil {
+		return false, nil
+	}
+	for _, allowed := range *origins {
+		if allowed == "*" || allowed == origin {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RateLimitMiddleware limit requests per client IP, passes all requests while rate limit is disabled
+func (mw *MiddlewareManager) RateLimitMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		limiter := mw.limiter.Load()
+		if limiter == nil {
+			return next(c)
+		}
+
+		allowed, err := limiter.Allow(c.RealIP())
+		if err != nil || !allowed {
+			mw.logger.FromContext(c.Request().Context()).Warnf("Rate limit exceeded, IPAddress: %s, Path: %s", c.RealIP(), c.Request().URL.Path)
+			c.Response().Header().Set(echo.HeaderRetryAfter, "1")
+			return utils.RespondError(c, appErrors.New(appErrors.CodeTooManyRequests, httpErrors.ErrTooManyRequests))
+		}
+
+		return next(c)
+	}
+}
+
+// CacheControlMiddleware let clients and proxies cache successful reads for cache.ContentTTL seconds.
+// Responses that set Cache-Control themselves, e.g. streams, are left as is.
+func (mw *MiddlewareManager) CacheControlMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ttl := mw.contentTTL.Load()
+		method := c.Request().Method
+		if ttl <= 0 || (method != http.MethodGet && method != http.MethodHead) {
+			return next(c)
+		}
+
+		res := c.Response()
+		res.Before(func() {
+			if res.Status < http.StatusMultipleChoices && res.Header().Get(echo.HeaderCacheControl) == "" {
+				res.Header().Set(echo.HeaderCacheControl, "public, max-age="+strconv.FormatInt(ttl, 10))
+			}
+		})
+		return next(c)
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareManager_RateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(trustedProxies []string) *echo.Echo {
+		cfg := &config.Config{
+			Server:    config.ServerConfig{TrustedProxies: trustedProxies},
+			RateLimit: config.RateLimitConfig{Enabled: true, Rate: 0.001, Burst: 2, ExpiresIn: 60},
+		}
+		mw := NewMiddlewareManager(cfg, logger.NewApiLogger(nil))
+		e := echo.New()
+		e.IPExtractor = mw.IPExtractor()
+		e.GET("/v1/news", func(c echo.Context) error {
+			return c.String(http.StatusOK, c.RealIP())
+		}, mw.RateLimitMiddleware)
+		return e
+	}
+	serve := func(e *echo.Echo, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/news", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		req.Header.Set(echo.HeaderXRealIP, forwardedFor)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// forwarded headers of direct clients are ignored, changing them does not reset the bucket
+	t.Run("SpoofedForwardedFor", func(t *testing.T) {
+		e := newServer(nil)
+
+		first := serve(e, "192.0.2.1:1234", "198.51.100.1")
+		require.Equal(t, http.StatusOK, first.Code)
+		require.Equal(t, "192.0.2.1", first.Body.String())
+		require.Equal(t, http.StatusOK, serve(e, "192.0.2.1:1234", "198.51.100.2").Code)
+		require.Equal(t, http.StatusTooManyRequests, serve(e, "192.0.2.1:1234", "198.51.100.3").Code)
+
+		// other clients have own buckets
+		require.Equal(t, http.StatusOK, serve(e, "192.0.2.2:1234", "198.51.100.1").Code)
+	})
+
+	// clients behind trusted proxy are limited by forwarded address
+	t.Run("TrustedProxy", func(t *testing.T) {
+		e := newServer([]string{"10.0.0.0/8"})
+
+		first := serve(e, "10.0.0.5:1234", "198.51.100.1")
+		require.Equal(t, http.StatusOK, first.Code)
+		require.Equal(t, "198.51.100.1", first.Body.String())
+		require.Equal(t, http.StatusOK, serve(e, "10.0.0.5:1234", "198.51.100.1").Code)
+		require.Equal(t, http.StatusTooManyRequests, serve(e, "10.0.0.5:1234", "198.51.100.1").Code)
+		require.Equal(t, http.StatusOK, serve(e, "10.0.0.5:1234", "198.51.100.2").Code)
+
+		// untrusted private address is not a proxy
+		require.Equal(t, "192.168.0.7", serve(e, "192.168.0.7:1234", "198.51.100.9").Body.String())
+	})
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/Dostonlv/task-del/pkg/locale"
+	"github.com/labstack/echo/v4"
+)
+
+// LocaleMiddleware pick content locale from lang param or Accept-Language header and store its fallback chain in request context.
+// Requests asking for no locale get source content as before.
+func (mw *MiddlewareManager) LocaleMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+
+		lang := c.QueryParam("lang")
+		acceptLanguage := c.Request().Header.Get("Accept-Language")
+		if lang == "" && acceptLanguage == "" {
+			return next(c)
+		}
+
+		chain := mw.locales.Chain(mw.locales.Negotiate(lang, acceptLanguage))
+		c.SetRequest(c.Request().WithContext(locale.WithContext(c.Request().Context(), chain)))
+		return next(c)
+	}
+}
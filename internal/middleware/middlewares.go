@@ -1,18 +1,63 @@
 package middleware
 
 import (
+	"sync/atomic"
+	"time"
+
 	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/idempotency"
+	"github.com/Dostonlv/task-del/pkg/locale"
 	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
 )
 
 // Middleware manager
 type MiddlewareManager struct {
 	cfg     *config.Config
-	origins []string
+	locales *locale.Locales
 	logger  logger.Logger
+
+	idempotency idempotency.Store
+
+	// settings reloaded live, see ApplyConfig
+	origins    atomic.Pointer[[]string]
+	limiter    atomic.Pointer[middleware.RateLimiterMemoryStore]
+	contentTTL atomic.Int64
+	rateLimit  config.RateLimitConfig
 }
 
 // Middleware manager constructor
-func NewMiddlewareManager(cfg *config.Config, origins []string, logger logger.Logger) *MiddlewareManager {
-	return &MiddlewareManager{cfg: cfg, origins: origins, logger: logger}
+func NewMiddlewareManager(cfg *config.Config, logger logger.Logger) *MiddlewareManager {
+	mw := &MiddlewareManager{cfg: cfg, locales: locale.NewLocales(cfg), logger: logger}
+	if cfg != nil {
+		mw.ApplyConfig(cfg)
+	}
+	return mw
+}
+
+// ApplyConfig apply reloadable settings: CORS origins, rate limit and cache TTL.
+// Rate limit counters start over when limit is changed. Not safe for concurrent use, config watcher applies reloads one by one.
+func (mw *MiddlewareManager) ApplyConfig(cfg *config.Config) {
+	origins := append([]string(nil), cfg.CORS.AllowOrigins...)
+	mw.origins.Store(&origins)
+
+	if cfg.RateLimit != mw.rateLimit || mw.limiter.Load() == nil {
+		mw.rateLimit = cfg.RateLimit
+		mw.applyRateLimit(cfg.RateLimit)
+	}
+
+	mw.contentTTL.Store(int64(cfg.Cache.ContentTTL))
+}
+
+func (mw *MiddlewareManager) applyRateLimit(cfg config.RateLimitConfig) {
+	if cfg.Enabled {
+		mw.limiter.Store(middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:      rate.Limit(cfg.Rate),
+			Burst:     cfg.Burst,
+			ExpiresIn: cfg.ExpiresIn * time.Second,
+		}))
+	} else {
+		mw.limiter.Store(nil)
+	}
 }
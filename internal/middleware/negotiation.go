@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// ContentNegotiationMiddleware reply 406 or 415 before handler runs when Accept or Content-Type has no registered codec
+func (mw *MiddlewareManager) ContentNegotiationMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if err := utils.CheckCodecs(c); err != nil {
+			mw.logger.FromContext(c.Request().Context()).Warnf("Content negotiation failed, Path: %s, Error: %s", c.Request().URL.Path, err)
+			return utils.RespondError(c, err)
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestLoggerMiddleware store request scoped child logger in request context and log every request
+func (mw *MiddlewareManager) RequestLoggerMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		req := c.Request()
+		requestID := utils.GetRequestID(c)
+
+		fields := []interface{}{
+			"request_id", requestID,
+			"method", req.Method,
+			"route", c.Path(),
+			"ip", utils.GetIPAddress(c),
+		}
+		if user := req.Context().Value(utils.UserCtxKey{}); user != nil {
+			fields = append(fields, "user", fmt.Sprint(user))
+		}
+		// span is started by TracingMiddleware running before this one
+		if spanCtx := trace.SpanContextFromContext(req.Context()); spanCtx.IsValid() {
+			fields = append(fields, "trace_id", spanCtx.TraceID().String())
+		}
+		reqLogger := mw.logger.With(fields...)
+
+		ctx := context.WithValue(req.Context(), utils.ReqIDCtxKey{}, requestID)
+		ctx = logger.ContextWithLogger(ctx, reqLogger)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := next(c)
+		if err != nil {
+			c.Error(err)
+		}
+
+		reqLogger.Infof(
+			"Request completed, Status: %d, Size: %d, Latency: %s",
+			c.Response().Status,
+			c.Response().Size,
+			time.Since(start),
+		)
+
+		return nil
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestMiddlewareManager_RequestLoggerMiddleware uses global tracer provider, so it does not run in parallel
+func TestMiddlewareManager_RequestLoggerMiddleware(t *testing.T) {
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	core, logs := observer.New(zap.DebugLevel)
+	mw := NewMiddlewareManager(nil, logger.NewCoreLogger(core))
+
+	e := echo.New()
+	e.Use(echoMiddleware.RequestID(), mw.TracingMiddleware, mw.RequestLoggerMiddleware)
+	e.GET("/v1/news/:id", func(c echo.Context) error {
+		// use cases and repositories log through logger of request context
+		logger.FromContext(c.Request().Context()).Infof("news loaded")
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/news/42", nil)
+	req.Header.Set(echo.HeaderXRequestID, "request-id")
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	require.Equal(t, "news loaded", entries[0].Message)
+	require.Contains(t, entries[1].Message, "Request completed, Status: 200")
+	for _, entry := range entries {
+		fields := entry.ContextMap()
+		require.Equal(t, "request-id", fields["request_id"], entry.Message)
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", fields["trace_id"], entry.Message)
+		require.Equal(t, "/v1/news/:id", fields["route"], entry.Message)
+		require.Equal(t, http.MethodGet, fields["method"], entry.Message)
+		require.Contains(t, fields, "ip")
+	}
+
+	// without request context logger entries go to given logger
+	require.Equal(t, mw.logger, mw.logger.FromContext(req.Context()))
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TestMiddlewareManager_TracingMiddleware uses global tracer provider, so it does not run in parallel
+func TestMiddlewareManager_TracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	mw := NewMiddlewareManager(nil, logger.NewApiLogger(nil))
+	e := echo.New()
+	e.Use(echoMiddleware.RequestID(), mw.TracingMiddleware)
+	e.GET("/v1/news/:id", func(c echo.Context) error {
+		if c.Param("id") == "broken" {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		return c.NoContent(http.StatusOK)
+	})
+
+	serve := func(path string, header http.Header) sdktrace.ReadOnlySpan {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		e.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := recorder.Ended()
+		require.NotEmpty(t, spans)
+		return spans[len(spans)-1]
+	}
+
+	t.Run("Attributes", func(t *testing.T) {
+		span := serve("/v1/news/42", http.Header{echo.HeaderXRequestID: {"request-id"}})
+
+		require.Equal(t, "GET /v1/news/:id", span.Name())
+		require.Equal(t, trace.SpanKindServer, span.SpanKind())
+		attrs := attribute.NewSet(span.Attributes()...)
+		for key, want := range map[attribute.Key]attribute.Value{
+			semconv.HTTPRequestMethodKey:      attribute.StringValue(http.MethodGet),
+			semconv.HTTPRouteKey:              attribute.StringValue("/v1/news/:id"),
+			semconv.URLPathKey:                attribute.StringValue("/v1/news/42"),
+			semconv.HTTPResponseStatusCodeKey: attribute.IntValue(http.StatusOK),
+			tracing.RequestIDKey:              attribute.StringValue("request-id"),
+		} {
+			value, ok := attrs.Value(key)
+			require.True(t, ok, key)
+			require.Equal(t, want, value, key)
+		}
+		require.Equal(t, codes.Unset, span.Status().Code)
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		span := serve("/v1/news/broken", nil)
+
+		attrs := attribute.NewSet(span.Attributes()...)
+		status, ok := attrs.Value(semconv.HTTPResponseStatusCodeKey)
+		require.True(t, ok)
+		require.EqualValues(t, http.StatusInternalServerError, status.AsInt64())
+		require.Equal(t, codes.Error, span.Status().Code)
+	})
+
+	t.Run("Parent from traceparent", func(t *testing.T) {
+		span := serve("/v1/news/42", http.Header{"Traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}})
+
+		require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		require.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	})
+}
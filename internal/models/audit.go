@@ -0,0 +1,147 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Audited actions of content
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// AuditEntry single content mutation, entries are never changed or removed
+type AuditEntry struct {
+	ID        int64         `json:"id" db:"id"`
+	Actor     string        `json:"actor" db:"actor"`
+	IP        string        `json:"ip" db:"ip"`
+	RequestID string        `json:"request_id" db:"request_id"`
+	Action    string        `json:"action" db:"action"`
+	Entity    string        `json:"entity" db:"entity"`
+	EntityID  uuid.UUID     `json:"entity_id" db:"entity_id"`
+	Before    AuditSnapshot `json:"before" db:"before" swaggertype:"object"`
+	After     AuditSnapshot `json:"after" db:"after" swaggertype:"object"`
+	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+}
+
+// AuditList audit entries page, newest first
+type AuditList struct {
+	TotalCount int           `json:"total_count"`
+	TotalPages int           `json:"total_pages"`
+	Page       int           `json:"page"`
+	Size       int           `json:"size"`
+	HasMore    bool          `json:"has_more"`
+	Entries    []*AuditEntry `json:"entries"`
+}
+
+// AuditSnapshot JSON document of entity, nil when entity did not exist before or after the change
+type AuditSnapshot json.RawMessage
+
+// NewAuditSnapshot encode entity into snapshot, nil entity gives nil snapshot
+func NewAuditSnapshot(entity interface{}) (AuditSnapshot, error) {
+	if entity == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewAuditSnapshot.Marshal")
+	}
+	return data, nil
+}
+
+// Value snapshot as JSONB, NULL when empty
+func (s AuditSnapshot) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return []byte(s), nil
+}
+
+// Scan snapshot from JSONB
+func (s *AuditSnapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+	case []byte:
+		*s = append(AuditSnapshot(nil), v...)
+	case string:
+		*s = AuditSnapshot(v)
+	default:
+		return errors.Errorf("AuditSnapshot.Scan: unsupported type %T", src)
+	}
+	return nil
+}
+
+// MarshalJSON snapshot document as is, null when empty
+func (s AuditSnapshot) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	return s, nil
+}
+
+// UnmarshalJSON keep snapshot document as is
+func (s *AuditSnapshot) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = nil
+		return nil
+	}
+	*s = append(AuditSnapshot(nil), data...)
+	return nil
+}
+
+// AuditFilter filters of audit entries, empty fields match everything
+type AuditFilter struct {
+	Actor     string
+	IP        string
+	RequestID string
+	Action    string
+	Entity    string
+	EntityID  *uuid.UUID
+	From      *time.Time
+	To        *time.Time
+}
+
+// ParseAuditFilter build filter from actor, ip, request_id, action, entity, entity_id, from and to query values,
+// from and to are RFC 3339 timestamps or dates
+func ParseAuditFilter(query url.Values) (*AuditFilter, error) {
+	filter := &AuditFilter{
+		Actor:     strings.TrimSpace(query.Get("actor")),
+		IP:        strings.TrimSpace(query.Get("ip")),
+		RequestID: strings.TrimSpace(query.Get("request_id")),
+		Action:    strings.ToLower(strings.TrimSpace(query.Get("action"))),
+		Entity:    strings.ToLower(strings.TrimSpace(query.Get("entity"))),
+	}
+
+	switch filter.Action {
+	case "", AuditActionCreate, AuditActionUpdate, AuditActionDelete:
+	default:
+		return nil, errors.Errorf("action: unknown action %q", filter.Action)
+	}
+
+	if entityID := strings.TrimSpace(query.Get("entity_id")); entityID != "" {
+		id, err := uuid.Parse(entityID)
+		if err != nil {
+			return nil, errors.Wrap(err, "entity_id")
+		}
+		filter.EntityID = &id
+	}
+
+	var err error
+	if filter.From, err = parseFilterTime(query.Get("from")); err != nil {
+		return nil, errors.Wrap(err, "from")
+	}
+	if filter.To, err = parseFilterTime(query.Get("to")); err != nil {
+		return nil, errors.Wrap(err, "to")
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry fields shared by all content types, content models embed it.
+// Locale and TranslationOutdated are set when localized content is requested
+type Entry struct {
+	ID                  uuid.UUID `json:"id" db:"id" validate:"omitempty,uuid"`
+	Title               string    `json:"title" db:"title" validate:"required,gte=3"`
+	Content             string    `json:"content" db:"content" validate:"required,gte=10"`
+	Tags                Tags      `json:"tags" db:"tags" validate:"omitempty,max=10,dive,gte=1,lte=32"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	Revision            int       `json:"revision" db:"revision"`
+	Locale              string    `json:"locale,omitempty" db:"-"`
+	TranslationOutdated bool      `json:"translation_outdated,omitempty" db:"-"`
+}
+
+// Base shared fields of content model
+func (e *Entry) Base() *Entry {
+	return e
+}
+
+// List page of content items, items are encoded under Key, e.g. "news" or "blogs"
+type List[T any] struct {
+	TotalCount int    `json:"total_count"`
+	TotalPages int    `json:"total_pages"`
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	HasMore    bool   `json:"has_more"`
+	Items      []*T   `json:"-"`
+	Key        string `json:"-"`
+}
+
+// listPage page fields of List
+type listPage struct {
+	TotalCount int  `json:"total_count"`
+	TotalPages int  `json:"total_pages"`
+	Page       int  `json:"page"`
+	Size       int  `json:"size"`
+	HasMore    bool `json:"has_more"`
+}
+
+// MarshalJSON page fields followed by items under Key
+func (l *List[T]) MarshalJSON() ([]byte, error) {
+	page, err := json.Marshal(listPage{TotalCount: l.TotalCount, TotalPages: l.TotalPages, Page: l.Page, Size: l.Size, HasMore: l.HasMore})
+	if err != nil {
+		return nil, err
+	}
+	items := l.Items
+	if items == nil {
+		items = make([]*T, 0)
+	}
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	key, err := json.Marshal(l.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	res := append(page[:len(page)-1], ',')
+	res = append(res, key...)
+	res = append(res, ':')
+	res = append(res, itemsJSON...)
+	return append(res, '}'), nil
+}
@@ -0,0 +1,31 @@
+package models
+
+// Envelope v2 response body, data is an item or a list of items
+type Envelope struct {
+	Data  interface{} `json:"data"`  // item or list of items
+	Meta  Meta        `json:"meta"`  // request metadata
+	Links Links       `json:"links"` // links to the item or pages of the list
+}
+
+// Meta v2 response metadata, Page is set for lists only
+type Meta struct {
+	RequestID string    `json:"request_id,omitempty" example:"5f0c9a8e-2b1d-4a3c-9e7f-1d2c3b4a5e6f"`
+	Page      *PageMeta `json:"page,omitempty"` // lists only
+}
+
+// PageMeta page of list
+type PageMeta struct {
+	TotalCount int `json:"total_count"`
+	TotalPages int `json:"total_pages"`
+	Page       int `json:"page"`
+	Size       int `json:"size"`
+}
+
+// Links v2 response links, pagination links are set for lists only
+type Links struct {
+	Self  string `json:"self" example:"/v2/news?page=2&size=10"`
+	First string `json:"first,omitempty" example:"/v2/news?page=1&size=10"`
+	Prev  string `json:"prev,omitempty" example:"/v2/news?page=1&size=10"` // omitted on first page
+	Next  string `json:"next,omitempty" example:"/v2/news?page=3&size=10"` // omitted on last page
+	Last  string `json:"last,omitempty" example:"/v2/news?page=5&size=10"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// IdempotencyRecord request made with Idempotency-Key, response is stored once request is completed
+type IdempotencyRecord struct {
+	Key         string            `db:"key"`
+	Fingerprint string            `db:"fingerprint"`
+	Completed   bool              `db:"completed"`
+	Status      int               `db:"status"`
+	Header      IdempotencyHeader `db:"header"`
+	Body        []byte            `db:"body"`
+	CreatedAt   time.Time         `db:"created_at"`
+	ExpiresAt   time.Time         `db:"expires_at"`
+}
+
+// IdempotencyHeader response headers replayed with stored response
+type IdempotencyHeader map[string]string
+
+// Value encode headers into JSON
+func (h IdempotencyHeader) Value() (driver.Value, error) {
+	if h == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(h)
+}
+
+// Scan decode headers from JSON
+func (h *IdempotencyHeader) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*h = IdempotencyHeader{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return errors.Errorf("IdempotencyHeader.Scan: unsupported type %T", src)
+	}
+	return json.Unmarshal(data, h)
+}
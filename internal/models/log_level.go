@@ -0,0 +1,9 @@
+package models
+
+// LogLevel runtime log level change, Duration is a Go duration like 15m after which previous levels are restored.
+// Overrides set levels of packages by path or its tail, e.g. internal/webhooks or content/usecase.
+type LogLevel struct {
+	Level     string            `json:"level" validate:"required,oneof=debug info warn error dpanic panic fatal"`
+	Overrides map[string]string `json:"overrides,omitempty" validate:"omitempty,dive,keys,required,endkeys,oneof=debug info warn error dpanic panic fatal"`
+	Duration  string            `json:"duration,omitempty" example:"15m"`
+}
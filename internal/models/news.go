@@ -1,30 +1,16 @@
 package models
 
-import (
-	"github.com/google/uuid"
-	"time"
-)
-
 // New model
 type New struct {
-	ID        uuid.UUID `json:"id" db:"id" validate:"omitempty,uuid"`
-	Title     string    `json:"title" db:"title" validate:"required,gte=3"`
-	Content   string    `json:"content" db:"content" validate:"required,gte=10"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	Entry
 }
 
 // NewsList All News response
-type NewsList struct {
-	TotalCount int    `json:"total_count"`
-	TotalPages int    `json:"total_pages"`
-	Page       int    `json:"page"`
-	Size       int    `json:"size"`
-	HasMore    bool   `json:"has_more"`
-	News       []*New `json:"news"`
-}
+type NewsList = List[New]
 
 // NewsSwagger Swagger model
 type NewsSwagger struct {
-	Title   string `json:"title" db:"title" validate:"required,gte=3"`
-	Content string `json:"content" db:"content" validate:"required,gte=10"`
+	Title   string   `json:"title" db:"title" validate:"required,gte=3"`
+	Content string   `json:"content" db:"content" validate:"required,gte=10"`
+	Tags    []string `json:"tags" db:"tags" validate:"omitempty,max=10,dive,gte=1,lte=32"`
 }
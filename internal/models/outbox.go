@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Aggregate types of outbox events
+const (
+	AggregateBlog = "blog"
+	AggregateNews = "news"
+)
+
+// OutboxEvent domain event stored in the same transaction as the change it describes
+type OutboxEvent struct {
+	ID            int64           `json:"-" db:"id"`
+	EventID       uuid.UUID       `json:"id" db:"event_id"`
+	AggregateType string          `json:"aggregate_type" db:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id" db:"aggregate_id"`
+	EventType     string          `json:"type" db:"event_type"`
+	Payload       json.RawMessage `json:"data" db:"payload" swaggertype:"object"`
+	Attempts      int             `json:"-" db:"attempts"`
+	LastError     *string         `json:"-" db:"last_error"`
+	CreatedAt     time.Time       `json:"occurred_at" db:"created_at"`
+	ProcessedAt   *time.Time      `json:"-" db:"processed_at"`
+	NextAttemptAt time.Time       `json:"-" db:"next_attempt_at"`
+	FailedAt      *time.Time      `json:"-" db:"failed_at"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"database/sql/driver"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Tags postgres TEXT[] column
+type Tags []string
+
+// Value encode tags into postgres array literal
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "{}", nil
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, tag := range t {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(tag))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+
+	return b.String(), nil
+}
+
+// Scan decode postgres array literal into tags
+func (t *Tags) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*t = Tags{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return errors.Errorf("models.Tags.Scan: unsupported type %T", src)
+	}
+
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return errors.Errorf("models.Tags.Scan: invalid array literal %q", s)
+	}
+	s = s[1 : len(s)-1]
+
+	tags := Tags{}
+	for len(s) > 0 {
+		var tag string
+		if s[0] == '"' {
+			var b strings.Builder
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				b.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return errors.New("models.Tags.Scan: unterminated quoted element")
+			}
+			tag = b.String()
+			s = s[i+1:]
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			tag = s[:end]
+			s = s[end:]
+			if tag == "NULL" {
+				tag = ""
+			}
+		}
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+		s = strings.TrimPrefix(s, ",")
+	}
+
+	*t = tags
+	return nil
+}
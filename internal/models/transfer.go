@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Import actions of single record
+const (
+	ImportActionCreated = "created"
+	ImportActionUpdated = "updated"
+)
+
+// MaxImportErrors number of line errors kept in import result, failures above it are only counted
+const MaxImportErrors = 1000
+
+// ExportFilter filters of blogs and news export
+type ExportFilter struct {
+	Title string
+	Tags  []string
+	From  *time.Time
+	To    *time.Time
+}
+
+// ParseExportFilter build filter from query values, tags are comma separated,
+// from and to are RFC 3339 timestamps or dates
+func ParseExportFilter(title, tags, from, to string) (*ExportFilter, error) {
+	filter := &ExportFilter{Title: strings.TrimSpace(title)}
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
+	var err error
+	if filter.From, err = parseFilterTime(from); err != nil {
+		return nil, errors.Wrap(err, "from")
+	}
+	if filter.To, err = parseFilterTime(to); err != nil {
+		return nil, errors.Wrap(err, "to")
+	}
+
+	return filter, nil
+}
+
+func parseFilterTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, value); err != nil {
+			return nil, err
+		}
+	}
+	return &t, nil
+}
+
+// ImportLineError rejected import record
+type ImportLineError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportResult import summary, in dry run mode counts are what the import would do
+type ImportResult struct {
+	DryRun  bool               `json:"dry_run"`
+	Created int                `json:"created"`
+	Updated int                `json:"updated"`
+	Failed  int                `json:"failed"`
+	Errors  []*ImportLineError `json:"errors"`
+}
+
+// AddError count failed record and keep its error while under MaxImportErrors
+func (r *ImportResult) AddError(line int, err error) {
+	r.Failed++
+	if len(r.Errors) < MaxImportErrors {
+		r.Errors = append(r.Errors, &ImportLineError{Line: line, Error: err.Error()})
+	}
+}
+
+// Add count imported record
+func (r *ImportResult) Add(action string) {
+	if action == ImportActionCreated {
+		r.Created++
+	} else {
+		r.Updated++
+	}
+}
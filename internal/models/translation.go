@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Translation statuses compared with source revision of blog or news
+const (
+	TranslationCurrent  = "current"
+	TranslationOutdated = "outdated"
+	TranslationMissing  = "missing"
+)
+
+// Translation title and content of blog or news in one locale, SourceRevision is revision of source it was translated from
+type Translation struct {
+	EntityID       uuid.UUID `json:"entity_id" db:"entity_id"`
+	Locale         string    `json:"locale" db:"locale"`
+	Title          string    `json:"title" db:"title" validate:"required,gte=3"`
+	Content        string    `json:"content" db:"content" validate:"required,gte=10"`
+	SourceRevision int       `json:"source_revision" db:"source_revision"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TranslationSwagger Translation Swagger model
+type TranslationSwagger struct {
+	Title   string `json:"title" validate:"required,gte=3"`
+	Content string `json:"content" validate:"required,gte=10"`
+}
+
+// TranslationStatus translation state of one supported locale
+type TranslationStatus struct {
+	Locale         string       `json:"locale"`
+	Status         string       `json:"status"`
+	SourceRevision int          `json:"source_revision"`
+	Translation    *Translation `json:"translation,omitempty"`
+}
+
+// TranslationsList translations state of blog or news in every supported locale except the source one
+type TranslationsList struct {
+	EntityID     uuid.UUID            `json:"entity_id"`
+	SourceLocale string               `json:"source_locale"`
+	Revision     int                  `json:"revision"`
+	Translations []*TranslationStatus `json:"translations"`
+}
+
+// NewTranslationStatus compare translation with source revision, nil translation is missing
+func NewTranslationStatus(locale string, revision int, translation *Translation) *TranslationStatus {
+	status := &TranslationStatus{Locale: locale, SourceRevision: revision, Translation: translation}
+	switch {
+	case translation == nil:
+		status.Status = TranslationMissing
+	case translation.SourceRevision < revision:
+		status.Status = TranslationOutdated
+	default:
+		status.Status = TranslationCurrent
+	}
+	return status
+}
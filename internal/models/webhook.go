@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Content lifecycle events delivered to webhook subscribers
+const (
+	EventBlogCreated = "blog.created"
+	EventBlogUpdated = "blog.updated"
+	EventBlogDeleted = "blog.deleted"
+	EventNewsCreated = "news.created"
+	EventNewsUpdated = "news.updated"
+	EventNewsDeleted = "news.deleted"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Webhook subscription model
+type Webhook struct {
+	ID           uuid.UUID  `json:"id" db:"id" validate:"omitempty,uuid"`
+	URL          string     `json:"url" db:"url" validate:"required,url,lte=2048"`
+	Secret       string     `json:"secret,omitempty" db:"secret" validate:"omitempty,gte=16,lte=128"`
+	Events       Tags       `json:"events" db:"events" validate:"required,min=1,dive,oneof=blog.created blog.updated blog.deleted news.created news.updated news.deleted"`
+	Active       bool       `json:"active" db:"active"`
+	FailureCount int        `json:"failure_count" db:"failure_count"`
+	DisabledAt   *time.Time `json:"disabled_at,omitempty" db:"disabled_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookSwagger Webhook Swagger model
+type WebhookSwagger struct {
+	URL    string   `json:"url" validate:"required,url,lte=2048"`
+	Secret string   `json:"secret,omitempty" validate:"omitempty,gte=16,lte=128"`
+	Events []string `json:"events" validate:"required,min=1"`
+	Active bool     `json:"active"`
+}
+
+// WebhooksList All Webhooks response
+type WebhooksList struct {
+	TotalCount int        `json:"total_count"`
+	TotalPages int        `json:"total_pages"`
+	Page       int        `json:"page"`
+	Size       int        `json:"size"`
+	HasMore    bool       `json:"has_more"`
+	Webhooks   []*Webhook `json:"webhooks"`
+}
+
+// WebhookDelivery single event delivery to webhook endpoint
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id" db:"webhook_id"`
+	EventID        uuid.UUID       `json:"event_id" db:"event_id"`
+	Event          string          `json:"event" db:"event"`
+	Payload        json.RawMessage `json:"payload" db:"payload" swaggertype:"object"`
+	Status         string          `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	ResponseStatus *int            `json:"response_status,omitempty" db:"response_status"`
+	Error          *string         `json:"error,omitempty" db:"error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	RedeliveryOf   *uuid.UUID      `json:"redelivery_of,omitempty" db:"redelivery_of"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// WebhookDeliveriesList All webhook deliveries response
+type WebhookDeliveriesList struct {
+	TotalCount int                `json:"total_count"`
+	TotalPages int                `json:"total_pages"`
+	Page       int                `json:"page"`
+	Size       int                `json:"size"`
+	HasMore    bool               `json:"has_more"`
+	Deliveries []*WebhookDelivery `json:"deliveries"`
+}
@@ -2,11 +2,7 @@ package news
 
 import "github.com/labstack/echo/v4"
 
-// Handlers News HTTP Handlers interface
-type Handlers interface {
-	Create() echo.HandlerFunc
-	Update() echo.HandlerFunc
-	Delete() echo.HandlerFunc
-	GetByID() echo.HandlerFunc
-	GetAll() echo.HandlerFunc
+// StreamHandlers live news stream HTTP Handlers interface
+type StreamHandlers interface {
+	Stream() echo.HandlerFunc
 }
@@ -0,0 +1,144 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/news"
+	"github.com/Dostonlv/task-del/pkg/grpcErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/sanitize"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	newsService "github.com/Dostonlv/task-del/proto/news"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// news gRPC service
+type newsMicroservice struct {
+	newsService.UnimplementedNewsServiceServer
+	cfg    *config.Config
+	newsUC news.UseCase
+	logger logger.Logger
+}
+
+// NewNewsMicroservice News gRPC service constructor
+func NewNewsMicroservice(cfg *config.Config, newsUC news.UseCase, logger logger.Logger) newsService.NewsServiceServer {
+	return &newsMicroservice{cfg: cfg, newsUC: newsUC, logger: logger}
+}
+
+// Create news
+func (s *newsMicroservice) Create(ctx context.Context, r *newsService.CreateNewsRequest) (*newsService.CreateNewsResponse, error) {
+	news := &models.New{
+		Entry: models.Entry{
+			Title:   sanitize.SanitizeString(r.GetTitle()),
+			Content: sanitize.SanitizeString(r.GetContent()),
+			Tags:    sanitize.SanitizeStrings(r.GetTags()),
+		},
+	}
+	if err := utils.ValidateStruct(ctx, news); err != nil {
+		return nil, s.errResponse(ctx, "Create", err)
+	}
+
+	createdNews, err := s.newsUC.Create(ctx, news)
+	if err != nil {
+		return nil, s.errResponse(ctx, "Create", err)
+	}
+
+	return &newsService.CreateNewsResponse{News: newsToProto(createdNews)}, nil
+}
+
+// Update news
+func (s *newsMicroservice) Update(ctx context.Context, r *newsService.UpdateNewsRequest) (*newsService.UpdateNewsResponse, error) {
+	newsID, err := utils.ParseID(r.GetId())
+	if err != nil {
+		return nil, s.errResponse(ctx, "Update", err)
+	}
+
+	news := &models.New{
+		Entry: models.Entry{
+			ID:      newsID,
+			Title:   sanitize.SanitizeString(r.GetTitle()),
+			Content: sanitize.SanitizeString(r.GetContent()),
+			Tags:    sanitize.SanitizeStrings(r.GetTags()),
+		},
+	}
+	if err = utils.ValidateStruct(ctx, news); err != nil {
+		return nil, s.errResponse(ctx, "Update", err)
+	}
+
+	updatedNews, err := s.newsUC.Update(ctx, news)
+	if err != nil {
+		return nil, s.errResponse(ctx, "Update", err)
+	}
+
+	return &newsService.UpdateNewsResponse{News: newsToProto(updatedNews)}, nil
+}
+
+// Delete news
+func (s *newsMicroservice) Delete(ctx context.Context, r *newsService.DeleteNewsRequest) (*newsService.DeleteNewsResponse, error) {
+	newsID, err := utils.ParseID(r.GetId())
+	if err != nil {
+		return nil, s.errResponse(ctx, "Delete", err)
+	}
+
+	if err = s.newsUC.Delete(ctx, newsID); err != nil {
+		return nil, s.errResponse(ctx, "Delete", err)
+	}
+
+	return &newsService.DeleteNewsResponse{}, nil
+}
+
+// GetByID news
+func (s *newsMicroservice) GetByID(ctx context.Context, r *newsService.GetNewsByIDRequest) (*newsService.GetNewsByIDResponse, error) {
+	newsID, err := utils.ParseID(r.GetId())
+	if err != nil {
+		return nil, s.errResponse(ctx, "GetByID", err)
+	}
+
+	news, err := s.newsUC.GetByID(ctx, newsID)
+	if err != nil {
+		return nil, s.errResponse(ctx, "GetByID", err)
+	}
+
+	return &newsService.GetNewsByIDResponse{News: newsToProto(news)}, nil
+}
+
+// GetAll news
+func (s *newsMicroservice) GetAll(ctx context.Context, r *newsService.GetNewsRequest) (*newsService.GetNewsResponse, error) {
+	pq := utils.NewPaginationQuery(int(r.GetPage()), int(r.GetSize()), r.GetOrderBy())
+
+	newsList, err := s.newsUC.GetAll(ctx, r.GetTitle(), pq)
+	if err != nil {
+		return nil, s.errResponse(ctx, "GetAll", err)
+	}
+
+	res := &newsService.GetNewsResponse{
+		TotalCount: int64(newsList.TotalCount),
+		TotalPages: int64(newsList.TotalPages),
+		Page:       int64(newsList.Page),
+		Size:       int64(newsList.Size),
+		HasMore:    newsList.HasMore,
+		News:       make([]*newsService.New, 0, len(newsList.Items)),
+	}
+	for _, news := range newsList.Items {
+		res.News = append(res.News, newsToProto(news))
+	}
+
+	return res, nil
+}
+
+func (s *newsMicroservice) errResponse(ctx context.Context, method string, err error) error {
+	s.logger.FromContext(ctx).Errorf("newsMicroservice.%s: %s", method, err)
+	return grpcErrors.ErrorResponse(ctx, err)
+}
+
+func newsToProto(news *models.New) *newsService.New {
+	return &newsService.New{
+		Id:        news.ID.String(),
+		Title:     news.Title,
+		Content:   news.Content,
+		Tags:      news.Tags,
+		CreatedAt: timestamppb.New(news.CreatedAt),
+	}
+}
@@ -0,0 +1,270 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/content/mock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	newsService "github.com/Dostonlv/task-del/proto/news"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serve news service over in-memory connection, requests pass through gRPC encoding and status details
+func newTestClient(t *testing.T, newsUC *mock.MockUseCase[models.New]) newsService.NewsServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	newsService.RegisterNewsServiceServer(server, NewNewsMicroservice(nil, newsUC, logger.NewApiLogger(nil)))
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return newsService.NewNewsServiceClient(conn)
+}
+
+// testContext bound calls, failed mock expectation stops server handler goroutine and call would never return
+func testContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestNewsMicroservice_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+	client := newTestClient(t, mockNewsUC)
+	ctx := testContext(t)
+
+	newsID := uuid.New()
+
+	// Create a news success case, input is sanitized before use case
+	t.Run("Create", func(t *testing.T) {
+		var created *models.New
+		mockNewsUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, news *models.New) (*models.New, error) {
+			created = news
+			news.ID = newsID
+			return news, nil
+		})
+
+		res, err := client.Create(ctx, &newsService.CreateNewsRequest{
+			Title:   "test-title",
+			Content: "test-content",
+			Tags:    []string{"go"},
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, "test-title", created.Title)
+		require.Equal(t, models.Tags{"go"}, created.Tags)
+		require.Equal(t, newsID.String(), res.GetNews().GetId())
+		require.Equal(t, "test-title", res.GetNews().GetTitle())
+		require.Equal(t, []string{"go"}, res.GetNews().GetTags())
+	})
+
+	// Create news validation error case, use case is not called
+	t.Run("Create Validation Error", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(ctx, "accept-language", "uz")
+		res, err := client.Create(ctx, &newsService.CreateNewsRequest{
+			Title:   "t",
+			Content: "short",
+		})
+
+		require.Nil(t, res)
+		st := status.Convert(err)
+		require.Equal(t, codes.InvalidArgument, st.Code())
+
+		details := st.Details()
+		require.Len(t, details, 3)
+		badRequest, ok := details[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		require.Len(t, badRequest.GetFieldViolations(), 2)
+		require.Equal(t, "title", badRequest.GetFieldViolations()[0].GetField())
+		require.Equal(t, "title maydoni kamida 3 ta belgidan iborat bo'lishi kerak", badRequest.GetFieldViolations()[0].GetDescription())
+		require.Equal(t, "content", badRequest.GetFieldViolations()[1].GetField())
+	})
+}
+
+func TestNewsMicroservice_Update(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+	client := newTestClient(t, mockNewsUC)
+	ctx := testContext(t)
+
+	newsID := uuid.New()
+
+	t.Run("Update", func(t *testing.T) {
+		mockNewsUC.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, news *models.New) (*models.New, error) {
+			return news, nil
+		})
+
+		res, err := client.Update(ctx, &newsService.UpdateNewsRequest{
+			Id:      newsID.String(),
+			Title:   "updated-title",
+			Content: "updated-content",
+		})
+
+		require.NoError(t, err)
+		require.Equal(t, newsID.String(), res.GetNews().GetId())
+		require.Equal(t, "updated-title", res.GetNews().GetTitle())
+	})
+
+	t.Run("Update Invalid ID", func(t *testing.T) {
+		res, err := client.Update(ctx, &newsService.UpdateNewsRequest{
+			Id:      "invalid",
+			Title:   "updated-title",
+			Content: "updated-content",
+		})
+
+		require.Nil(t, res)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestNewsMicroservice_Delete(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+	client := newTestClient(t, mockNewsUC)
+	ctx := testContext(t)
+
+	newsID := uuid.New()
+
+	t.Run("Delete", func(t *testing.T) {
+		mockNewsUC.EXPECT().Delete(gomock.Any(), newsID).Return(nil)
+
+		res, err := client.Delete(ctx, &newsService.DeleteNewsRequest{Id: newsID.String()})
+
+		require.NoError(t, err)
+		require.NotNil(t, res)
+	})
+
+	t.Run("Delete Not Found", func(t *testing.T) {
+		mockNewsUC.EXPECT().Delete(gomock.Any(), newsID).
+			Return(errors.Wrap(sql.ErrNoRows, "newsRepo.Delete.ExecContext"))
+
+		res, err := client.Delete(ctx, &newsService.DeleteNewsRequest{Id: newsID.String()})
+
+		require.Nil(t, res)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestNewsMicroservice_GetByID(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+	client := newTestClient(t, mockNewsUC)
+	ctx := testContext(t)
+
+	newsID := uuid.New()
+
+	t.Run("GetByID", func(t *testing.T) {
+		mockNewsUC.EXPECT().GetByID(gomock.Any(), newsID).Return(&models.New{
+			Entry: models.Entry{
+				ID:      newsID,
+				Title:   "test-title",
+				Content: "test-content",
+			},
+		}, nil)
+
+		res, err := client.GetByID(ctx, &newsService.GetNewsByIDRequest{Id: newsID.String()})
+
+		require.NoError(t, err)
+		require.Equal(t, newsID.String(), res.GetNews().GetId())
+		require.Equal(t, "test-content", res.GetNews().GetContent())
+	})
+
+	// invalid id case
+	t.Run("GetByID Invalid ID", func(t *testing.T) {
+		res, err := client.GetByID(ctx, &newsService.GetNewsByIDRequest{Id: "invalid"})
+
+		require.Nil(t, res)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	// not found case
+	t.Run("GetByID Not Found", func(t *testing.T) {
+		mockNewsUC.EXPECT().GetByID(gomock.Any(), gomock.Any()).
+			Return(nil, errors.Wrap(sql.ErrNoRows, "newsRepo.GetByID.GetContext"))
+
+		res, err := client.GetByID(ctx, &newsService.GetNewsByIDRequest{Id: uuid.NewString()})
+
+		require.Nil(t, res)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestNewsMicroservice_GetAll(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockNewsUC := mock.NewMockUseCase[models.New](ctrl)
+	client := newTestClient(t, mockNewsUC)
+	ctx := testContext(t)
+
+	t.Run("GetAll", func(t *testing.T) {
+		mockNewsUC.EXPECT().GetAll(gomock.Any(), "go", gomock.Any()).Return(&models.NewsList{
+			TotalCount: 3,
+			TotalPages: 2,
+			Page:       1,
+			Size:       2,
+			HasMore:    true,
+			Items: []*models.New{
+				{Entry: models.Entry{ID: uuid.New(), Title: "first"}},
+				{Entry: models.Entry{ID: uuid.New(), Title: "second"}},
+			},
+		}, nil)
+
+		res, err := client.GetAll(ctx, &newsService.GetNewsRequest{Title: "go", Page: 1, Size: 2})
+
+		require.NoError(t, err)
+		require.Equal(t, int64(3), res.GetTotalCount())
+		require.Equal(t, int64(2), res.GetTotalPages())
+		require.True(t, res.GetHasMore())
+		require.Len(t, res.GetNews(), 2)
+		require.Equal(t, "second", res.GetNews()[1].GetTitle())
+	})
+}
@@ -5,11 +5,7 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-// Map news routes
-func MapNewsRoutes(newsGroup *echo.Group, h news.Handlers) {
-	newsGroup.POST("", h.Create())
-	newsGroup.GET("", h.GetAll())
-	newsGroup.DELETE("/:id", h.Delete())
-	newsGroup.PUT("/:id", h.Update())
-	newsGroup.GET("/:id", h.GetByID())
+// Map live news stream routes
+func MapStreamRoutes(newsGroup *echo.Group, h news.StreamHandlers) {
+	newsGroup.GET("/stream", h.Stream())
 }
@@ -0,0 +1,230 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/news"
+	"github.com/Dostonlv/task-del/internal/news/stream"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	headerLastEventID = "Last-Event-ID"
+	defaultHeartbeat  = 15 * time.Second
+	sseRetry          = 3 * time.Second
+	wsWriteWait       = 10 * time.Second
+	wsMaxMessageSize  = 512
+	retryAfterSeconds = "5"
+	eventReset        = "reset"
+)
+
+// stream handlers
+type streamHandlers struct {
+	cfg       *config.Config
+	hub       *stream.Hub
+	heartbeat time.Duration
+	upgrader  websocket.Upgrader
+	logger    logger.Logger
+}
+
+// NewStreamHandlers Live news stream handlers constructor
+func NewStreamHandlers(cfg *config.Config, hub *stream.Hub, logger logger.Logger) news.StreamHandlers {
+	heartbeat := cfg.Stream.Heartbeat * time.Second
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+
+	return &streamHandlers{
+		cfg:       cfg,
+		hub:       hub,
+		heartbeat: heartbeat,
+		// stream is public and read only, same as CORS policy of the API
+		upgrader: websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }},
+		logger:   logger,
+	}
+}
+
+// Stream
+// @Summary Live news stream
+// @Description push news.created, news.updated and news.deleted events over Server-Sent Events,
+// @Description or over WebSocket when upgrade is requested. Send Last-Event-ID header (or last_event_id param)
+// @Description to resume, "reset" event means events were missed and news should be reloaded.
+// @Tags news
+// @Produce text/event-stream
+// @Param tags query string false "comma separated tags, only news with any of them are streamed"
+// @Param last_event_id query string false "resume after event ID"
+// @Success 200 {object} stream.Event
+// @Failure 503 {object} httpErrors.RestError
+// @Router /v1/news/stream [get]
+func (h *streamHandlers) Stream() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		lastEventID := c.Request().Header.Get(headerLastEventID)
+		if lastEventID == "" {
+			lastEventID = c.QueryParam("last_event_id")
+		}
+
+		sub, err := h.hub.Subscribe(lastEventID, parseTags(c.QueryParams()["tags"]))
+		if err != nil {
+			h.logger.FromContext(c.Request().Context()).Warnf("Stream subscription rejected: %s", err)
+			c.Response().Header().Set(echo.HeaderRetryAfter, retryAfterSeconds)
+			return utils.RespondError(c, appErrors.Wrap(err, appErrors.CodeUnavailable, err.Error()))
+		}
+		defer sub.Close()
+
+		if websocket.IsWebSocketUpgrade(c.Request()) {
+			return h.serveWebSocket(c, sub)
+		}
+		return h.serveSSE(c, sub)
+	}
+}
+
+// serveSSE write events in text/event-stream format until client leaves or hub disconnects it
+func (h *streamHandlers) serveSSE(c echo.Context, sub *stream.Subscription) error {
+	res := c.Response()
+
+	// server write timeout would cut long lived stream
+	if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil {
+		return errors.Wrap(err, "streamHandlers.serveSSE.SetWriteDeadline")
+	}
+
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", sseRetry.Milliseconds()); err != nil {
+		return nil
+	}
+	if sub.Reset {
+		if _, err := fmt.Fprintf(res, "event: %s\ndata: {}\n\n", eventReset); err != nil {
+			return nil
+		}
+	}
+	for _, event := range sub.Replay {
+		if err := writeSSE(res, event); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-sub.Done():
+			// client reconnects after retry delay and resumes from last received event
+			return nil
+		case event := <-sub.Events():
+			if err := writeSSE(res, event); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// serveWebSocket send events as JSON text messages, heartbeats are ping frames
+func (h *streamHandlers) serveWebSocket(c echo.Context, sub *stream.Subscription) error {
+	conn, err := h.upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// upgrader already replied with error status
+		return nil
+	}
+	defer conn.Close()
+
+	// read loop detects closed connections, clients are not expected to send messages
+	pongWait := 2 * h.heartbeat
+	conn.SetReadLimit(wsMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	if sub.Reset {
+		if err := writeWebSocket(conn, &stream.Event{Type: eventReset}); err != nil {
+			return nil
+		}
+	}
+	for _, event := range sub.Replay {
+		if err := writeWebSocket(conn, event); err != nil {
+			return nil
+		}
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return nil
+		case <-sub.Done():
+			code := websocket.CloseGoingAway
+			if errors.Is(sub.Err(), stream.ErrLagging) || errors.Is(sub.Err(), stream.ErrMissed) {
+				code = websocket.CloseTryAgainLater
+			}
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, sub.Err().Error()), time.Now().Add(wsWriteWait))
+			return nil
+		case event := <-sub.Events():
+			if err := writeWebSocket(conn, event); err != nil {
+				return nil
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func writeSSE(res *echo.Response, event *stream.Event) error {
+	_, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+	return err
+}
+
+func writeWebSocket(conn *websocket.Conn, event *stream.Event) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(wsWriteWait)); err != nil {
+		return err
+	}
+	return conn.WriteJSON(event)
+}
+
+// parseTags accept both repeated and comma separated tags params
+func parseTags(params []string) []string {
+	tags := make([]string, 0, len(params))
+	for _, param := range params {
+		for _, tag := range strings.Split(param, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/news/stream"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamServer(t *testing.T, maxConns int) (*stream.Hub, *httptest.Server) {
+	cfg := &config.Config{Stream: config.StreamConfig{BufferSize: 10, ClientBuffer: 10, Heartbeat: 1, MaxConnections: maxConns}}
+	hub := stream.NewHub(cfg)
+
+	e := echo.New()
+	MapStreamRoutes(e.Group("/v1/news"), NewStreamHandlers(cfg, hub, logger.NewApiLogger(nil)))
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	return hub, server
+}
+
+func TestStreamHandlers_SSE(t *testing.T) {
+	t.Parallel()
+
+	hub, server := newStreamServer(t, 1)
+	ctx := context.Background()
+	require.NoError(t, hub.Publish(ctx, &models.OutboxEvent{ID: 1, AggregateType: models.AggregateNews, EventType: models.EventNewsCreated, Payload: []byte(`{"tags":["go"]}`)}))
+	require.NoError(t, hub.Publish(ctx, &models.OutboxEvent{ID: 2, AggregateType: models.AggregateNews, EventType: models.EventNewsUpdated, Payload: []byte(`{"tags":["go"]}`)}))
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/v1/news/stream?tags=go", nil)
+	require.NoError(t, err)
+	req.Header.Set(headerLastEventID, "1")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+
+	// replayed event is followed by live one
+	reader := bufio.NewReader(res.Body)
+	require.Equal(t, "retry: 3000\n\n", readSSE(t, reader))
+	require.Equal(t, "id: 2\nevent: news.updated\ndata: {\"tags\":[\"go\"]}\n\n", readSSE(t, reader))
+
+	require.NoError(t, hub.Publish(ctx, &models.OutboxEvent{ID: 3, AggregateType: models.AggregateNews, EventType: models.EventNewsDeleted, Payload: []byte(`{"id":"x"}`)}))
+	require.Equal(t, "id: 3\nevent: news.deleted\ndata: {\"id\":\"x\"}\n\n", readSSE(t, reader))
+	require.Equal(t, ": heartbeat\n\n", readSSE(t, reader))
+
+	// connection limit rejects second client
+	limited, err := http.Get(server.URL + "/v1/news/stream")
+	require.NoError(t, err)
+	limited.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, limited.StatusCode)
+	require.Equal(t, retryAfterSeconds, limited.Header.Get(echo.HeaderRetryAfter))
+
+	// shutdown ends open stream
+	require.NoError(t, hub.Close(ctx))
+}
+
+func TestStreamHandlers_WebSocket(t *testing.T) {
+	t.Parallel()
+
+	hub, server := newStreamServer(t, 0)
+	ctx := context.Background()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/v1/news/stream", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.Eventually(t, func() bool { return hub.Connections() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, hub.Publish(ctx, &models.OutboxEvent{ID: 7, AggregateType: models.AggregateNews, EventType: models.EventNewsCreated, Payload: []byte(`{"tags":[]}`)}))
+
+	event := &stream.Event{}
+	require.NoError(t, conn.ReadJSON(event))
+	require.Equal(t, "7", event.ID)
+	require.Equal(t, models.EventNewsCreated, event.Type)
+
+	// shutdown closes connection with going away code
+	require.NoError(t, hub.Close(ctx))
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+}
+
+func readSSE(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+
+	var message strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		message.WriteString(line)
+		if line == "\n" {
+			return message.String()
+		}
+	}
+}
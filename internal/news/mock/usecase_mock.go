@@ -1,111 +0,0 @@
-// Code generated by MockGen. DO NOT EDIT.
-// Source: usecase.go
-
-// Package mock is a generated GoMock package.
-package mock
-import (
-	context "context"
-	"github.com/Dostonlv/task-del/internal/models"
-	"github.com/Dostonlv/task-del/pkg/utils"
-	reflect "reflect"
-
-
-	gomock "go.uber.org/mock/gomock"
-)
-
-// MockUseCase is a mock of UseCase interface.
-type MockUseCase struct {
-	ctrl     *gomock.Controller
-	recorder *MockUseCaseMockRecorder
-}
-
-// MockUseCaseMockRecorder is the mock recorder for MockUseCase.
-type MockUseCaseMockRecorder struct {
-	mock *MockUseCase
-}
-
-// NewMockUseCase creates a new mock instance.
-func NewMockUseCase(ctrl *gomock.Controller) *MockUseCase {
-	mock := &MockUseCase{ctrl: ctrl}
-	mock.recorder = &MockUseCaseMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
-	return m.recorder
-}
-
-// Create mocks base method.
-func (m *MockUseCase) Create(ctx context.Context, news *models.New) (*models.New, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, news)
-	ret0, _ := ret[0].(*models.New)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// Create indicates an expected call of Create.
-func (mr *MockUseCaseMockRecorder) Create(ctx, news any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUseCase)(nil).Create), ctx, news)
-}
-
-// Delete mocks base method.
-func (m *MockUseCase) Delete(ctx context.Context, newsID int64) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, newsID)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// Delete indicates an expected call of Delete.
-func (mr *MockUseCaseMockRecorder) Delete(ctx, newsID any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUseCase)(nil).Delete), ctx, newsID)
-}
-
-// GetAll mocks base method.
-func (m *MockUseCase) GetAll(ctx context.Context, title string ,query *utils.PaginationQuery) (*models.NewsList, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx, query)
-	ret0, _ := ret[0].(*models.NewsList)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetAll indicates an expected call of GetAll.
-func (mr *MockUseCaseMockRecorder) GetAll(ctx, title any ,query any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockUseCase)(nil).GetAll), ctx,title, query)
-}
-
-// GetByID mocks base method.
-func (m *MockUseCase) GetByID(ctx context.Context, newsID int64) (*models.New, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, newsID)
-	ret0, _ := ret[0].(*models.New)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// GetByID indicates an expected call of GetByID.
-func (mr *MockUseCaseMockRecorder) GetByID(ctx, newsID any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUseCase)(nil).GetByID), ctx, newsID)
-}
-
-// Update mocks base method.
-func (m *MockUseCase) Update(ctx context.Context, news *models.New) (*models.New, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, news)
-	ret0, _ := ret[0].(*models.New)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// Update indicates an expected call of Update.
-func (mr *MockUseCaseMockRecorder) Update(ctx, news any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUseCase)(nil).Update), ctx, news)
-}
@@ -0,0 +1,69 @@
+package news
+
+import (
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/content"
+	contentHttp "github.com/Dostonlv/task-del/internal/content/delivery/http"
+	"github.com/Dostonlv/task-del/internal/content/repository"
+	"github.com/Dostonlv/task-del/internal/content/usecase"
+	"github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/labstack/echo/v4"
+)
+
+// Type news content type
+var Type = &content.Type{
+	Name:              "news",
+	Plural:            "news",
+	Table:             "news",
+	TranslationsTable: "news_translations",
+	TranslationsKey:   "news_id",
+	Aggregate:         models.AggregateNews,
+	EventCreated:      models.EventNewsCreated,
+	EventUpdated:      models.EventNewsUpdated,
+	EventDeleted:      models.EventNewsDeleted,
+}
+
+// Repository News repository interface
+type Repository = content.Repository[models.New]
+
+// UseCase News use case interface
+type UseCase = content.UseCase[models.New]
+
+// Handlers News HTTP Handlers interface
+type Handlers = content.Handlers
+
+// HandlersV2 News HTTP v2 Handlers interface
+type HandlersV2 = content.HandlersV2
+
+// NewRepository News repository constructor
+func NewRepository(db *sqlx.DB) Repository {
+	return repository.NewRepository[models.New](db, Type)
+}
+
+// NewUseCase News use case constructor
+func NewUseCase(cfg *config.Config, repo Repository, logger logger.Logger) UseCase {
+	return usecase.NewUseCase[models.New](cfg, Type, repo, logger)
+}
+
+// NewHandlers News handlers constructor
+func NewHandlers(cfg *config.Config, uc UseCase, logger logger.Logger) Handlers {
+	return contentHttp.NewHandlers[models.New](cfg, Type, uc, logger)
+}
+
+// NewHandlersV2 News v2 handlers constructor
+func NewHandlersV2(cfg *config.Config, uc UseCase, logger logger.Logger) HandlersV2 {
+	return contentHttp.NewHandlersV2[models.New](cfg, Type, uc, logger)
+}
+
+// MapRoutes Map news routes
+func MapRoutes(group *echo.Group, h Handlers, mw *middleware.MiddlewareManager) {
+	contentHttp.MapRoutes(group, h, mw)
+}
+
+// MapRoutesV2 Map news v2 routes
+func MapRoutesV2(group *echo.Group, h HandlersV2, mw *middleware.MiddlewareManager) {
+	contentHttp.MapRoutesV2(group, h, mw)
+}
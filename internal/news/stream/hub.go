@@ -0,0 +1,217 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultBufferSize   = 1000
+	defaultClientBuffer = 64
+)
+
+var (
+	// ErrTooManyConnections connection limit is reached
+	ErrTooManyConnections = errors.New("too many stream connections")
+	// ErrClosed hub is shutting down and does not accept subscribers
+	ErrClosed = errors.New("stream is closed")
+)
+
+// Event live news event, ID is the outbox event sequence number
+type Event struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+
+	tags []string
+}
+
+// Hub broadcasting news events to stream subscribers of this instance, fed by Listener.
+// Recent events are kept in bounded replay buffer, so reconnecting clients resume from Last-Event-ID.
+type Hub struct {
+	bufferSize   int
+	clientBuffer int
+	maxConns     int
+
+	mu          sync.Mutex
+	buffer      []*Event
+	subscribers map[*Subscription]struct{}
+	conns       int
+	closed      bool
+	active      sync.WaitGroup
+}
+
+// NewHub stream hub constructor
+func NewHub(cfg *config.Config) *Hub {
+	h := &Hub{
+		bufferSize:   cfg.Stream.BufferSize,
+		clientBuffer: cfg.Stream.ClientBuffer,
+		maxConns:     cfg.Stream.MaxConnections,
+		subscribers:  make(map[*Subscription]struct{}),
+	}
+	if h.bufferSize <= 0 {
+		h.bufferSize = defaultBufferSize
+	}
+	if h.clientBuffer <= 0 {
+		h.clientBuffer = defaultClientBuffer
+	}
+	return h
+}
+
+// Publish broadcast news event to matching subscribers, never blocks the listener.
+// Subscriber falling behind by more than its buffer is disconnected and resumes with Last-Event-ID.
+func (h *Hub) Publish(ctx context.Context, outboxEvent *models.OutboxEvent) error {
+	if outboxEvent.AggregateType != models.AggregateNews {
+		return nil
+	}
+
+	var payload struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal(outboxEvent.Payload, &payload); err != nil {
+		return errors.Wrap(err, "Hub.Publish.Unmarshal")
+	}
+
+	// single line payload fits into one SSE data field
+	data := &bytes.Buffer{}
+	if err := json.Compact(data, outboxEvent.Payload); err != nil {
+		return errors.Wrap(err, "Hub.Publish.Compact")
+	}
+
+	event := &Event{
+		ID:   strconv.FormatInt(outboxEvent.ID, 10),
+		Type: outboxEvent.EventType,
+		Data: data.Bytes(),
+		tags: payload.Tags,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// relay notifies again when another sink fails, subscribers already got it
+	if h.indexOf(event.ID) >= 0 {
+		return nil
+	}
+
+	if len(h.buffer) == h.bufferSize {
+		copy(h.buffer, h.buffer[1:])
+		h.buffer = h.buffer[:len(h.buffer)-1]
+	}
+	h.buffer = append(h.buffer, event)
+
+	for sub := range h.subscribers {
+		if !sub.matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.closeWith(ErrLagging)
+			delete(h.subscribers, sub)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe register subscriber interested in events with any of tags, all events when tags are empty.
+// Events published after lastEventID are replayed when it is still buffered, otherwise Reset is set
+// and client should reload the news list.
+func (h *Hub) Subscribe(lastEventID string, tags []string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+	// disconnected subscribers hold their slot until the connection is released
+	if h.maxConns > 0 && h.conns >= h.maxConns {
+		return nil, ErrTooManyConnections
+	}
+
+	sub := &Subscription{
+		hub:    h,
+		tags:   tags,
+		events: make(chan *Event, h.clientBuffer),
+		done:   make(chan struct{}),
+	}
+
+	if lastEventID != "" {
+		idx := h.indexOf(lastEventID)
+		if idx < 0 {
+			sub.Reset = true
+		} else {
+			for _, event := range h.buffer[idx+1:] {
+				if sub.matches(event) {
+					sub.Replay = append(sub.Replay, event)
+				}
+			}
+		}
+	}
+
+	h.subscribers[sub] = struct{}{}
+	h.conns++
+	h.active.Add(1)
+	return sub, nil
+}
+
+// Reset drop replay buffer and disconnect subscribers with ErrMissed when events may have been missed,
+// reconnecting clients do not find their Last-Event-ID and reload the news list
+func (h *Hub) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.buffer = h.buffer[:0]
+	for sub := range h.subscribers {
+		sub.closeWith(ErrMissed)
+		delete(h.subscribers, sub)
+	}
+}
+
+// Connections number of open stream connections
+func (h *Hub) Connections() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.conns
+}
+
+// Close reject new subscribers, disconnect current ones and wait until their connections are released
+func (h *Hub) Close(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	for sub := range h.subscribers {
+		sub.closeWith(ErrClosed)
+		delete(h.subscribers, sub)
+	}
+	h.mu.Unlock()
+
+	released := make(chan struct{})
+	go func() {
+		h.active.Wait()
+		close(released)
+	}()
+
+	select {
+	case <-released:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// indexOf position of buffered event, -1 when it is not buffered
+func (h *Hub) indexOf(eventID string) int {
+	for i := len(h.buffer) - 1; i >= 0; i-- {
+		if h.buffer[i].ID == eventID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,175 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHub(bufferSize, clientBuffer, maxConns int) *Hub {
+	return NewHub(&config.Config{Stream: config.StreamConfig{
+		BufferSize:     bufferSize,
+		ClientBuffer:   clientBuffer,
+		MaxConnections: maxConns,
+	}})
+}
+
+func newsEvent(id int64, eventType string, payload string) *models.OutboxEvent {
+	return &models.OutboxEvent{
+		ID:            id,
+		EventID:       uuid.New(),
+		AggregateType: models.AggregateNews,
+		AggregateID:   uuid.New(),
+		EventType:     eventType,
+		Payload:       []byte(payload),
+	}
+}
+
+func TestHub_Replay(t *testing.T) {
+	t.Parallel()
+
+	hub := newTestHub(3, 10, 0)
+	ctx := context.Background()
+
+	for i := int64(1); i <= 4; i++ {
+		require.NoError(t, hub.Publish(ctx, newsEvent(i, models.EventNewsCreated, `{"tags": ["go"]}`)))
+	}
+	// blog events are not streamed
+	require.NoError(t, hub.Publish(ctx, &models.OutboxEvent{ID: 5, AggregateType: models.AggregateBlog, Payload: []byte(`{}`)}))
+
+	// events after buffered Last-Event-ID are replayed with compacted payload
+	t.Run("Replay", func(t *testing.T) {
+		sub, err := hub.Subscribe("2", nil)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		require.False(t, sub.Reset)
+		require.Len(t, sub.Replay, 2)
+		require.Equal(t, "3", sub.Replay[0].ID)
+		require.Equal(t, "4", sub.Replay[1].ID)
+		require.Equal(t, `{"tags":["go"]}`, string(sub.Replay[0].Data))
+	})
+
+	// evicted Last-Event-ID asks client to reload
+	t.Run("Reset", func(t *testing.T) {
+		sub, err := hub.Subscribe("1", nil)
+		require.NoError(t, err)
+		defer sub.Close()
+
+		require.True(t, sub.Reset)
+		require.Empty(t, sub.Replay)
+	})
+}
+
+func TestHub_Publish(t *testing.T) {
+	t.Parallel()
+
+	hub := newTestHub(10, 10, 0)
+	ctx := context.Background()
+
+	tagged, err := hub.Subscribe("", []string{"go", "rust"})
+	require.NoError(t, err)
+	defer tagged.Close()
+	all, err := hub.Subscribe("", nil)
+	require.NoError(t, err)
+	defer all.Close()
+
+	require.NoError(t, hub.Publish(ctx, newsEvent(1, models.EventNewsCreated, `{"tags":["python"]}`)))
+	require.NoError(t, hub.Publish(ctx, newsEvent(2, models.EventNewsUpdated, `{"tags":["rust"]}`)))
+	require.NoError(t, hub.Publish(ctx, newsEvent(3, models.EventNewsDeleted, `{"id":"1"}`)))
+	// relay retry of already broadcast event is ignored
+	require.NoError(t, hub.Publish(ctx, newsEvent(2, models.EventNewsUpdated, `{"tags":["rust"]}`)))
+
+	// tagged subscriber gets matching events and all deletions
+	require.Len(t, tagged.Events(), 2)
+	require.Equal(t, "2", (<-tagged.Events()).ID)
+	require.Equal(t, "3", (<-tagged.Events()).ID)
+	require.Len(t, all.Events(), 3)
+}
+
+func TestHub_Lagging(t *testing.T) {
+	t.Parallel()
+
+	hub := newTestHub(10, 1, 0)
+	ctx := context.Background()
+
+	sub, err := hub.Subscribe("", nil)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	require.NoError(t, hub.Publish(ctx, newsEvent(1, models.EventNewsCreated, `{}`)))
+	require.NoError(t, hub.Publish(ctx, newsEvent(2, models.EventNewsCreated, `{}`)))
+
+	<-sub.Done()
+	require.ErrorIs(t, sub.Err(), ErrLagging)
+}
+
+func TestHub_ConnectionLimit(t *testing.T) {
+	t.Parallel()
+
+	hub := newTestHub(10, 10, 2)
+
+	first, err := hub.Subscribe("", nil)
+	require.NoError(t, err)
+	second, err := hub.Subscribe("", nil)
+	require.NoError(t, err)
+
+	_, err = hub.Subscribe("", nil)
+	require.ErrorIs(t, err, ErrTooManyConnections)
+
+	// released slot can be reused
+	first.Close()
+	third, err := hub.Subscribe("", nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, hub.Connections())
+
+	// close disconnects subscribers and waits for their connections to be released
+	closed := make(chan error, 1)
+	go func() {
+		closed <- hub.Close(context.Background())
+	}()
+
+	<-second.Done()
+	<-third.Done()
+	require.ErrorIs(t, second.Err(), ErrClosed)
+	second.Close()
+	third.Close()
+
+	select {
+	case err := <-closed:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("hub is not closed after connections are released")
+	}
+
+	_, err = hub.Subscribe("", nil)
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func TestHub_Reset(t *testing.T) {
+	t.Parallel()
+
+	hub := newTestHub(10, 10, 0)
+	ctx := context.Background()
+
+	sub, err := hub.Subscribe("", nil)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	require.NoError(t, hub.Publish(ctx, newsEvent(1, models.EventNewsCreated, `{}`)))
+	hub.Reset()
+
+	// subscribers are disconnected and their Last-Event-ID is no longer buffered
+	<-sub.Done()
+	require.ErrorIs(t, sub.Err(), ErrMissed)
+
+	resumed, err := hub.Subscribe("1", nil)
+	require.NoError(t, err)
+	defer resumed.Close()
+	require.True(t, resumed.Reset)
+}
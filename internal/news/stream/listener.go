@@ -0,0 +1,155 @@
+package stream
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/outbox"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/jackc/pgx"
+	"github.com/pkg/errors"
+)
+
+const (
+	reconnectBackoffBase = time.Second
+	reconnectBackoffMax  = 30 * time.Second
+)
+
+// Conn dedicated Postgres connection receiving notifications
+type Conn interface {
+	Listen(channel string) error
+	WaitForNotification(ctx context.Context) (*pgx.Notification, error)
+	Close() error
+}
+
+// Listener feed hub with news events notified by outbox relay of any instance, so every instance streams every event.
+// Notifications sent while connection is down are lost, hub is reset after reconnect and clients reload.
+type Listener struct {
+	hub     *Hub
+	repo    outbox.Repository
+	connect func() (Conn, error)
+	logger  logger.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewListener stream listener constructor, connect opens new dedicated connection
+func NewListener(hub *Hub, repo outbox.Repository, connect func() (Conn, error), logger logger.Logger) *Listener {
+	return &Listener{hub: hub, repo: repo, connect: connect, logger: logger}
+}
+
+// Start listen on Channel and receive notifications in background, failed connection fails start
+func (l *Listener) Start(ctx context.Context) error {
+	conn, err := l.listen()
+	if err != nil {
+		return err
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	l.done = make(chan struct{})
+
+	go l.run(runCtx, conn)
+	return nil
+}
+
+// Stop close connection and wait for listener loop
+func (l *Listener) Stop(ctx context.Context) error {
+	if l.cancel == nil {
+		return nil
+	}
+	l.cancel()
+
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *Listener) listen() (Conn, error) {
+	conn, err := l.connect()
+	if err != nil {
+		return nil, errors.Wrap(err, "Listener.listen.connect")
+	}
+	if err := conn.Listen(Channel); err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrap(err, "Listener.listen.Listen")
+	}
+	return conn, nil
+}
+
+func (l *Listener) run(ctx context.Context, conn Conn) {
+	defer close(l.done)
+
+	for {
+		err := l.receive(ctx, conn)
+		_ = conn.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		l.logger.Errorf("Listener.run.receive: %s", err)
+
+		if conn = l.reconnect(ctx); conn == nil {
+			return
+		}
+		l.hub.Reset()
+	}
+}
+
+// receive publish notified events until connection fails or ctx is done
+func (l *Listener) receive(ctx context.Context, conn Conn) error {
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		l.publish(ctx, notification.Payload)
+	}
+}
+
+// publish load notified event and broadcast it, hub is reset when event can not be loaded
+func (l *Listener) publish(ctx context.Context, payload string) {
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		l.logger.Errorf("Listener.publish.ParseInt, Payload: %q, Error: %s", payload, err)
+		return
+	}
+
+	event, err := l.repo.GetByID(ctx, id)
+	if err != nil {
+		if ctx.Err() == nil {
+			l.logger.Errorf("Listener.publish.GetByID, ID: %d, Error: %s", id, err)
+			l.hub.Reset()
+		}
+		return
+	}
+
+	if err := l.hub.Publish(ctx, event); err != nil {
+		l.logger.Errorf("Listener.publish.Publish, EventID: %s, Error: %s", event.EventID, err)
+	}
+}
+
+// reconnect retry with backoff until connected, nil when ctx is done
+func (l *Listener) reconnect(ctx context.Context) Conn {
+	for attempt := 1; ; attempt++ {
+		timer := time.NewTimer(utils.Backoff(attempt, reconnectBackoffBase, reconnectBackoffMax))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		conn, err := l.listen()
+		if err == nil {
+			l.logger.Infof("Stream listener reconnected, Attempts: %d", attempt)
+			return conn
+		}
+		l.logger.Errorf("Listener.reconnect, Attempt: %d, Error: %s", attempt, err)
+	}
+}
@@ -0,0 +1,117 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/outbox/mock"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeConn struct {
+	notifications chan *pgx.Notification
+	fail          chan error
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{notifications: make(chan *pgx.Notification), fail: make(chan error)}
+}
+
+func (c *fakeConn) Listen(channel string) error {
+	return nil
+}
+
+func (c *fakeConn) WaitForNotification(ctx context.Context) (*pgx.Notification, error) {
+	select {
+	case n := <-c.notifications:
+		return n, nil
+	case err := <-c.fail:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func receive(t *testing.T, sub *Subscription) *Event {
+	t.Helper()
+
+	select {
+	case event := <-sub.Events():
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not received")
+		return nil
+	}
+}
+
+func TestListener(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	hub := newTestHub(10, 10, 0)
+
+	first, second := newFakeConn(), newFakeConn()
+	conns := []*fakeConn{first, second}
+	connect := func() (Conn, error) {
+		conn := conns[0]
+		conns = conns[1:]
+		return conn, nil
+	}
+
+	listener := NewListener(hub, mockRepo, connect, logger.NewApiLogger(nil))
+	require.NoError(t, listener.Start(context.Background()))
+
+	// notified event is loaded and broadcast
+	sub, err := hub.Subscribe("", nil)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(newsEvent(1, models.EventNewsCreated, `{}`), nil)
+	first.notifications <- &pgx.Notification{Channel: Channel, Payload: "1"}
+	require.Equal(t, "1", receive(t, sub).ID)
+
+	// notifications may be lost while reconnecting, subscribers are reset
+	first.fail <- errors.New("connection reset")
+	<-sub.Done()
+	require.ErrorIs(t, sub.Err(), ErrMissed)
+
+	resumed, err := hub.Subscribe("", nil)
+	require.NoError(t, err)
+	defer resumed.Close()
+
+	mockRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(newsEvent(2, models.EventNewsUpdated, `{}`), nil)
+	second.notifications <- &pgx.Notification{Channel: Channel, Payload: "2"}
+	require.Equal(t, "2", receive(t, resumed).ID)
+
+	require.NoError(t, listener.Stop(context.Background()))
+}
+
+func TestListener_StartError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	connect := func() (Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+	listener := NewListener(newTestHub(10, 10, 0), mock.NewMockRepository(ctrl), connect, logger.NewApiLogger(nil))
+
+	err := listener.Start(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "connection refused")
+	require.NoError(t, listener.Stop(context.Background()))
+}
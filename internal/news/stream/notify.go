@@ -0,0 +1,42 @@
+package stream
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// Channel Postgres notification channel of relayed news events, payload is outbox event ID
+const Channel = "news_stream"
+
+// NotifySink outbox sink notifying every instance about relayed news event.
+// Relay claims each event on a single instance, notification fans it out to the hubs of all of them.
+type NotifySink struct {
+	db *sqlx.DB
+}
+
+// NewNotifySink stream outbox sink constructor
+func NewNotifySink(db *sqlx.DB) *NotifySink {
+	return &NotifySink{db: db}
+}
+
+// Name of outbox sink
+func (s *NotifySink) Name() string {
+	return "stream"
+}
+
+// Publish notify listeners about news event, other aggregates are skipped
+func (s *NotifySink) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	if event.AggregateType != models.AggregateNews {
+		return nil
+	}
+
+	if _, err := s.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, Channel, strconv.FormatInt(event.ID, 10)); err != nil {
+		return errors.Wrap(err, "NotifySink.Publish.ExecContext")
+	}
+
+	return nil
+}
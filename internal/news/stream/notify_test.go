@@ -0,0 +1,35 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifySink_Publish(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sink := NewNotifySink(sqlx.NewDb(db, "sqlmock"))
+
+	// news event is notified by its outbox ID
+	t.Run("Publish", func(t *testing.T) {
+		mock.ExpectExec(`SELECT pg_notify($1, $2)`).WithArgs(Channel, "42").WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, sink.Publish(context.Background(), newsEvent(42, models.EventNewsCreated, `{}`)))
+	})
+
+	// blog events are not streamed
+	t.Run("Publish Blog", func(t *testing.T) {
+		require.NoError(t, sink.Publish(context.Background(), &models.OutboxEvent{ID: 43, AggregateType: models.AggregateBlog}))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
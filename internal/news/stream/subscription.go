@@ -0,0 +1,87 @@
+package stream
+
+import (
+	"sync"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrLagging subscriber did not keep up with published events
+	ErrLagging = errors.New("stream subscriber is lagging behind")
+	// ErrMissed events may have been missed while notifications were not received
+	ErrMissed = errors.New("stream events may have been missed")
+)
+
+// Subscription stream subscriber, must be closed when connection ends
+type Subscription struct {
+	// Replay buffered events published after requested Last-Event-ID
+	Replay []*Event
+	// Reset requested Last-Event-ID is no longer buffered, events may have been missed
+	Reset bool
+
+	hub    *Hub
+	tags   []string
+	events chan *Event
+
+	done      chan struct{}
+	err       error
+	closeOnce sync.Once
+	leaveOnce sync.Once
+}
+
+// Events live events channel
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+// Done closed when hub disconnects subscriber, Err tells why
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err reason of disconnect, ErrClosed on shutdown, ErrLagging for slow subscriber or ErrMissed after hub reset
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close unsubscribe and release connection slot
+func (s *Subscription) Close() {
+	s.leaveOnce.Do(func() {
+		s.hub.mu.Lock()
+		delete(s.hub.subscribers, s)
+		s.hub.conns--
+		s.hub.mu.Unlock()
+
+		s.closeWith(nil)
+		s.hub.active.Done()
+	})
+}
+
+func (s *Subscription) closeWith(err error) {
+	s.closeOnce.Do(func() {
+		s.err = err
+		close(s.done)
+	})
+}
+
+// matches event has any of subscribed tags, deletions are sent to everyone as payload has no tags
+func (s *Subscription) matches(event *Event) bool {
+	if len(s.tags) == 0 || event.Type == models.EventNewsDeleted {
+		return true
+	}
+	for _, tag := range s.tags {
+		for _, eventTag := range event.tags {
+			if tag == eventTag {
+				return true
+			}
+		}
+	}
+	return false
+}
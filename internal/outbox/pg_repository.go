@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/utils"
+)
+
+// Handler process single outbox event, returned error schedules retry of the event
+type Handler func(ctx context.Context, event *models.OutboxEvent) error
+
+// RetryPolicy schedule of events whose handler failed, after MaxAttempts attempts event is marked failed
+// and no longer blocks later events of its aggregate
+type RetryPolicy struct {
+	MaxAttempts int
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+}
+
+// Backoff delay before next attempt of event handled given number of times
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	return utils.Backoff(attempts, p.BackoffBase, p.BackoffMax)
+}
+
+// Exhausted true if event handled given number of times must not be retried, zero MaxAttempts retries forever
+func (p RetryPolicy) Exhausted(attempts int) bool {
+	return p.MaxAttempts > 0 && attempts >= p.MaxAttempts
+}
+
+// Repository Outbox repository interface
+type Repository interface {
+	ProcessBatch(ctx context.Context, limit int, retry RetryPolicy, handler Handler) (int, error)
+	GetByID(ctx context.Context, id int64) (*models.OutboxEvent, error)
+	DeleteProcessed(ctx context.Context, before time.Time) (int64, error)
+}
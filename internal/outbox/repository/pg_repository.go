@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/outbox"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const addEventQuery = `INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)`
+
+// AddEvent write event into outbox, tx must be the transaction of the change the event describes
+func AddEvent(ctx context.Context, tx sqlx.ExecerContext, aggregateType string, aggregateID uuid.UUID, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "outbox.AddEvent.Marshal")
+	}
+
+	if _, err := tx.ExecContext(ctx, addEventQuery, uuid.New(), aggregateType, aggregateID, eventType, payload); err != nil {
+		return errors.Wrap(err, "outbox.AddEvent.ExecContext")
+	}
+
+	return nil
+}
+
+// outbox Repository
+type outboxRepo struct {
+	db *sqlx.DB
+}
+
+// NewOutboxRepository Outbox Repository constructor
+func NewOutboxRepository(db *sqlx.DB) outbox.Repository {
+	return &outboxRepo{db: db}
+}
+
+// ProcessBatch lock oldest pending event of every aggregate with FOR UPDATE SKIP LOCKED and pass them to handler in order.
+// Later events of an aggregate are not selected while an earlier one is pending, so per aggregate order is kept
+// across concurrent relays. Failed event is retried after retry backoff and marked failed once retry attempts
+// are exhausted, which unblocks its aggregate. Returns number of successfully handled events.
+func (r *outboxRepo) ProcessBatch(ctx context.Context, limit int, retry outbox.RetryPolicy, handler outbox.Handler) (int, error) {
+	claimEvents := `SELECT id, event_id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, created_at, processed_at, next_attempt_at, failed_at
+	FROM outbox o
+	WHERE o.processed_at IS NULL AND o.failed_at IS NULL AND o.next_attempt_at <= CURRENT_TIMESTAMP
+		AND NOT EXISTS (
+			SELECT 1 FROM outbox p
+			WHERE p.aggregate_id = o.aggregate_id AND p.processed_at IS NULL AND p.failed_at IS NULL AND p.id < o.id
+		)
+	ORDER BY o.id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED`
+	markProcessed := `UPDATE outbox SET processed_at = CURRENT_TIMESTAMP, attempts = attempts + 1, last_error = NULL WHERE id = $1`
+	markFailed := `UPDATE outbox SET attempts = attempts + 1, last_error = $1,
+		next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 millisecond',
+		failed_at = CASE WHEN $3 THEN CURRENT_TIMESTAMP END
+	WHERE id = $4`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "outboxRepo.ProcessBatch", claimEvents)
+	defer span.End()
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "outboxRepo.ProcessBatch.BeginTxx"))
+	}
+	defer tx.Rollback()
+
+	events := make([]*models.OutboxEvent, 0, limit)
+	if err := tx.SelectContext(ctx, &events, claimEvents, limit); err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "outboxRepo.ProcessBatch.SelectContext"))
+	}
+
+	processed := 0
+	for _, event := range events {
+		if handleErr := handler(ctx, event); handleErr != nil {
+			attempts := event.Attempts + 1
+			exhausted := retry.Exhausted(attempts)
+			if exhausted {
+				logger.FromContext(ctx).Errorf("Outbox event failed, retries exhausted, EventID: %s, Event: %s, Attempts: %d, Error: %s",
+					event.EventID, event.EventType, attempts, handleErr)
+			} else {
+				logger.FromContext(ctx).Debugf("outboxRepo.ProcessBatch, EventID: %s, Attempts: %d, Error: %s", event.EventID, attempts, handleErr)
+			}
+
+			if _, err := tx.ExecContext(ctx, markFailed, handleErr.Error(), retry.Backoff(attempts).Milliseconds(), exhausted, event.ID); err != nil {
+				return 0, tracing.RecordError(span, errors.Wrap(err, "outboxRepo.ProcessBatch.ExecContext.markFailed"))
+			}
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, markProcessed, event.ID); err != nil {
+			return 0, tracing.RecordError(span, errors.Wrap(err, "outboxRepo.ProcessBatch.ExecContext.markProcessed"))
+		}
+		processed++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "outboxRepo.ProcessBatch.Commit"))
+	}
+
+	return processed, nil
+}
+
+// GetByID outbox event by sequence ID
+func (r *outboxRepo) GetByID(ctx context.Context, id int64) (*models.OutboxEvent, error) {
+	getEvent := `SELECT id, event_id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, created_at, processed_at, next_attempt_at, failed_at
+	FROM outbox
+	WHERE id = $1`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "outboxRepo.GetByID", getEvent)
+	defer span.End()
+
+	event := &models.OutboxEvent{}
+	if err := r.db.GetContext(ctx, event, getEvent, id); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, "outboxRepo.GetByID.GetContext"))
+	}
+
+	return event, nil
+}
+
+// DeleteProcessed remove events processed before given time
+func (r *outboxRepo) DeleteProcessed(ctx context.Context, before time.Time) (int64, error) {
+	deleteProcessed := `DELETE FROM outbox WHERE processed_at IS NOT NULL AND processed_at < $1`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "outboxRepo.DeleteProcessed", deleteProcessed)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteProcessed, before)
+	if err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "outboxRepo.DeleteProcessed.ExecContext"))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "outboxRepo.DeleteProcessed.RowsAffected"))
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/outbox"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepo_ProcessBatch(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewOutboxRepository(sqlxDB)
+
+	claimEvents := `SELECT id, event_id, aggregate_type, aggregate_id, event_type, payload, attempts, last_error, created_at, processed_at, next_attempt_at, failed_at
+	FROM outbox o
+	WHERE o.processed_at IS NULL AND o.failed_at IS NULL AND o.next_attempt_at <= CURRENT_TIMESTAMP
+		AND NOT EXISTS (
+			SELECT 1 FROM outbox p
+			WHERE p.aggregate_id = o.aggregate_id AND p.processed_at IS NULL AND p.failed_at IS NULL AND p.id < o.id
+		)
+	ORDER BY o.id
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED`
+	columns := []string{"id", "event_id", "aggregate_type", "aggregate_id", "event_type", "payload", "attempts", "last_error", "created_at", "processed_at", "next_attempt_at", "failed_at"}
+	markFailed := `UPDATE outbox SET attempts = attempts + 1, last_error = $1,
+		next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 millisecond',
+		failed_at = CASE WHEN $3 THEN CURRENT_TIMESTAMP END
+	WHERE id = $4`
+	retry := outbox.RetryPolicy{MaxAttempts: 3, BackoffBase: time.Second, BackoffMax: time.Minute}
+
+	// handled event is marked processed, failed one records error and is retried after backoff
+	t.Run("ProcessBatch", func(t *testing.T) {
+		okEventID := uuid.New()
+		failedEventID := uuid.New()
+		rows := sqlmock.NewRows(columns).
+			AddRow(1, okEventID, models.AggregateNews, uuid.New(), models.EventNewsCreated, []byte(`{}`), 0, nil, time.Now(), nil, time.Now(), nil).
+			AddRow(2, failedEventID, models.AggregateBlog, uuid.New(), models.EventBlogCreated, []byte(`{}`), 1, nil, time.Now(), nil, time.Now(), nil)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimEvents).WithArgs(10).WillReturnRows(rows)
+		mock.ExpectExec(`UPDATE outbox SET processed_at = CURRENT_TIMESTAMP, attempts = attempts + 1, last_error = NULL WHERE id = $1`).
+			WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(markFailed).
+			WithArgs("sink webhooks: unavailable", int64(2000), false, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		processed, err := repo.ProcessBatch(context.Background(), 10, retry, func(ctx context.Context, event *models.OutboxEvent) error {
+			if event.EventID == failedEventID {
+				return errors.New("sink webhooks: unavailable")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, processed)
+	})
+
+	// event failing its last attempt is marked failed and no longer blocks its aggregate
+	t.Run("ProcessBatch Retries Exhausted", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).
+			AddRow(3, uuid.New(), models.AggregateBlog, uuid.New(), models.EventBlogUpdated, []byte(`{}`), 2, "unavailable", time.Now(), nil, time.Now(), nil)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimEvents).WithArgs(10).WillReturnRows(rows)
+		mock.ExpectExec(markFailed).
+			WithArgs("sink broker: unavailable", int64(4000), true, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		processed, err := repo.ProcessBatch(context.Background(), 10, retry, func(ctx context.Context, event *models.OutboxEvent) error {
+			return errors.New("sink broker: unavailable")
+		})
+		require.NoError(t, err)
+		require.Zero(t, processed)
+	})
+
+	// claim error rolls transaction back
+	t.Run("ProcessBatch Error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(claimEvents).WithArgs(10).WillReturnError(sqlmock.ErrCancelled)
+		mock.ExpectRollback()
+
+		processed, err := repo.ProcessBatch(context.Background(), 10, retry, func(ctx context.Context, event *models.OutboxEvent) error {
+			return nil
+		})
+		require.Error(t, err)
+		require.Zero(t, processed)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,13 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/Dostonlv/task-del/internal/models"
+)
+
+// Sink destination of relayed outbox events, must tolerate duplicates as delivery is at-least-once
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+}
@@ -0,0 +1,46 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/outbox"
+	"github.com/pkg/errors"
+)
+
+// Broker message broker publisher
+type Broker interface {
+	Publish(ctx context.Context, subject string, msgID string, data []byte) error
+}
+
+// broker sink
+type brokerSink struct {
+	broker        Broker
+	subjectPrefix string
+}
+
+// NewBrokerSink sink publishing events to "<prefix>.<event type>" subjects, event ID is used as message ID
+func NewBrokerSink(broker Broker, subjectPrefix string) outbox.Sink {
+	return &brokerSink{broker: broker, subjectPrefix: subjectPrefix}
+}
+
+// Name of sink
+func (s *brokerSink) Name() string {
+	return "broker"
+}
+
+// Publish event to broker
+func (s *brokerSink) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "brokerSink.Publish.Marshal")
+	}
+
+	subject := event.EventType
+	if s.subjectPrefix != "" {
+		subject = s.subjectPrefix + "." + subject
+	}
+
+	return s.broker.Publish(ctx, subject, event.EventID.String(), data)
+}
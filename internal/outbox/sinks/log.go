@@ -0,0 +1,31 @@
+package sinks
+
+import (
+	"context"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/outbox"
+	"github.com/Dostonlv/task-del/pkg/logger"
+)
+
+// log sink
+type logSink struct {
+	logger logger.Logger
+}
+
+// NewLogSink sink writing every event to application log
+func NewLogSink(logger logger.Logger) outbox.Sink {
+	return &logSink{logger: logger}
+}
+
+// Name of sink
+func (s *logSink) Name() string {
+	return "log"
+}
+
+// Publish log event
+func (s *logSink) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	s.logger.Infof("Outbox event, EventID: %s, Event: %s, AggregateType: %s, AggregateID: %s, Payload: %s",
+		event.EventID, event.EventType, event.AggregateType, event.AggregateID, event.Payload)
+	return nil
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/outbox"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const cleanupInterval = time.Hour
+
+// Relay move outbox events to sinks in background, every event is published to all sinks at least once
+type Relay struct {
+	cfg    config.OutboxConfig
+	retry  outbox.RetryPolicy
+	repo   outbox.Repository
+	sinks  []outbox.Sink
+	logger logger.Logger
+
+	lastCleanup time.Time
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewRelay outbox relay constructor
+func NewRelay(cfg *config.Config, repo outbox.Repository, sinks []outbox.Sink, logger logger.Logger) *Relay {
+	outboxCfg := cfg.Outbox
+	if outboxCfg.BatchSize <= 0 {
+		outboxCfg.BatchSize = 100
+	}
+
+	retry := outbox.RetryPolicy{
+		MaxAttempts: outboxCfg.MaxAttempts,
+		BackoffBase: outboxCfg.BackoffBase * time.Second,
+		BackoffMax:  outboxCfg.BackoffMax * time.Second,
+	}
+
+	return &Relay{cfg: outboxCfg, retry: retry, repo: repo, sinks: sinks, logger: logger}
+}
+
+// Start run relay loop in background
+func (r *Relay) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.run(runCtx)
+	return nil
+}
+
+// Stop wait for in-flight batch, unfinished events stay pending and are relayed after restart
+func (r *Relay) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Relay) run(ctx context.Context) {
+	defer close(r.done)
+
+	pollInterval := r.cfg.PollInterval * time.Second
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.relayPending(ctx)
+		r.cleanup(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayPending process batches until pending events are drained or a batch fails. A batch claims only the oldest
+// pending event of every aggregate, so batches continue while any event is processed and bursts of changes
+// to one item are relayed in one poll instead of one event per poll.
+func (r *Relay) relayPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := r.repo.ProcessBatch(ctx, r.cfg.BatchSize, r.retry, r.publish)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.Errorf("Relay.relayPending.ProcessBatch: %s", err)
+			}
+			return
+		}
+		if processed == 0 {
+			return
+		}
+	}
+}
+
+// publish event to every sink, failure of any sink schedules retry of the event for all of them
+func (r *Relay) publish(ctx context.Context, event *models.OutboxEvent) error {
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			r.logger.Warnf("Outbox sink failed, Sink: %s, EventID: %s, Event: %s, Attempts: %d, Error: %s",
+				sink.Name(), event.EventID, event.EventType, event.Attempts+1, err)
+			return errors.Wrapf(err, "sink %s", sink.Name())
+		}
+	}
+	return nil
+}
+
+// cleanup delete processed events older than retention period
+func (r *Relay) cleanup(ctx context.Context) {
+	if r.cfg.Retention <= 0 || time.Since(r.lastCleanup) < cleanupInterval {
+		return
+	}
+	r.lastCleanup = time.Now()
+
+	deleted, err := r.repo.DeleteProcessed(ctx, time.Now().Add(-r.cfg.Retention*time.Hour))
+	if err != nil {
+		r.logger.Errorf("Relay.cleanup.DeleteProcessed: %s", err)
+		return
+	}
+	if deleted > 0 {
+		r.logger.Infof("Outbox cleanup, Deleted: %d", deleted)
+	}
+}
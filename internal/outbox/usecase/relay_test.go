@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/outbox"
+	"github.com/Dostonlv/task-del/internal/outbox/mock"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelay_RelayPending(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	logSink := mock.NewMockSink(ctrl)
+	webhooksSink := mock.NewMockSink(ctrl)
+	cfg := &config.Config{Outbox: config.OutboxConfig{BatchSize: 2, MaxAttempts: 5, BackoffBase: 2, BackoffMax: 60}}
+	relay := NewRelay(cfg, mockRepo, []outbox.Sink{logSink, webhooksSink}, logger.NewApiLogger(nil))
+	retry := outbox.RetryPolicy{MaxAttempts: 5, BackoffBase: 2 * time.Second, BackoffMax: time.Minute}
+
+	first := &models.OutboxEvent{ID: 1, EventID: uuid.New(), EventType: models.EventNewsCreated}
+	second := &models.OutboxEvent{ID: 2, EventID: uuid.New(), EventType: models.EventNewsUpdated}
+	third := &models.OutboxEvent{ID: 3, EventID: uuid.New(), EventType: models.EventNewsDeleted}
+
+	// batches continue until nothing is processed, events reach every sink in order
+	gomock.InOrder(
+		mockRepo.EXPECT().ProcessBatch(gomock.Any(), 2, retry, gomock.Any()).DoAndReturn(func(ctx context.Context, limit int, retry outbox.RetryPolicy, handler outbox.Handler) (int, error) {
+			require.NoError(t, handler(ctx, first))
+			require.NoError(t, handler(ctx, second))
+			return 2, nil
+		}),
+		mockRepo.EXPECT().ProcessBatch(gomock.Any(), 2, retry, gomock.Any()).DoAndReturn(func(ctx context.Context, limit int, retry outbox.RetryPolicy, handler outbox.Handler) (int, error) {
+			require.NoError(t, handler(ctx, third))
+			return 1, nil
+		}),
+		mockRepo.EXPECT().ProcessBatch(gomock.Any(), 2, retry, gomock.Any()).Return(0, nil),
+	)
+	gomock.InOrder(
+		logSink.EXPECT().Publish(gomock.Any(), first).Return(nil),
+		webhooksSink.EXPECT().Publish(gomock.Any(), first).Return(nil),
+		logSink.EXPECT().Publish(gomock.Any(), second).Return(nil),
+		webhooksSink.EXPECT().Publish(gomock.Any(), second).Return(nil),
+		logSink.EXPECT().Publish(gomock.Any(), third).Return(nil),
+		webhooksSink.EXPECT().Publish(gomock.Any(), third).Return(nil),
+	)
+
+	relay.relayPending(context.Background())
+}
+
+func TestRelay_RelayPendingSameAggregate(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	sink := mock.NewMockSink(ctrl)
+	cfg := &config.Config{Outbox: config.OutboxConfig{BatchSize: 100}}
+	relay := NewRelay(cfg, mockRepo, []outbox.Sink{sink}, logger.NewApiLogger(nil))
+
+	// burst of updates of one item, every batch claims only its oldest pending event
+	newsID := uuid.New()
+	pending := make([]*models.OutboxEvent, 0, 5)
+	for i := 1; i <= 5; i++ {
+		pending = append(pending, &models.OutboxEvent{ID: int64(i), EventID: uuid.New(), AggregateID: newsID, EventType: models.EventNewsUpdated})
+	}
+	mockRepo.EXPECT().ProcessBatch(gomock.Any(), 100, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, limit int, retry outbox.RetryPolicy, handler outbox.Handler) (int, error) {
+		if len(pending) == 0 {
+			return 0, nil
+		}
+		event := pending[0]
+		pending = pending[1:]
+		return 1, handler(ctx, event)
+	}).Times(6)
+	sink.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).Times(5)
+
+	// whole burst is relayed in one poll
+	relay.relayPending(context.Background())
+	require.Empty(t, pending)
+}
+
+func TestRelay_PublishSinkError(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	failingSink := mock.NewMockSink(ctrl)
+	nextSink := mock.NewMockSink(ctrl)
+	relay := NewRelay(&config.Config{}, mock.NewMockRepository(ctrl), []outbox.Sink{failingSink, nextSink}, logger.NewApiLogger(nil))
+
+	event := &models.OutboxEvent{ID: 1, EventID: uuid.New(), EventType: models.EventBlogCreated}
+
+	// failed sink schedules retry of event, following sinks are not called
+	failingSink.EXPECT().Publish(gomock.Any(), event).Return(errors.New("unavailable"))
+	failingSink.EXPECT().Name().Return("broker").AnyTimes()
+
+	err := relay.publish(context.Background(), event)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "sink broker")
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/audit"
+	blogsGrpc "github.com/Dostonlv/task-del/internal/blogs/delivery/grpc"
+	newsGrpc "github.com/Dostonlv/task-del/internal/news/delivery/grpc"
+	"github.com/Dostonlv/task-del/pkg/lifecycle"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	blogsService "github.com/Dostonlv/task-del/proto/blogs"
+	newsService "github.com/Dostonlv/task-del/proto/news"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDMetadataKey = "x-request-id"
+
+// grpcComponent gRPC server sharing use case instances with REST handlers
+func (s *Server) grpcComponent() lifecycle.Component {
+	server := grpc.NewServer(
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle: s.cfg.GRPC.MaxConnectionIdle * time.Second,
+			MaxConnectionAge:  s.cfg.GRPC.MaxConnectionAge * time.Second,
+		}),
+		grpc.ChainUnaryInterceptor(s.grpcRecoveryInterceptor, s.grpcLoggerInterceptor),
+	)
+
+	blogsService.RegisterBlogsServiceServer(server, blogsGrpc.NewBlogsMicroservice(s.cfg, s.blogsUC, s.logger))
+	newsService.RegisterNewsServiceServer(server, newsGrpc.NewNewsMicroservice(s.cfg, s.newsUC, s.logger))
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	for _, service := range []string{"", blogsService.BlogsService_ServiceDesc.ServiceName, newsService.NewsService_ServiceDesc.ServiceName} {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	if s.cfg.GRPC.Reflection {
+		reflection.Register(server)
+	}
+
+	return lifecycle.Component{
+		Name: "grpc",
+		Start: func(ctx context.Context) error {
+			ln, err := net.Listen("tcp", s.cfg.GRPC.Port)
+			if err != nil {
+				return err
+			}
+			s.logger.Infof("Server grpc is listening on PORT: %s", s.cfg.GRPC.Port)
+
+			go func() {
+				if err := server.Serve(ln); err != nil {
+					s.lifecycle.Fail("grpc", err)
+				}
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			healthServer.Shutdown()
+
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				// drain timeout exceeded, drop remaining streams
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+// grpcActorName audit actor of bearer token in authorization metadata, same as for REST requests
+func (s *Server) grpcActorName(md metadata.MD) string {
+	var token string
+	if values := md.Get("authorization"); len(values) > 0 {
+		token, _ = strings.CutPrefix(values[0], "Bearer ")
+	}
+	if token == "" {
+		return audit.ActorAnonymous
+	}
+
+	adminToken := s.cfg.Server.AdminToken
+	if adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) == 1 {
+		return audit.AdminActor(adminToken)
+	}
+	if name, ok := audit.UserActor(token, s.cfg.Server.JwtSecretKey); ok {
+		return name
+	}
+	return audit.ActorAnonymous
+}
+
+// grpcLoggerInterceptor store request scoped logger and audit actor in context and log every call
+func (s *Server) grpcLoggerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	requestID := uuid.NewString()
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(requestIDMetadataKey); len(ids) > 0 && ids[0] != "" {
+		requestID = ids[0]
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDMetadataKey, requestID))
+
+	reqLogger := s.logger.With("request_id", requestID, "method", info.FullMethod)
+	ctx = logger.ContextWithLogger(ctx, reqLogger)
+
+	actor := audit.Actor{Name: s.grpcActorName(md), RequestID: requestID}
+	if p, ok := peer.FromContext(ctx); ok {
+		actor.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(actor.IP); err == nil {
+			actor.IP = host
+		}
+	}
+	ctx = audit.WithActor(ctx, actor)
+
+	res, err := handler(ctx, req)
+
+	reqLogger.Infof("Request completed, Code: %s, Latency: %s", status.Code(err), time.Since(start))
+	return res, err
+}
+
+// grpcRecoveryInterceptor convert handler panics into internal errors
+func (s *Server) grpcRecoveryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Errorf("gRPC panic recovered, Method: %s, Panic: %v", info.FullMethod, r)
+			err = status.Error(codes.Internal, "internal Server Error")
+		}
+	}()
+
+	return handler(ctx, req)
+}
@@ -2,16 +2,32 @@ package server
 
 import (
 	"github.com/Dostonlv/task-del/docs"
-	blogsHttp "github.com/Dostonlv/task-del/internal/blogs/delivery/http"
+	auditHttp "github.com/Dostonlv/task-del/internal/audit/delivery/http"
+	auditRepository "github.com/Dostonlv/task-del/internal/audit/repository"
+	auditUseCase "github.com/Dostonlv/task-del/internal/audit/usecase"
+	"github.com/Dostonlv/task-del/internal/blogs"
+	graphqlHttp "github.com/Dostonlv/task-del/internal/graphql/delivery/http"
+	graphqlSchema "github.com/Dostonlv/task-del/internal/graphql/schema"
+	healthHttp "github.com/Dostonlv/task-del/internal/health/delivery/http"
+	healthUseCase "github.com/Dostonlv/task-del/internal/health/usecase"
+	"github.com/Dostonlv/task-del/internal/idempotency"
+	idempotencyRepository "github.com/Dostonlv/task-del/internal/idempotency/repository"
+	idempotencyUseCase "github.com/Dostonlv/task-del/internal/idempotency/usecase"
+	loggingHttp "github.com/Dostonlv/task-del/internal/logging/delivery/http"
+	apiMiddlewares "github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/Dostonlv/task-del/internal/news"
 	newsHttp "github.com/Dostonlv/task-del/internal/news/delivery/http"
+	"github.com/Dostonlv/task-del/internal/news/stream"
+	webhooksHttp "github.com/Dostonlv/task-del/internal/webhooks/delivery/http"
+	webhooksRepository "github.com/Dostonlv/task-del/internal/webhooks/repository"
+	webhooksUseCase "github.com/Dostonlv/task-del/internal/webhooks/usecase"
+	"github.com/Dostonlv/task-del/pkg/lifecycle"
 
-	"github.com/Dostonlv/task-del/internal/blogs/repository"
-	"github.com/Dostonlv/task-del/internal/blogs/usecase"
-	newRepo "github.com/Dostonlv/task-del/internal/news/repository"
-	newUseCase "github.com/Dostonlv/task-del/internal/news/usecase"
+	"github.com/Dostonlv/task-del/migrations"
 	"github.com/Dostonlv/task-del/pkg/csrf"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -23,32 +39,74 @@ import (
 // @Accept  json
 // @Produce  json
 // @Success 200 {string} model "{"status": "Healthy!"}"
-// @Router /health [get]
+// @Router /v1/health [get]
 // Map Server Handlers
 func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	// Init repositories
-	bRepo := repository.NewBlogsRepository(s.db)
-	nRepo := newRepo.NewNewsRepository(s.db)
-
-	commUC := usecase.NewBlogsUseCase(s.cfg, bRepo, s.logger)
-	newUC := newUseCase.NewNewsUseCase(nRepo, s.logger, s.cfg)
+	bRepo := blogs.NewRepository(s.db)
+	nRepo := news.NewRepository(s.db)
+	wRepo := webhooksRepository.NewWebhooksRepository(s.db)
+	aRepo := auditRepository.NewAuditRepository(s.db)
+
+	dispatcher := webhooksUseCase.NewDispatcher(s.cfg, wRepo, s.logger)
+	if s.cfg.Webhooks.Enabled {
+		s.AddWorker(lifecycle.Component{Name: "webhooks", Start: dispatcher.Start, Stop: dispatcher.Stop})
+	}
+	webhooksUC := webhooksUseCase.NewWebhooksUseCase(s.cfg, wRepo, dispatcher, s.logger)
+	if s.cfg.Stream.Enabled {
+		s.stream = stream.NewHub(s.cfg)
+		s.addStreamListener()
+	}
+	if err := s.addOutboxRelay(dispatcher); err != nil {
+		return err
+	}
+
+	s.blogsUC = blogs.NewUseCase(s.cfg, bRepo, s.logger)
+	s.newsUC = news.NewUseCase(s.cfg, nRepo, s.logger)
+	auditUC := auditUseCase.NewAuditUseCase(s.cfg, aRepo, s.logger)
+
+	s.health = healthUseCase.NewHealthUseCase(s.cfg, s.logger)
+	s.health.Register("postgres", healthUseCase.PostgresCheck(s.db))
+	s.health.Register("migrations", healthUseCase.MigrationsCheck(s.db, migrations.FS))
 
 	// Init handlers
-	blogHandlers := blogsHttp.NewBlogsHandlers(s.cfg, commUC, s.logger)
-	newsHandlers := newsHttp.NewNewsHandlers(s.cfg, newUC, s.logger)
+	blogHandlers := blogs.NewHandlers(s.cfg, s.blogsUC, s.logger)
+	newsHandlers := news.NewHandlers(s.cfg, s.newsUC, s.logger)
+	blogHandlersV2 := blogs.NewHandlersV2(s.cfg, s.blogsUC, s.logger)
+	newsHandlersV2 := news.NewHandlersV2(s.cfg, s.newsUC, s.logger)
+	healthHandlers := healthHttp.NewHealthHandlers(s.cfg, s.health, s.logger)
+	webhooksHandlers := webhooksHttp.NewWebhooksHandlers(s.cfg, webhooksUC, s.logger)
+	loggingHandlers := loggingHttp.NewLoggingHandlers(s.cfg, s.logger)
+	auditHandlers := auditHttp.NewAuditHandlers(s.cfg, auditUC, s.logger)
+
+	gqlSchema, err := graphqlSchema.NewSchema(s.blogsUC, s.newsUC)
+	if err != nil {
+		return err
+	}
+	graphqlHandlers := graphqlHttp.NewGraphQLHandlers(s.cfg, gqlSchema, s.newsUC, s.logger)
+
+	mw := apiMiddlewares.NewMiddlewareManager(s.cfg, s.logger)
+	e.IPExtractor = mw.IPExtractor()
+	s.watcher.Subscribe(mw.ApplyConfig)
+	if s.cfg.Idempotency.Enabled {
+		store := s.idempotencyStore()
+		mw.SetIdempotencyStore(store)
+		cleaner := idempotencyUseCase.NewCleaner(store, s.logger)
+		s.AddWorker(lifecycle.Component{Name: "idempotency", Start: cleaner.Start, Stop: cleaner.Stop})
+	}
 
 	docs.SwaggerInfo.Version = "1.0"
 	docs.SwaggerInfo.Title = "blog and news API"
 	docs.SwaggerInfo.Description = "blog and news REST API."
 	docs.SwaggerInfo.Version = "1.0"
-	docs.SwaggerInfo.BasePath = "/v1"
+	docs.SwaggerInfo.BasePath = "/"
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: []string{"*"},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, csrf.CSRFHeader},
+		AllowOriginFunc: mw.AllowOrigin,
+		AllowHeaders:    []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderXRequestID, csrf.CSRFHeader, apiMiddlewares.IdempotencyKeyHeader},
 	}))
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
 		StackSize:         1 << 10, // 1 KB
@@ -56,24 +114,60 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 		DisableStackAll:   true,
 	}))
 	e.Use(middleware.RequestID())
+	e.Use(mw.TracingMiddleware)
+	e.Use(mw.RequestLoggerMiddleware)
+	e.Use(mw.AuditMiddleware)
 
 	e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
 		Level: 5,
 		Skipper: func(c echo.Context) bool {
-			return strings.Contains(c.Request().URL.Path, "swagger")
+			return strings.Contains(c.Request().URL.Path, "swagger") || isStreamRoute(c)
+		},
+	}))
+	e.Use(middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+		Skipper: func(c echo.Context) bool {
+			return isStreamRoute(c) || isTransferRoute(c)
 		},
+		Timeout: s.cfg.Server.CtxDefaultTimeout * time.Second,
 	}))
 	e.Use(middleware.Secure())
-	e.Use(middleware.BodyLimit("2M"))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Skipper: isTransferRoute,
+		Limit:   "2M",
+	}))
+
+	healthHttp.MapHealthRoutes(e, healthHandlers)
 
-	v1 := e.Group("/v1")
+	v1 := e.Group("/v1", mw.RateLimitMiddleware)
 
 	health := v1.Group("/health")
 	blogGroup := v1.Group("/blogs")
 	newsGroup := v1.Group("/news")
+	webhooksGroup := v1.Group("/admin/webhooks")
+	logLevelGroup := v1.Group("/admin/log-level")
+	auditGroup := v1.Group("/admin/audit")
+
+	blogGroup.Use(mw.CacheControlMiddleware)
+	newsGroup.Use(mw.CacheControlMiddleware)
+
+	blogs.MapRoutes(blogGroup, blogHandlers, mw)
+	news.MapRoutes(newsGroup, newsHandlers, mw)
+	if s.stream != nil {
+		newsHttp.MapStreamRoutes(newsGroup, newsHttp.NewStreamHandlers(s.cfg, s.stream, s.logger))
+	}
+	graphqlHttp.MapGraphQLRoutes(v1, graphqlHandlers, s.cfg.Server.Mode)
+	webhooksHttp.MapWebhooksRoutes(webhooksGroup, webhooksHandlers, mw)
+	loggingHttp.MapLoggingRoutes(logLevelGroup, loggingHandlers, mw)
+	auditHttp.MapAuditRoutes(auditGroup, auditHandlers, mw)
 
-	blogsHttp.MapBlogsRoutes(blogGroup, blogHandlers)
-	newsHttp.MapNewsRoutes(newsGroup, newsHandlers)
+	// v1 is frozen, response changes go to v2
+	v2 := e.Group("/v2", mw.RateLimitMiddleware)
+
+	blogGroupV2 := v2.Group("/blogs", mw.CacheControlMiddleware)
+	newsGroupV2 := v2.Group("/news", mw.CacheControlMiddleware)
+
+	blogs.MapRoutesV2(blogGroupV2, blogHandlersV2, mw)
+	news.MapRoutesV2(newsGroupV2, newsHandlersV2, mw)
 
 	health.GET("", func(c echo.Context) error {
 		return c.JSON(http.StatusOK, map[string]string{"status": "healthy!"})
@@ -81,3 +175,25 @@ func (s *Server) MapHandlers(e *echo.Echo) error {
 
 	return nil
 }
+
+// idempotencyStore store of Idempotency-Key records selected by config
+func (s *Server) idempotencyStore() idempotency.Store {
+	if s.cfg.Idempotency.Store == "memory" {
+		return idempotencyRepository.NewMemoryStore()
+	}
+	return idempotencyRepository.NewPostgresStore(s.db)
+}
+
+// isStreamRoute long lived stream connections are neither compressed nor limited by request timeout
+func isStreamRoute(c echo.Context) bool {
+	return c.Path() == "/v1/news/stream"
+}
+
+// isTransferRoute bulk import and export run longer than API requests, import routes set their own body limit
+func isTransferRoute(c echo.Context) bool {
+	switch c.Path() {
+	case "/v1/blogs/export", "/v1/blogs/import", "/v1/news/export", "/v1/news/import", "/v1/admin/audit/export":
+		return true
+	}
+	return false
+}
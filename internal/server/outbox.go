@@ -0,0 +1,64 @@
+package server
+
+import (
+	"github.com/Dostonlv/task-del/internal/news/stream"
+	"github.com/Dostonlv/task-del/internal/outbox"
+	outboxRepository "github.com/Dostonlv/task-del/internal/outbox/repository"
+	"github.com/Dostonlv/task-del/internal/outbox/sinks"
+	outboxUseCase "github.com/Dostonlv/task-del/internal/outbox/usecase"
+	"github.com/Dostonlv/task-del/pkg/broker"
+	"github.com/Dostonlv/task-del/pkg/db/postgres"
+	"github.com/Dostonlv/task-del/pkg/lifecycle"
+	"github.com/pkg/errors"
+)
+
+// addOutboxRelay register relay worker publishing outbox events to configured sinks,
+// components are added so that relay is stopped before the sinks it publishes to
+func (s *Server) addOutboxRelay(webhooksSink outbox.Sink) error {
+	if !s.cfg.Outbox.Enabled {
+		return nil
+	}
+
+	relaySinks := make([]outbox.Sink, 0, len(s.cfg.Outbox.Sinks))
+	for _, name := range s.cfg.Outbox.Sinks {
+		switch name {
+		case "log":
+			relaySinks = append(relaySinks, sinks.NewLogSink(s.logger))
+		case "broker":
+			publisher, err := broker.NewNatsPublisher(s.cfg)
+			if err != nil {
+				return err
+			}
+			s.AddWorker(lifecycle.Component{Name: "broker", Stop: publisher.Close})
+			relaySinks = append(relaySinks, sinks.NewBrokerSink(publisher, s.cfg.Outbox.Broker.SubjectPrefix))
+		case "webhooks":
+			if !s.cfg.Webhooks.Enabled {
+				return errors.New("outbox webhooks sink requires webhooks to be enabled")
+			}
+			relaySinks = append(relaySinks, webhooksSink)
+		case "stream":
+			relaySinks = append(relaySinks, stream.NewNotifySink(s.db))
+		default:
+			return errors.Errorf("unknown outbox sink %q", name)
+		}
+	}
+
+	relay := outboxUseCase.NewRelay(s.cfg, outboxRepository.NewOutboxRepository(s.db), relaySinks, s.logger)
+	s.AddWorker(lifecycle.Component{Name: "outbox", Start: relay.Start, Stop: relay.Stop})
+
+	return nil
+}
+
+// addStreamListener register listener feeding stream hub with news events relayed by any instance
+func (s *Server) addStreamListener() {
+	connect := func() (stream.Conn, error) {
+		conn, err := postgres.NewListenConn(s.cfg)
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+	}
+
+	listener := stream.NewListener(s.stream, outboxRepository.NewOutboxRepository(s.db), connect, s.logger)
+	s.AddWorker(lifecycle.Component{Name: "stream listener", Start: listener.Start, Stop: listener.Stop})
+}
@@ -3,9 +3,15 @@ package server
 import (
 	"context"
 	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/blogs"
+	"github.com/Dostonlv/task-del/internal/health"
+	"github.com/Dostonlv/task-del/internal/news"
+	"github.com/Dostonlv/task-del/internal/news/stream"
+	"github.com/Dostonlv/task-del/pkg/lifecycle"
 	"github.com/Dostonlv/task-del/pkg/logger"
+	"net"
 	"net/http"
-	_ "net/http/pprof"
+	"net/http/pprof"
 	"os"
 	"os/signal"
 	"syscall"
@@ -13,60 +19,180 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
 const (
 	maxHeaderBytes = 1 << 20
-	ctxTimeout     = 5
 )
 
 // Server struct
 type Server struct {
-	echo   *echo.Echo
-	cfg    *config.Config
-	db     *sqlx.DB
-	logger logger.Logger
+	echo      *echo.Echo
+	cfg       *config.Config
+	db        *sqlx.DB
+	logger    logger.Logger
+	health    health.UseCase
+	blogsUC   blogs.UseCase
+	newsUC    news.UseCase
+	stream    *stream.Hub
+	watcher   *config.Watcher
+	lifecycle *lifecycle.Manager
+	workers   []lifecycle.Component
 }
 
-// NewServer constructor
-func NewServer(cfg *config.Config, db *sqlx.DB, logger logger.Logger) *Server {
-	return &Server{echo: echo.New(), cfg: cfg, db: db, logger: logger}
+// NewServer constructor, components subscribe to watcher for reloadable settings
+func NewServer(cfg *config.Config, db *sqlx.DB, logger logger.Logger, watcher *config.Watcher) *Server {
+	return &Server{
+		echo:      echo.New(),
+		cfg:       cfg,
+		db:        db,
+		logger:    logger,
+		watcher:   watcher,
+		lifecycle: lifecycle.NewManager(logger, cfg.Server.ShutdownTimeout*time.Second),
+	}
+}
+
+// AddWorker register background worker, workers are started after database and stopped before it
+func (s *Server) AddWorker(c lifecycle.Component) {
+	s.workers = append(s.workers, c)
 }
 
+// Run map handlers, start all components and block until shutdown signal or component failure
 func (s *Server) Run() error {
+	if err := s.MapHandlers(s.echo); err != nil {
+		return err
+	}
+
+	s.lifecycle.Append(s.postgresComponent())
+	for _, w := range s.workers {
+		s.lifecycle.Append(w)
+	}
+	if s.cfg.Server.Debug && s.cfg.Server.PprofPort != "" {
+		s.lifecycle.Append(s.debugComponent())
+	}
+	if s.cfg.GRPC.Enabled {
+		s.lifecycle.Append(s.grpcComponent())
+	}
+	s.lifecycle.Append(s.httpComponent())
+	if s.stream != nil {
+		s.lifecycle.Append(s.streamComponent())
+	}
+	s.lifecycle.Append(s.readinessComponent())
+	s.lifecycle.Append(lifecycle.Component{Name: "config watcher", Start: s.watcher.Start, Stop: s.watcher.Stop})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := s.lifecycle.Run(ctx); err != nil {
+		return err
+	}
+
+	s.logger.Info("Server Exited Properly")
+	return nil
+}
+
+func (s *Server) httpComponent() lifecycle.Component {
 	server := &http.Server{
 		Addr:           s.cfg.Server.Port,
+		Handler:        s.echo,
 		ReadTimeout:    time.Second * s.cfg.Server.ReadTimeout,
 		WriteTimeout:   time.Second * s.cfg.Server.WriteTimeout,
 		MaxHeaderBytes: maxHeaderBytes,
 	}
+	s.echo.Server = server
+
+	return s.listenerComponent("http", server)
+}
+
+func (s *Server) debugComponent() lifecycle.Component {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	server := &http.Server{
+		Addr:           s.cfg.Server.PprofPort,
+		Handler:        mux,
+		MaxHeaderBytes: maxHeaderBytes,
+	}
 
-	go func() {
-		s.logger.Infof("Server is listening on PORT: %s", s.cfg.Server.Port)
-		if err := s.echo.StartServer(server); err != nil {
-			s.logger.Fatalf("Error starting Server: ", err)
-		}
-	}()
+	return s.listenerComponent("debug", server)
+}
 
-	go func() {
-		s.logger.Infof("Starting Debug Server on PORT: %s", s.cfg.Server.PprofPort)
-		if err := http.ListenAndServe(s.cfg.Server.PprofPort, http.DefaultServeMux); err != nil {
-			s.logger.Errorf("Error PPROF ListenAndServe: %s", err)
-		}
-	}()
+// listenerComponent bind listener on start so address errors are reported synchronously, serve in background
+func (s *Server) listenerComponent(name string, server *http.Server) lifecycle.Component {
+	return lifecycle.Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			ln, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return err
+			}
+			s.logger.Infof("Server %s is listening on PORT: %s", name, server.Addr)
 
-	if err := s.MapHandlers(s.echo); err != nil {
-		return err
+			go func() {
+				if err := server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					s.lifecycle.Fail(name, err)
+				}
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			if err := server.Shutdown(ctx); err != nil {
+				// drain timeout exceeded, drop remaining connections
+				_ = server.Close()
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+// readinessComponent is stopped first, so readiness probe fails before connections are drained.
+// Stop waits DrainDelay for load balancers to notice failing probe and stop sending new requests.
+func (s *Server) readinessComponent() lifecycle.Component {
+	return lifecycle.Component{
+		Name: "readiness",
+		Stop: func(ctx context.Context) error {
+			s.health.SetShuttingDown()
+			return waitDrainDelay(ctx, s.cfg.Server.DrainDelay*time.Second)
+		},
 	}
+}
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+// waitDrainDelay wait delay or until ctx is done, delay is part of the shutdown timeout
+func waitDrainDelay(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
 
-	<-quit
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
-	ctx, shutdown := context.WithTimeout(context.Background(), ctxTimeout*time.Second)
-	defer shutdown()
+// streamComponent is stopped before http server, otherwise open streams would hold its shutdown until timeout
+func (s *Server) streamComponent() lifecycle.Component {
+	return lifecycle.Component{
+		Name: "stream",
+		Stop: s.stream.Close,
+	}
+}
 
-	s.logger.Info("Server Exited Properly")
-	return s.echo.Server.Shutdown(ctx)
+// postgresComponent is stopped last, after in-flight requests and workers are done
+func (s *Server) postgresComponent() lifecycle.Component {
+	return lifecycle.Component{
+		Name: "postgres",
+		Stop: func(ctx context.Context) error {
+			return s.db.Close()
+		},
+	}
 }
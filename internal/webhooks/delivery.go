@@ -1,12 +1,14 @@
-package blogs
+package webhooks
 
 import "github.com/labstack/echo/v4"
 
-// Handlers Blogs HTTP Handlers interface
+// Handlers Webhooks HTTP Handlers interface
 type Handlers interface {
 	Create() echo.HandlerFunc
 	Update() echo.HandlerFunc
 	Delete() echo.HandlerFunc
 	GetByID() echo.HandlerFunc
 	GetAll() echo.HandlerFunc
+	GetDeliveries() echo.HandlerFunc
+	Redeliver() echo.HandlerFunc
 }
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/webhooks"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// webhooks handlers
+type webhooksHandlers struct {
+	cfg        *config.Config
+	webhooksUC webhooks.UseCase
+	logger     logger.Logger
+}
+
+// NewWebhooksHandlers Webhooks handlers constructor
+func NewWebhooksHandlers(cfg *config.Config, webhooksUC webhooks.UseCase, logger logger.Logger) webhooks.Handlers {
+	return &webhooksHandlers{cfg: cfg, webhooksUC: webhooksUC, logger: logger}
+}
+
+// Create
+// @Summary Create webhook
+// @Description subscribe endpoint to content lifecycle events, generated secret is returned only in this response
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param body body models.WebhookSwagger true "body"
+// @Success 201 {object} models.Webhook
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 401 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/webhooks [post]
+func (h *webhooksHandlers) Create() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		webhook := &models.Webhook{}
+		if err := utils.ReadRequest(c, webhook); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		createdWebhook, err := h.webhooksUC.Create(c.Request().Context(), webhook)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusCreated, createdWebhook)
+	}
+}
+
+// Update
+// @Summary Update webhook
+// @Description replace url, events and active flag, activating disabled webhook resets its failures counter
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "webhook ID"
+// @Param body body models.WebhookSwagger true "body"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} httpErrors.RestError "validation failed, fields lists every failed rule"
+// @Failure 404 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/webhooks/{id} [put]
+func (h *webhooksHandlers) Update() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		webhookID, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		webhook := &models.Webhook{}
+		if err = utils.ReadRequest(c, webhook); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+		webhook.ID = webhookID
+
+		updatedWebhook, err := h.webhooksUC.Update(c.Request().Context(), webhook)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, updatedWebhook)
+	}
+}
+
+// Delete
+// @Summary Delete webhook
+// @Description delete webhook together with its delivery log
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "webhook ID"
+// @Success 200 {string} string "ok"
+// @Failure 404 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/webhooks/{id} [delete]
+func (h *webhooksHandlers) Delete() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		webhookID, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		if err = h.webhooksUC.Delete(c.Request().Context(), webhookID); err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+// GetByID
+// @Summary Get webhook by ID
+// @Description get webhook by ID
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "webhook ID"
+// @Success 200 {object} models.Webhook
+// @Failure 404 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/webhooks/{id} [get]
+func (h *webhooksHandlers) GetByID() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		webhookID, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		webhook, err := h.webhooksUC.GetByID(c.Request().Context(), webhookID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, webhook)
+	}
+}
+
+// GetAll
+// @Summary Get all webhooks
+// @Description get all webhooks with pagination
+// @Tags Webhooks
+// @Produce json
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.WebhooksList
+// @Failure 400 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/webhooks [get]
+func (h *webhooksHandlers) GetAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		webhooksList, err := h.webhooksUC.GetAll(c.Request().Context(), pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, webhooksList)
+	}
+}
+
+// GetDeliveries
+// @Summary Get webhook delivery log
+// @Description get deliveries of webhook, newest first
+// @Tags Webhooks
+// @Produce json
+// @Param id path string true "webhook ID"
+// @Param page query int false "page number" Format(page)
+// @Param size query int false "number of elements per page" Format(size)
+// @Success 200 {object} models.WebhookDeliveriesList
+// @Failure 404 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/webhooks/{id}/deliveries [get]
+func (h *webhooksHandlers) GetDeliveries() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		webhookID, err := utils.ParseID(c.Param("id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		pq, err := utils.GetPaginationFromCtx(c)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		deliveries, err := h.webhooksUC.GetDeliveries(c.Request().Context(), webhookID, pq)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusOK, deliveries)
+	}
+}
+
+// Redeliver
+// @Summary Redeliver webhook event
+// @Description schedule new delivery of the same event payload
+// @Tags Webhooks
+// @Produce json
+// @Param delivery_id path string true "delivery ID"
+// @Success 202 {object} models.WebhookDelivery
+// @Failure 400 {object} httpErrors.RestError
+// @Failure 404 {object} httpErrors.RestError
+// @Security BearerAuth
+// @Router /v1/admin/webhooks/deliveries/{delivery_id}/redeliver [post]
+func (h *webhooksHandlers) Redeliver() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		deliveryID, err := utils.ParseID(c.Param("delivery_id"))
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		delivery, err := h.webhooksUC.Redeliver(c.Request().Context(), deliveryID)
+		if err != nil {
+			return utils.ErrResponseWithLog(c, h.logger, err)
+		}
+
+		return c.JSON(http.StatusAccepted, delivery)
+	}
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/Dostonlv/task-del/internal/middleware"
+	"github.com/Dostonlv/task-del/internal/webhooks"
+	"github.com/labstack/echo/v4"
+)
+
+// Map webhooks admin routes
+func MapWebhooksRoutes(webhooksGroup *echo.Group, h webhooks.Handlers, mw *middleware.MiddlewareManager) {
+	webhooksGroup.Use(mw.AdminAuthMiddleware)
+	webhooksGroup.POST("", h.Create())
+	webhooksGroup.GET("", h.GetAll())
+	webhooksGroup.DELETE("/:id", h.Delete())
+	webhooksGroup.PUT("/:id", h.Update())
+	webhooksGroup.GET("/:id", h.GetByID())
+	webhooksGroup.GET("/:id/deliveries", h.GetDeliveries())
+	webhooksGroup.POST("/deliveries/:delivery_id/redeliver", h.Redeliver())
+}
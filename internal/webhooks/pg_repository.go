@@ -0,0 +1,28 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// Repository Webhooks repository interface
+type Repository interface {
+	Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	Update(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	Delete(ctx context.Context, webhookID uuid.UUID) error
+	GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error)
+	GetAll(ctx context.Context, query *utils.PaginationQuery) (*models.WebhooksList, error)
+	RecordSuccess(ctx context.Context, webhookID uuid.UUID) error
+	RecordFailure(ctx context.Context, webhookID uuid.UUID, disableAfter int) (bool, error)
+
+	CreateDeliveries(ctx context.Context, event *models.OutboxEvent, payload []byte) (int64, error)
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, query *utils.PaginationQuery) (*models.WebhookDeliveriesList, error)
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+}
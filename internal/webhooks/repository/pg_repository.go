@@ -0,0 +1,357 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/webhooks"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// webhooks Repository
+type webhooksRepo struct {
+	db *sqlx.DB
+}
+
+// NewWebhooksRepository Webhooks Repository constructor
+func NewWebhooksRepository(db *sqlx.DB) webhooks.Repository {
+	return &webhooksRepo{db: db}
+}
+
+// Create webhook
+func (r *webhooksRepo) Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	createWebhook := `INSERT INTO webhooks (id, url, secret, events, active) VALUES ($1, $2, $3, $4, $5) RETURNING *`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.Create", createWebhook)
+	defer span.End()
+
+	w := &models.Webhook{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createWebhook,
+		uuid.New(),
+		webhook.URL,
+		webhook.Secret,
+		webhook.Events,
+		webhook.Active,
+	).StructScan(w); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.Create.StructScan"))
+	}
+
+	return w, nil
+}
+
+// Update webhook, reactivated webhook starts with reset failure counter
+func (r *webhooksRepo) Update(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	updateWebhook := `UPDATE webhooks SET
+		url = $1,
+		events = $2,
+		failure_count = CASE WHEN $3 AND NOT active THEN 0 ELSE failure_count END,
+		disabled_at = CASE WHEN $3 THEN NULL ELSE COALESCE(disabled_at, CURRENT_TIMESTAMP) END,
+		active = $3,
+		updated_at = CURRENT_TIMESTAMP
+	WHERE id = $4
+	RETURNING *`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.Update", updateWebhook)
+	defer span.End()
+
+	w := &models.Webhook{}
+	if err := r.db.QueryRowxContext(ctx, updateWebhook, webhook.URL, webhook.Events, webhook.Active, webhook.ID).StructScan(w); err != nil {
+		return nil, tracing.RecordError(span, webhookNotFound(errors.Wrap(err, "webhooksRepo.Update.StructScan")))
+	}
+
+	return w, nil
+}
+
+// Delete webhook with its delivery log
+func (r *webhooksRepo) Delete(ctx context.Context, webhookID uuid.UUID) error {
+	deleteWebhook := `DELETE FROM webhooks WHERE id = $1`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.Delete", deleteWebhook)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, deleteWebhook, webhookID)
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.Delete.ExecContext"))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.Delete.RowsAffected"))
+	}
+	if rowsAffected == 0 {
+		return tracing.RecordError(span, webhookNotFound(errors.Wrap(sql.ErrNoRows, "webhooksRepo.Delete.rowsAffected")))
+	}
+
+	return nil
+}
+
+// GetByID webhook
+func (r *webhooksRepo) GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error) {
+	getWebhook := `SELECT id, url, secret, events, active, failure_count, disabled_at, created_at, updated_at
+	FROM webhooks WHERE id = $1`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.GetByID", getWebhook)
+	defer span.End()
+
+	w := &models.Webhook{}
+	if err := r.db.GetContext(ctx, w, getWebhook, webhookID); err != nil {
+		return nil, tracing.RecordError(span, webhookNotFound(errors.Wrap(err, "webhooksRepo.GetByID.GetContext")))
+	}
+
+	return w, nil
+}
+
+// GetAll webhooks
+func (r *webhooksRepo) GetAll(ctx context.Context, query *utils.PaginationQuery) (*models.WebhooksList, error) {
+	getTotalCount := `SELECT COUNT(id) FROM webhooks`
+	getWebhooks := `SELECT id, url, secret, events, active, failure_count, disabled_at, created_at, updated_at
+	FROM webhooks
+	ORDER BY created_at DESC OFFSET $1 LIMIT $2`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.GetAll", getWebhooks)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getTotalCount); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.GetAll.GetContext.totalCount"))
+	}
+
+	webhooksList := make([]*models.Webhook, 0, query.GetSize())
+	if totalCount > 0 {
+		if err := r.db.SelectContext(ctx, &webhooksList, getWebhooks, query.GetOffset(), query.GetLimit()); err != nil {
+			return nil, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.GetAll.SelectContext"))
+		}
+	}
+
+	return &models.WebhooksList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		Webhooks:   webhooksList,
+	}, nil
+}
+
+// CreateDeliveries create pending delivery of event for every active webhook subscribed to its type.
+// Webhooks that already have a delivery of the event are skipped, so relay retries do not duplicate deliveries.
+func (r *webhooksRepo) CreateDeliveries(ctx context.Context, event *models.OutboxEvent, payload []byte) (int64, error) {
+	createDeliveries := `INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload, status)
+	SELECT id, $1, $2, $3, 'pending'
+	FROM webhooks
+	WHERE active AND events @> ARRAY[$2]::TEXT[]
+	ON CONFLICT (webhook_id, event_id) WHERE redelivery_of IS NULL DO NOTHING`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.CreateDeliveries", createDeliveries)
+	defer span.End()
+
+	result, err := r.db.ExecContext(ctx, createDeliveries, event.EventID, event.EventType, payload)
+	if err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.CreateDeliveries.ExecContext"))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.CreateDeliveries.RowsAffected"))
+	}
+
+	return rowsAffected, nil
+}
+
+// RecordSuccess reset consecutive failures counter
+func (r *webhooksRepo) RecordSuccess(ctx context.Context, webhookID uuid.UUID) error {
+	recordSuccess := `UPDATE webhooks SET failure_count = 0 WHERE id = $1 AND failure_count <> 0`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.RecordSuccess", recordSuccess)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(ctx, recordSuccess, webhookID); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.RecordSuccess.ExecContext"))
+	}
+
+	return nil
+}
+
+// RecordFailure increment consecutive failures counter and disable webhook once it reaches disableAfter (zero never disables),
+// returns true if webhook has been disabled by this call
+func (r *webhooksRepo) RecordFailure(ctx context.Context, webhookID uuid.UUID, disableAfter int) (bool, error) {
+	recordFailure := `UPDATE webhooks SET
+		failure_count = failure_count + 1,
+		active = active AND ($1 <= 0 OR failure_count + 1 < $1),
+		disabled_at = CASE WHEN active AND $1 > 0 AND failure_count + 1 >= $1 THEN CURRENT_TIMESTAMP ELSE disabled_at END
+	WHERE id = $2
+	RETURNING active, failure_count`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.RecordFailure", recordFailure)
+	defer span.End()
+
+	var (
+		active       bool
+		failureCount int
+	)
+	if err := r.db.QueryRowxContext(ctx, recordFailure, disableAfter, webhookID).Scan(&active, &failureCount); err != nil {
+		return false, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.RecordFailure.Scan"))
+	}
+
+	return !active && failureCount == disableAfter, nil
+}
+
+// CreateDelivery webhook delivery
+func (r *webhooksRepo) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	createDelivery := `INSERT INTO webhook_deliveries (id, webhook_id, event_id, event, payload, status, next_attempt_at, redelivery_of)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING *`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.CreateDelivery", createDelivery)
+	defer span.End()
+
+	d := &models.WebhookDelivery{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createDelivery,
+		uuid.New(),
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.Event,
+		[]byte(delivery.Payload),
+		models.DeliveryStatusPending,
+		delivery.NextAttemptAt,
+		delivery.RedeliveryOf,
+	).StructScan(d); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.CreateDelivery.StructScan"))
+	}
+
+	return d, nil
+}
+
+// UpdateDelivery store delivery attempt result
+func (r *webhooksRepo) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	updateDelivery := `UPDATE webhook_deliveries SET
+		status = $1,
+		attempts = $2,
+		response_status = $3,
+		error = $4,
+		next_attempt_at = $5,
+		delivered_at = $6
+	WHERE id = $7`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.UpdateDelivery", updateDelivery)
+	defer span.End()
+
+	if _, err := r.db.ExecContext(
+		ctx,
+		updateDelivery,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseStatus,
+		delivery.Error,
+		delivery.NextAttemptAt,
+		delivery.DeliveredAt,
+		delivery.ID,
+	); err != nil {
+		return tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.UpdateDelivery.ExecContext"))
+	}
+
+	return nil
+}
+
+// GetDeliveryByID webhook delivery
+func (r *webhooksRepo) GetDeliveryByID(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	getDelivery := `SELECT id, webhook_id, event_id, event, payload, status, attempts, response_status, error,
+		next_attempt_at, delivered_at, created_at
+	FROM webhook_deliveries WHERE id = $1`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.GetDeliveryByID", getDelivery)
+	defer span.End()
+
+	d := &models.WebhookDelivery{}
+	if err := r.db.GetContext(ctx, d, getDelivery, deliveryID); err != nil {
+		return nil, tracing.RecordError(span, notFound(errors.Wrap(err, "webhooksRepo.GetDeliveryByID.GetContext"), appErrors.CodeDeliveryNotFound, "delivery not found"))
+	}
+
+	return d, nil
+}
+
+// GetDeliveries delivery log of webhook, newest first
+func (r *webhooksRepo) GetDeliveries(ctx context.Context, webhookID uuid.UUID, query *utils.PaginationQuery) (*models.WebhookDeliveriesList, error) {
+	getTotalCount := `SELECT COUNT(id) FROM webhook_deliveries WHERE webhook_id = $1`
+	getDeliveries := `SELECT id, webhook_id, event_id, event, payload, status, attempts, response_status, error,
+		next_attempt_at, delivered_at, created_at
+	FROM webhook_deliveries
+	WHERE webhook_id = $1
+	ORDER BY created_at DESC OFFSET $2 LIMIT $3`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.GetDeliveries", getDeliveries)
+	defer span.End()
+
+	var totalCount int
+	if err := r.db.GetContext(ctx, &totalCount, getTotalCount, webhookID); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.GetDeliveries.GetContext.totalCount"))
+	}
+
+	deliveries := make([]*models.WebhookDelivery, 0, query.GetSize())
+	if totalCount > 0 {
+		if err := r.db.SelectContext(ctx, &deliveries, getDeliveries, webhookID, query.GetOffset(), query.GetLimit()); err != nil {
+			return nil, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.GetDeliveries.SelectContext"))
+		}
+	}
+
+	return &models.WebhookDeliveriesList{
+		TotalCount: totalCount,
+		TotalPages: utils.GetTotalPages(totalCount, query.GetSize()),
+		Page:       query.GetPage(),
+		Size:       query.GetSize(),
+		HasMore:    utils.GetHasMore(query.GetPage(), totalCount, query.GetSize()),
+		Deliveries: deliveries,
+	}, nil
+}
+
+// ClaimDueDeliveries lease pending deliveries whose next attempt is due.
+// Leased deliveries are hidden from other dispatchers until lease expires, so every attempt is made by a single instance.
+func (r *webhooksRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	claimDeliveries := `UPDATE webhook_deliveries SET next_attempt_at = CURRENT_TIMESTAMP + $1 * INTERVAL '1 millisecond'
+	WHERE id IN (
+		SELECT id FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+		ORDER BY next_attempt_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING *`
+
+	ctx, span := tracing.StartSQLSpan(ctx, "webhooksRepo.ClaimDueDeliveries", claimDeliveries)
+	defer span.End()
+
+	deliveries := make([]*models.WebhookDelivery, 0, limit)
+	if err := r.db.SelectContext(ctx, &deliveries, claimDeliveries, lease.Milliseconds(), limit); err != nil {
+		return nil, tracing.RecordError(span, errors.Wrap(err, "webhooksRepo.ClaimDueDeliveries.SelectContext"))
+	}
+
+	if len(deliveries) > 0 {
+		logger.FromContext(ctx).Debugf("webhooksRepo.ClaimDueDeliveries, Claimed: %d", len(deliveries))
+	}
+
+	return deliveries, nil
+}
+
+// webhookNotFound catalogue error of missing webhook, other errors are returned as is
+func webhookNotFound(err error) error {
+	return notFound(err, appErrors.CodeWebhookNotFound, "webhook not found")
+}
+
+func notFound(err error, code appErrors.Code, message string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return appErrors.Wrap(err, code, message)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooksRepo_RecordFailure(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewWebhooksRepository(sqlxDB)
+	webhookID := uuid.New()
+
+	recordFailure := `UPDATE webhooks SET
+		failure_count = failure_count + 1,
+		active = active AND ($1 <= 0 OR failure_count + 1 < $1),
+		disabled_at = CASE WHEN active AND $1 > 0 AND failure_count + 1 >= $1 THEN CURRENT_TIMESTAMP ELSE disabled_at END
+	WHERE id = $2
+	RETURNING active, failure_count`
+
+	// failure below threshold keeps webhook active
+	t.Run("RecordFailure", func(t *testing.T) {
+		mock.ExpectQuery(recordFailure).WithArgs(3, webhookID).
+			WillReturnRows(sqlmock.NewRows([]string{"active", "failure_count"}).AddRow(true, 1))
+
+		disabled, err := repo.RecordFailure(context.Background(), webhookID, 3)
+		require.NoError(t, err)
+		require.False(t, disabled)
+	})
+
+	// failure reaching threshold disables webhook
+	t.Run("RecordFailure Disable", func(t *testing.T) {
+		mock.ExpectQuery(recordFailure).WithArgs(3, webhookID).
+			WillReturnRows(sqlmock.NewRows([]string{"active", "failure_count"}).AddRow(false, 3))
+
+		disabled, err := repo.RecordFailure(context.Background(), webhookID, 3)
+		require.NoError(t, err)
+		require.True(t, disabled)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhooksRepo_ClaimDueDeliveries(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewWebhooksRepository(sqlxDB)
+
+	delivery := &models.WebhookDelivery{ID: uuid.New(), WebhookID: uuid.New(), EventID: uuid.New(), Event: models.EventNewsCreated}
+	rows := sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event", "payload", "status", "attempts"}).
+		AddRow(delivery.ID, delivery.WebhookID, delivery.EventID, delivery.Event, []byte(`{}`), models.DeliveryStatusPending, 0)
+
+	mock.ExpectQuery(`UPDATE webhook_deliveries SET next_attempt_at = CURRENT_TIMESTAMP + $1 * INTERVAL '1 millisecond'
+	WHERE id IN (
+		SELECT id FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+		ORDER BY next_attempt_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING *`).WithArgs(int64(80000), 10).WillReturnRows(rows)
+
+	deliveries, err := repo.ClaimDueDeliveries(context.Background(), 10, 80*time.Second)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, delivery.ID, deliveries[0].ID)
+	require.JSONEq(t, `{}`, string(deliveries[0].Payload))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhooksRepo_CreateDeliveries(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	repo := NewWebhooksRepository(sqlxDB)
+	event := &models.OutboxEvent{EventID: uuid.New(), EventType: models.EventBlogCreated}
+	payload := []byte(`{"id":"1"}`)
+
+	createDeliveries := `INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload, status)
+	SELECT id, $1, $2, $3, 'pending'
+	FROM webhooks
+	WHERE active AND events @> ARRAY[$2]::TEXT[]
+	ON CONFLICT (webhook_id, event_id) WHERE redelivery_of IS NULL DO NOTHING`
+
+	// first relay attempt creates deliveries
+	t.Run("CreateDeliveries", func(t *testing.T) {
+		mock.ExpectExec(createDeliveries).WithArgs(event.EventID, event.EventType, payload).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		created, err := repo.CreateDeliveries(context.Background(), event, payload)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), created)
+	})
+
+	// retried event conflicts with existing deliveries and creates none
+	t.Run("CreateDeliveries Retry", func(t *testing.T) {
+		mock.ExpectExec(createDeliveries).WithArgs(event.EventID, event.EventType, payload).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		created, err := repo.CreateDeliveries(context.Background(), event, payload)
+		require.NoError(t, err)
+		require.Zero(t, created)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
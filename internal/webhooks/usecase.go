@@ -0,0 +1,19 @@
+package webhooks
+
+import (
+	"context"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// webhooks use case interface
+type UseCase interface {
+	Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	Update(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error)
+	Delete(ctx context.Context, webhookID uuid.UUID) error
+	GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error)
+	GetAll(ctx context.Context, query *utils.PaginationQuery) (*models.WebhooksList, error)
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, query *utils.PaginationQuery) (*models.WebhookDeliveriesList, error)
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+}
@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/webhooks"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Headers sent with every webhook delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix  = "sha256="
+	maxResponseBytes = 64 << 10
+)
+
+var errWebhookDisabled = errors.New("webhook is disabled")
+
+// Sign HMAC-SHA256 signature of "timestamp.payload" with webhook secret, receivers recompute it to verify deliveries
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher outbox sink turning events into webhook deliveries, deliveries are sent in background
+// and failed ones are retried with exponential backoff
+type Dispatcher struct {
+	cfg    config.WebhooksConfig
+	repo   webhooks.Repository
+	client *http.Client
+	logger logger.Logger
+	now    func() time.Time
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewDispatcher webhooks dispatcher constructor
+func NewDispatcher(cfg *config.Config, repo webhooks.Repository, logger logger.Logger) *Dispatcher {
+	webhooksCfg := cfg.Webhooks
+	if webhooksCfg.Workers <= 0 {
+		webhooksCfg.Workers = 1
+	}
+	if webhooksCfg.BatchSize <= 0 {
+		webhooksCfg.BatchSize = 50
+	}
+	if webhooksCfg.MaxAttempts <= 0 {
+		webhooksCfg.MaxAttempts = 1
+	}
+
+	return &Dispatcher{
+		cfg:  webhooksCfg,
+		repo: repo,
+		client: &http.Client{
+			Timeout: webhooksCfg.Timeout * time.Second,
+			// redirects are reported as failures, endpoint url must be updated instead
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger,
+		now:    time.Now,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Name of outbox sink
+func (d *Dispatcher) Name() string {
+	return "webhooks"
+}
+
+// Publish persist delivery of outbox event for every subscribed webhook and wake delivery loop
+func (d *Dispatcher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "Dispatcher.Publish.Marshal")
+	}
+
+	created, err := d.repo.CreateDeliveries(ctx, event, payload)
+	if err != nil {
+		return err
+	}
+	if created > 0 {
+		d.Wake()
+	}
+
+	return nil
+}
+
+// Wake trigger delivery of due deliveries without waiting for next poll
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start run dispatcher loop in background
+func (d *Dispatcher) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go d.run(runCtx)
+	return nil
+}
+
+// Stop wait for in-flight deliveries, interrupted ones are retried once their lease expires
+func (d *Dispatcher) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+	d.cancel()
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	defer close(d.done)
+
+	pollInterval := d.cfg.PollInterval * time.Second
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+			d.deliverDue(ctx)
+		case <-ticker.C:
+			d.deliverDue(ctx)
+		}
+	}
+}
+
+// deliverDue claim due deliveries batch by batch and send them with bounded concurrency
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	// lease outlives single attempt, so crashed instance deliveries are picked up again
+	lease := 2*d.cfg.Timeout*time.Second + time.Minute
+
+	for ctx.Err() == nil {
+		deliveries, err := d.repo.ClaimDueDeliveries(ctx, d.cfg.BatchSize, lease)
+		if err != nil {
+			d.logger.Errorf("Dispatcher.deliverDue.ClaimDueDeliveries: %s", err)
+			return
+		}
+
+		webhooksCache := make(map[uuid.UUID]*models.Webhook)
+		var mu sync.Mutex
+		getWebhook := func(id uuid.UUID) (*models.Webhook, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if w, ok := webhooksCache[id]; ok {
+				return w, nil
+			}
+			w, err := d.repo.GetByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			webhooksCache[id] = w
+			return w, nil
+		}
+
+		sem := make(chan struct{}, d.cfg.Workers)
+		var wg sync.WaitGroup
+		for _, delivery := range deliveries {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(delivery *models.WebhookDelivery) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				d.deliver(ctx, delivery, getWebhook)
+			}(delivery)
+		}
+		wg.Wait()
+
+		if len(deliveries) < d.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// deliver make single delivery attempt and store its outcome
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery, getWebhook func(uuid.UUID) (*models.Webhook, error)) {
+	webhook, err := getWebhook(delivery.WebhookID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		d.logger.Errorf("Dispatcher.deliver.GetByID, WebhookID: %s, Error: %s", delivery.WebhookID, err)
+		return
+	}
+	if webhook == nil || !webhook.Active {
+		d.finish(ctx, delivery, 0, errWebhookDisabled, true)
+		return
+	}
+
+	status, sendErr := d.send(ctx, webhook, delivery)
+	if ctx.Err() != nil {
+		// dispatcher is stopping, delivery is retried once its lease expires
+		return
+	}
+
+	delivery.Attempts++
+	d.finish(ctx, delivery, status, sendErr, delivery.Attempts >= d.cfg.MaxAttempts)
+
+	if sendErr == nil {
+		if err := d.repo.RecordSuccess(ctx, webhook.ID); err != nil {
+			d.logger.Errorf("Dispatcher.deliver.RecordSuccess, WebhookID: %s, Error: %s", webhook.ID, err)
+		}
+		return
+	}
+
+	disabled, err := d.repo.RecordFailure(ctx, webhook.ID, d.cfg.DisableAfterFailures)
+	if err != nil {
+		d.logger.Errorf("Dispatcher.deliver.RecordFailure, WebhookID: %s, Error: %s", webhook.ID, err)
+		return
+	}
+	if disabled {
+		d.logger.Warnf("Webhook disabled after %d consecutive failures, WebhookID: %s, URL: %s", d.cfg.DisableAfterFailures, webhook.ID, webhook.URL)
+	}
+}
+
+// finish update delivery with attempt outcome, failed delivery is rescheduled unless it is final
+func (d *Dispatcher) finish(ctx context.Context, delivery *models.WebhookDelivery, status int, sendErr error, final bool) {
+	now := d.now()
+
+	delivery.ResponseStatus = nil
+	if status > 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.DeliveryStatusSucceeded
+		delivery.Error = nil
+		delivery.DeliveredAt = &now
+	case final:
+		msg := sendErr.Error()
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.Error = &msg
+	default:
+		msg := sendErr.Error()
+		delivery.Status = models.DeliveryStatusPending
+		delivery.Error = &msg
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+
+	if err := d.repo.UpdateDelivery(ctx, delivery); err != nil {
+		d.logger.Errorf("Dispatcher.finish.UpdateDelivery, DeliveryID: %s, Error: %s", delivery.ID, err)
+	}
+}
+
+// send post signed payload to webhook endpoint, any non 2xx response is a failure
+func (d *Dispatcher) send(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, errors.Wrap(err, "Dispatcher.send.NewRequest")
+	}
+
+	timestamp := strconv.FormatInt(d.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-del-webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "Dispatcher.send.Do")
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, errors.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// backoff delay before next attempt, doubles with every attempt up to BackoffMax
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	return utils.Backoff(attempts, d.cfg.BackoffBase*time.Second, d.cfg.BackoffMax*time.Second)
+}
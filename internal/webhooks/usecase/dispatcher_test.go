@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/webhooks/mock"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDispatcher(repo *mock.MockRepository, maxAttempts int, disableAfter int) *Dispatcher {
+	cfg := &config.Config{Webhooks: config.WebhooksConfig{
+		Enabled:              true,
+		Workers:              2,
+		BatchSize:            10,
+		Timeout:              5,
+		PollInterval:         1,
+		MaxAttempts:          maxAttempts,
+		BackoffBase:          10,
+		BackoffMax:           60,
+		DisableAfterFailures: disableAfter,
+	}}
+	return NewDispatcher(cfg, repo, logger.NewApiLogger(nil))
+}
+
+func TestDispatcher_DeliverSigned(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhook := &models.Webhook{ID: uuid.New(), Secret: "0123456789abcdef", Active: true}
+	payload := []byte(`{"type":"news.created"}`)
+	delivery := &models.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, Event: models.EventNewsCreated, Payload: payload}
+
+	// local receiver verifies signature of delivered payload
+	var received int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, Sign(webhook.Secret, r.Header.Get(HeaderTimestamp), body), r.Header.Get(HeaderSignature))
+		require.Equal(t, models.EventNewsCreated, r.Header.Get(HeaderEvent))
+		require.Equal(t, delivery.ID.String(), r.Header.Get(HeaderDelivery))
+		atomic.AddInt32(&received, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	webhook.URL = receiver.URL
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	dispatcher := newTestDispatcher(mockRepo, 3, 5)
+
+	mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), 10, gomock.Any()).Return([]*models.WebhookDelivery{delivery}, nil)
+	mockRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+	mockRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, d *models.WebhookDelivery) error {
+		require.Equal(t, models.DeliveryStatusSucceeded, d.Status)
+		require.Equal(t, 1, d.Attempts)
+		require.Equal(t, http.StatusNoContent, *d.ResponseStatus)
+		require.NotNil(t, d.DeliveredAt)
+		return nil
+	})
+	mockRepo.EXPECT().RecordSuccess(gomock.Any(), webhook.ID).Return(nil)
+
+	dispatcher.deliverDue(context.Background())
+	require.Equal(t, int32(1), atomic.LoadInt32(&received))
+}
+
+func TestDispatcher_RetryAndDisable(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	webhook := &models.Webhook{ID: uuid.New(), URL: receiver.URL, Secret: "0123456789abcdef", Active: true}
+	mockRepo := mock.NewMockRepository(ctrl)
+	dispatcher := newTestDispatcher(mockRepo, 3, 2)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dispatcher.now = func() time.Time { return now }
+
+	// failed attempt below max attempts is rescheduled with backoff
+	t.Run("Retry", func(t *testing.T) {
+		delivery := &models.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, Payload: []byte(`{}`), Attempts: 1}
+
+		mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), 10, gomock.Any()).Return([]*models.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+		mockRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, d *models.WebhookDelivery) error {
+			require.Equal(t, models.DeliveryStatusPending, d.Status)
+			require.Equal(t, 2, d.Attempts)
+			require.Equal(t, now.Add(20*time.Second), d.NextAttemptAt)
+			require.NotNil(t, d.Error)
+			return nil
+		})
+		mockRepo.EXPECT().RecordFailure(gomock.Any(), webhook.ID, 2).Return(false, nil)
+
+		dispatcher.deliverDue(context.Background())
+	})
+
+	// last attempt marks delivery failed and endpoint failing too often is disabled
+	t.Run("Final", func(t *testing.T) {
+		delivery := &models.WebhookDelivery{ID: uuid.New(), WebhookID: webhook.ID, Payload: []byte(`{}`), Attempts: 2}
+
+		mockRepo.EXPECT().ClaimDueDeliveries(gomock.Any(), 10, gomock.Any()).Return([]*models.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), webhook.ID).Return(webhook, nil)
+		mockRepo.EXPECT().UpdateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, d *models.WebhookDelivery) error {
+			require.Equal(t, models.DeliveryStatusFailed, d.Status)
+			require.Equal(t, http.StatusInternalServerError, *d.ResponseStatus)
+			return nil
+		})
+		mockRepo.EXPECT().RecordFailure(gomock.Any(), webhook.ID, 2).Return(true, nil)
+
+		dispatcher.deliverDue(context.Background())
+	})
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepository(ctrl)
+	dispatcher := newTestDispatcher(mockRepo, 3, 5)
+
+	event := &models.OutboxEvent{EventID: uuid.New(), AggregateID: uuid.New(), EventType: models.EventNewsDeleted, Payload: []byte(`{"id":"1"}`)}
+
+	// outbox event is persisted as deliveries of subscribed webhooks and wakes delivery loop
+	mockRepo.EXPECT().CreateDeliveries(gomock.Any(), event, gomock.Any()).DoAndReturn(func(ctx context.Context, e *models.OutboxEvent, payload []byte) (int64, error) {
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(payload, &body))
+		require.Equal(t, models.EventNewsDeleted, body["type"])
+		require.Equal(t, map[string]interface{}{"id": "1"}, body["data"])
+		return 2, nil
+	})
+
+	require.NoError(t, dispatcher.Publish(context.Background(), event))
+	require.Len(t, dispatcher.wake, 1)
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newTestDispatcher(nil, 10, 0)
+
+	require.Equal(t, 10*time.Second, dispatcher.backoff(1))
+	require.Equal(t, 20*time.Second, dispatcher.backoff(2))
+	require.Equal(t, 40*time.Second, dispatcher.backoff(3))
+	require.Equal(t, 60*time.Second, dispatcher.backoff(4))
+	require.Equal(t, 60*time.Second, dispatcher.backoff(30))
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/webhooks"
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/Dostonlv/task-del/pkg/tracing"
+	"github.com/Dostonlv/task-del/pkg/utils"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const secretBytes = 32
+
+// webhooks use case
+type webhooksUC struct {
+	cfg          *config.Config
+	webhooksRepo webhooks.Repository
+	dispatcher   *Dispatcher
+	logger       logger.Logger
+}
+
+// NewWebhooksUseCase webhooks use case constructor
+func NewWebhooksUseCase(cfg *config.Config, webhooksRepo webhooks.Repository, dispatcher *Dispatcher, logger logger.Logger) webhooks.UseCase {
+	return &webhooksUC{cfg: cfg, webhooksRepo: webhooksRepo, dispatcher: dispatcher, logger: logger}
+}
+
+// Create webhook, secret is generated when not provided and returned only once
+func (u *webhooksUC) Create(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	ctx, span := tracing.StartSpan(ctx, "webhooksUC.Create")
+	defer span.End()
+
+	if webhook.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, errors.Wrap(err, "webhooksUC.Create.generateSecret")
+		}
+		webhook.Secret = secret
+	}
+	webhook.Active = true
+
+	createdWebhook, err := u.webhooksRepo.Create(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.FromContext(ctx).Infof("Webhook created, ID: %s, URL: %s", createdWebhook.ID, createdWebhook.URL)
+	return createdWebhook, nil
+}
+
+// Update webhook url, events and active flag, reactivation resets failures counter
+func (u *webhooksUC) Update(ctx context.Context, webhook *models.Webhook) (*models.Webhook, error) {
+	ctx, span := tracing.StartSpan(ctx, "webhooksUC.Update")
+	defer span.End()
+
+	updatedWebhook, err := u.webhooksRepo.Update(ctx, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	u.logger.FromContext(ctx).Infof("Webhook updated, ID: %s, Active: %t", updatedWebhook.ID, updatedWebhook.Active)
+	return withoutSecret(updatedWebhook), nil
+}
+
+// Delete webhook
+func (u *webhooksUC) Delete(ctx context.Context, webhookID uuid.UUID) error {
+	ctx, span := tracing.StartSpan(ctx, "webhooksUC.Delete")
+	defer span.End()
+
+	if err := u.webhooksRepo.Delete(ctx, webhookID); err != nil {
+		return err
+	}
+
+	u.logger.FromContext(ctx).Infof("Webhook deleted, ID: %s", webhookID)
+	return nil
+}
+
+// GetByID webhook
+func (u *webhooksUC) GetByID(ctx context.Context, webhookID uuid.UUID) (*models.Webhook, error) {
+	ctx, span := tracing.StartSpan(ctx, "webhooksUC.GetByID")
+	defer span.End()
+
+	webhook, err := u.webhooksRepo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return withoutSecret(webhook), nil
+}
+
+// GetAll webhooks
+func (u *webhooksUC) GetAll(ctx context.Context, query *utils.PaginationQuery) (*models.WebhooksList, error) {
+	ctx, span := tracing.StartSpan(ctx, "webhooksUC.GetAll")
+	defer span.End()
+
+	webhooksList, err := u.webhooksRepo.GetAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooksList.Webhooks {
+		withoutSecret(webhook)
+	}
+
+	return webhooksList, nil
+}
+
+// GetDeliveries delivery log of webhook
+func (u *webhooksUC) GetDeliveries(ctx context.Context, webhookID uuid.UUID, query *utils.PaginationQuery) (*models.WebhookDeliveriesList, error) {
+	ctx, span := tracing.StartSpan(ctx, "webhooksUC.GetDeliveries")
+	defer span.End()
+
+	if _, err := u.webhooksRepo.GetByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	return u.webhooksRepo.GetDeliveries(ctx, webhookID, query)
+}
+
+// Redeliver schedule new delivery of the same event payload
+func (u *webhooksUC) Redeliver(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	ctx, span := tracing.StartSpan(ctx, "webhooksUC.Redeliver")
+	defer span.End()
+
+	delivery, err := u.webhooksRepo.GetDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := u.webhooksRepo.GetByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.Active {
+		return nil, appErrors.Wrap(errWebhookDisabled, appErrors.CodeWebhookDisabled, "webhook is disabled, activate it to redeliver")
+	}
+
+	redelivery, err := u.webhooksRepo.CreateDelivery(ctx, &models.WebhookDelivery{
+		WebhookID:     delivery.WebhookID,
+		EventID:       delivery.EventID,
+		Event:         delivery.Event,
+		Payload:       delivery.Payload,
+		NextAttemptAt: time.Now(),
+		RedeliveryOf:  &delivery.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.dispatcher.Wake()
+
+	u.logger.FromContext(ctx).Infof("Webhook delivery scheduled again, DeliveryID: %s, RedeliveryID: %s", deliveryID, redelivery.ID)
+	return redelivery, nil
+}
+
+func withoutSecret(webhook *models.Webhook) *models.Webhook {
+	webhook.Secret = ""
+	return webhook
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/Dostonlv/task-del/internal/models"
+	"github.com/Dostonlv/task-del/internal/webhooks/mock"
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooksUC_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{}
+	mockRepo := mock.NewMockRepository(ctrl)
+	webhooksUC := NewWebhooksUseCase(cfg, mockRepo, NewDispatcher(cfg, mockRepo, logger.NewApiLogger(nil)), logger.NewApiLogger(nil))
+
+	// secret is generated and webhook starts active
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, w *models.Webhook) (*models.Webhook, error) {
+		require.Len(t, w.Secret, 2*secretBytes)
+		require.True(t, w.Active)
+		return w, nil
+	})
+
+	webhook, err := webhooksUC.Create(context.Background(), &models.Webhook{URL: "http://localhost/hook", Events: models.Tags{models.EventNewsCreated}})
+	require.NoError(t, err)
+	require.NotEmpty(t, webhook.Secret)
+}
+
+func TestWebhooksUC_Redeliver(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{}
+	mockRepo := mock.NewMockRepository(ctrl)
+	dispatcher := NewDispatcher(cfg, mockRepo, logger.NewApiLogger(nil))
+	webhooksUC := NewWebhooksUseCase(cfg, mockRepo, dispatcher, logger.NewApiLogger(nil))
+
+	delivery := &models.WebhookDelivery{ID: uuid.New(), WebhookID: uuid.New(), EventID: uuid.New(), Event: models.EventBlogCreated, Payload: []byte(`{}`)}
+
+	// new delivery of the same event wakes dispatcher
+	t.Run("Redeliver", func(t *testing.T) {
+		mockRepo.EXPECT().GetDeliveryByID(gomock.Any(), delivery.ID).Return(delivery, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), delivery.WebhookID).Return(&models.Webhook{ID: delivery.WebhookID, Active: true}, nil)
+		mockRepo.EXPECT().CreateDelivery(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, d *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+			require.Equal(t, delivery.EventID, d.EventID)
+			require.Equal(t, &delivery.ID, d.RedeliveryOf)
+			d.ID = uuid.New()
+			return d, nil
+		})
+
+		redelivery, err := webhooksUC.Redeliver(context.Background(), delivery.ID)
+		require.NoError(t, err)
+		require.NotEqual(t, delivery.ID, redelivery.ID)
+		require.Len(t, dispatcher.wake, 1)
+	})
+
+	// disabled webhook is not redelivered
+	t.Run("Redeliver Disabled", func(t *testing.T) {
+		mockRepo.EXPECT().GetDeliveryByID(gomock.Any(), delivery.ID).Return(delivery, nil)
+		mockRepo.EXPECT().GetByID(gomock.Any(), delivery.WebhookID).Return(&models.Webhook{ID: delivery.WebhookID}, nil)
+
+		redelivery, err := webhooksUC.Redeliver(context.Background(), delivery.ID)
+		require.Nil(t, redelivery)
+		require.Equal(t, http.StatusBadRequest, httpErrors.ParseErrors(err).Status())
+	})
+}
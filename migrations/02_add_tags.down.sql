@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS blogs_tags_idx;
+DROP INDEX IF EXISTS news_tags_idx;
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS tags;
+ALTER TABLE news DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,5 @@
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE news ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS blogs_tags_idx ON blogs USING GIN (tags);
+CREATE INDEX IF NOT EXISTS news_tags_idx ON news USING GIN (tags);
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS webhooks
+(
+    id              UUID                        PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    url             VARCHAR(2048)               NOT NULL        CHECK (url <> ''),
+    secret          VARCHAR(128)                NOT NULL        CHECK (secret <> ''),
+    events          TEXT[]                      NOT NULL        DEFAULT '{}',
+    active          BOOLEAN                     NOT NULL        DEFAULT TRUE,
+    failure_count   INTEGER                     NOT NULL        DEFAULT 0,
+    disabled_at     TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhooks_events_idx ON webhooks USING GIN (events) WHERE active;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id              UUID                        PRIMARY KEY     DEFAULT uuid_generate_v4(),
+    webhook_id      UUID                        NOT NULL        REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id        UUID                        NOT NULL,
+    event           VARCHAR(64)                 NOT NULL,
+    payload         JSONB                       NOT NULL,
+    status          VARCHAR(16)                 NOT NULL        DEFAULT 'pending',
+    attempts        INTEGER                     NOT NULL        DEFAULT 0,
+    response_status INTEGER,
+    error           TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP,
+    delivered_at    TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_idx ON webhook_deliveries (webhook_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
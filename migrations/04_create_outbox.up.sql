@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS outbox
+(
+    id              BIGSERIAL                   PRIMARY KEY,
+    event_id        UUID                        NOT NULL        UNIQUE,
+    aggregate_type  VARCHAR(32)                 NOT NULL,
+    aggregate_id    UUID                        NOT NULL,
+    event_type      VARCHAR(64)                 NOT NULL,
+    payload         JSONB                       NOT NULL,
+    attempts        INTEGER                     NOT NULL        DEFAULT 0,
+    last_error      TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP,
+    processed_at    TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (id) WHERE processed_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_pending_aggregate_idx ON outbox (aggregate_id, id) WHERE processed_at IS NULL;
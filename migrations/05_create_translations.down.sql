@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS news_translations;
+DROP TABLE IF EXISTS blog_translations;
+
+ALTER TABLE news DROP COLUMN IF EXISTS revision;
+ALTER TABLE blogs DROP COLUMN IF EXISTS revision;
@@ -0,0 +1,24 @@
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE news ADD COLUMN IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS blog_translations
+(
+    blog_id         UUID                        NOT NULL        REFERENCES blogs (id) ON DELETE CASCADE,
+    locale          VARCHAR(16)                 NOT NULL        CHECK (locale <> ''),
+    title           VARCHAR(255)                NOT NULL        CHECK (title <> ''),
+    content         VARCHAR(512)                NOT NULL        CHECK (content <> ''),
+    source_revision INTEGER                     NOT NULL,
+    updated_at      TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blog_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS news_translations
+(
+    news_id         UUID                        NOT NULL        REFERENCES news (id) ON DELETE CASCADE,
+    locale          VARCHAR(16)                 NOT NULL        CHECK (locale <> ''),
+    title           VARCHAR(255)                NOT NULL        CHECK (title <> ''),
+    content         VARCHAR(512)                NOT NULL        CHECK (content <> ''),
+    source_revision INTEGER                     NOT NULL,
+    updated_at      TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (news_id, locale)
+);
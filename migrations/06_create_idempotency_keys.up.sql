@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    key             TEXT                        PRIMARY KEY,
+    fingerprint     VARCHAR(64)                 NOT NULL,
+    completed       BOOLEAN                     NOT NULL        DEFAULT FALSE,
+    status          INTEGER                     NOT NULL        DEFAULT 0,
+    header          JSONB                       NOT NULL        DEFAULT '{}',
+    body            BYTEA,
+    created_at      TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP,
+    expires_at      TIMESTAMP WITH TIME ZONE    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS audit_log
+(
+    id              BIGSERIAL                   PRIMARY KEY,
+    actor           VARCHAR(64)                 NOT NULL,
+    ip              VARCHAR(64)                 NOT NULL        DEFAULT '',
+    request_id      VARCHAR(64)                 NOT NULL        DEFAULT '',
+    action          VARCHAR(16)                 NOT NULL,
+    entity          VARCHAR(32)                 NOT NULL,
+    entity_id       UUID                        NOT NULL,
+    before          JSONB,
+    after           JSONB,
+    created_at      TIMESTAMP WITH TIME ZONE    NOT NULL        DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx ON audit_log (entity, entity_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+
+-- audit log is append-only, rows can not be changed or removed
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE PROCEDURE audit_log_append_only();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE PROCEDURE audit_log_append_only();
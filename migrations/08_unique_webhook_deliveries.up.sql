@@ -0,0 +1,10 @@
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS redelivery_of UUID;
+
+-- relay retries an event until every sink succeeds, keep one delivery per webhook and event, manual redeliveries excepted
+DELETE FROM webhook_deliveries d
+USING webhook_deliveries k
+WHERE d.webhook_id = k.webhook_id AND d.event_id = k.event_id
+    AND d.redelivery_of IS NULL AND k.redelivery_of IS NULL
+    AND (d.created_at, d.id) > (k.created_at, k.id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_event_idx ON webhook_deliveries (webhook_id, event_id) WHERE redelivery_of IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS outbox_failed_idx;
+DROP INDEX IF EXISTS outbox_pending_idx;
+DROP INDEX IF EXISTS outbox_pending_aggregate_idx;
+ALTER TABLE outbox DROP COLUMN IF EXISTS failed_at;
+ALTER TABLE outbox DROP COLUMN IF EXISTS next_attempt_at;
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (id) WHERE processed_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_pending_aggregate_idx ON outbox (aggregate_id, id) WHERE processed_at IS NULL;
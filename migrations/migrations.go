@@ -0,0 +1,9 @@
+// Package migrations embeds SQL migrations into the binary
+package migrations
+
+import "embed"
+
+// FS up and down migrations named <version>_<name>.<up|down>.sql
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,90 @@
+package appErrors
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Code stable machine readable error code, clients may rely on codes, messages may change
+type Code string
+
+// Error catalogue, delivery layers map every code to a status
+const (
+	CodeInternal             Code = "INTERNAL"
+	CodeBadRequest           Code = "BAD_REQUEST"
+	CodeValidation           Code = "VALIDATION_FAILED"
+	CodeInvalidID            Code = "INVALID_ID"
+	CodeNotFound             Code = "NOT_FOUND"
+	CodeAlreadyExists        Code = "ALREADY_EXISTS"
+	CodeUnauthorized         Code = "UNAUTHORIZED"
+	CodeForbidden            Code = "FORBIDDEN"
+	CodeTimeout              Code = "REQUEST_TIMEOUT"
+	CodeNotAcceptable        Code = "NOT_ACCEPTABLE"
+	CodeUnsupportedMediaType Code = "UNSUPPORTED_MEDIA_TYPE"
+	CodeTooManyRequests      Code = "TOO_MANY_REQUESTS"
+	CodeUnavailable          Code = "UNAVAILABLE"
+
+	CodeContentNotFound     Code = "CONTENT_NOT_FOUND"
+	CodeTranslationNotFound Code = "TRANSLATION_NOT_FOUND"
+	CodeUnsupportedLocale   Code = "UNSUPPORTED_LOCALE"
+	CodeSourceLocale        Code = "SOURCE_LOCALE"
+	CodeWebhookNotFound     Code = "WEBHOOK_NOT_FOUND"
+	CodeDeliveryNotFound    Code = "DELIVERY_NOT_FOUND"
+	CodeWebhookDisabled     Code = "WEBHOOK_DISABLED"
+
+	CodeIdempotencyKeyReused     Code = "IDEMPOTENCY_KEY_REUSED"
+	CodeIdempotencyKeyInProgress Code = "IDEMPOTENCY_KEY_IN_PROGRESS"
+)
+
+// Error domain error with catalogue code, Message is safe to show to clients, cause is not
+type Error struct {
+	Code    Code
+	Message string
+	cause   error
+}
+
+// New error of code with client message
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Newf error of code with formatted client message
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap error of code caused by err, errors.Is and errors.As see through it
+func Wrap(err error, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, cause: err}
+}
+
+// Error message with cause
+func (e *Error) Error() string {
+	if e.cause == nil {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Code, e.Message, e.cause)
+}
+
+// Unwrap cause
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// As first catalogue error in chain of err
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// CodeOf code of first catalogue error in chain of err, CodeInternal for other errors
+func CodeOf(err error) Code {
+	if appErr, ok := As(err); ok {
+		return appErr.Code
+	}
+	return CodeInternal
+}
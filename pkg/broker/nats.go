@@ -0,0 +1,54 @@
+package broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dostonlv/task-del/config"
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+)
+
+// NatsPublisher publish messages to NATS JetStream, message ID lets the stream drop duplicates of redelivered events
+type NatsPublisher struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	timeout time.Duration
+}
+
+// NewNatsPublisher connect to NATS server
+func NewNatsPublisher(cfg *config.Config) (*NatsPublisher, error) {
+	conn, err := nats.Connect(cfg.Outbox.Broker.URL, nats.Name(cfg.Tracing.ServiceName), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, errors.Wrap(err, "broker.NewNatsPublisher.Connect")
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "broker.NewNatsPublisher.JetStream")
+	}
+
+	timeout := cfg.Outbox.Broker.Timeout * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return &NatsPublisher{conn: conn, js: js, timeout: timeout}, nil
+}
+
+// Publish message and wait for stream acknowledgement
+func (p *NatsPublisher) Publish(ctx context.Context, subject string, msgID string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	if _, err := p.js.Publish(subject, data, nats.MsgId(msgID), nats.Context(ctx)); err != nil {
+		return errors.Wrap(err, "NatsPublisher.Publish")
+	}
+	return nil
+}
+
+// Close drain pending messages and close connection
+func (p *NatsPublisher) Close(ctx context.Context) error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,196 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotAcceptable none of types in Accept header is registered
+	ErrNotAcceptable = errors.New("not acceptable")
+	// ErrUnsupportedMediaType request Content-Type is not registered
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// Codec converts JSON documents to and from another format, so every format shares JSON field names,
+// omitted fields and custom JSON marshalers of models
+type Codec interface {
+	// MediaTypes accepted media types, the first one is used as response Content-Type
+	MediaTypes() []string
+	// FromJSON convert JSON document into codec format
+	FromJSON(doc []byte) ([]byte, error)
+	// ToJSON convert body in codec format into JSON document
+	ToJSON(body []byte) ([]byte, error)
+}
+
+// Registry codecs selected by Accept and Content-Type headers
+type Registry struct {
+	codecs      []Codec
+	byMediaType map[string]Codec
+}
+
+// NewRegistry registry constructor, the first codec is used when client accepts any type or sends no Content-Type
+func NewRegistry(codecs ...Codec) *Registry {
+	r := &Registry{byMediaType: make(map[string]Codec)}
+	for _, codec := range codecs {
+		r.Register(codec)
+	}
+	return r
+}
+
+// Register add codec, its media types replace ones registered before
+func (r *Registry) Register(codec Codec) {
+	r.codecs = append(r.codecs, codec)
+	for _, mediaType := range codec.MediaTypes() {
+		r.byMediaType[mediaType] = codec
+	}
+}
+
+// Default codec used when client has no preference
+func (r *Registry) Default() Codec {
+	return r.codecs[0]
+}
+
+// Negotiate pick codec for Accept header by quality and order, wildcards match the default codec first
+func (r *Registry) Negotiate(accept string) (Codec, error) {
+	if strings.TrimSpace(accept) == "" {
+		return r.Default(), nil
+	}
+
+	for _, mediaRange := range parseAccept(accept) {
+		switch {
+		case mediaRange == "*/*":
+			return r.Default(), nil
+		case strings.HasSuffix(mediaRange, "/*"):
+			prefix := strings.TrimSuffix(mediaRange, "*")
+			for _, codec := range r.codecs {
+				for _, mediaType := range codec.MediaTypes() {
+					if strings.HasPrefix(mediaType, prefix) {
+						return codec, nil
+					}
+				}
+			}
+		default:
+			if codec, ok := r.byMediaType[mediaRange]; ok {
+				return codec, nil
+			}
+		}
+	}
+
+	return nil, errors.Wrapf(ErrNotAcceptable, "accept %q, supported %s", accept, strings.Join(r.mediaTypes(), ", "))
+}
+
+// ForContentType codec of request body, default codec when Content-Type is empty
+func (r *Registry) ForContentType(contentType string) (Codec, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return r.Default(), nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errors.Wrapf(ErrUnsupportedMediaType, "content type %q", contentType)
+	}
+	codec, ok := r.byMediaType[mediaType]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnsupportedMediaType, "content type %q, supported %s", contentType, strings.Join(r.mediaTypes(), ", "))
+	}
+
+	return codec, nil
+}
+
+// Marshal encode value as JSON and convert it into codec format
+func Marshal(codec Codec, v interface{}) ([]byte, error) {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "codec.Marshal.json")
+	}
+	return codec.FromJSON(doc)
+}
+
+// Accepts media type is listed in Accept header with non zero quality, wildcards do not count
+func Accepts(accept, mediaType string) bool {
+	for _, mediaRange := range parseAccept(accept) {
+		if mediaRange == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Registry) mediaTypes() []string {
+	mediaTypes := make([]string, 0, len(r.codecs))
+	for _, codec := range r.codecs {
+		mediaTypes = append(mediaTypes, codec.MediaTypes()[0])
+	}
+	return mediaTypes
+}
+
+// parseAccept media ranges ordered by quality, ranges with q=0 are dropped
+func parseAccept(accept string) []string {
+	type mediaRange struct {
+		name    string
+		quality float64
+	}
+
+	ranges := make([]mediaRange, 0)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			ranges = append(ranges, mediaRange{name: mediaType, quality: quality})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	names := make([]string, len(ranges))
+	for i, r := range ranges {
+		names[i] = r.name
+	}
+	return names
+}
+
+// decodeJSON JSON document into generic value, integral numbers become int64
+func decodeJSON(doc []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return convertNumbers(v), nil
+}
+
+func convertNumbers(v interface{}) interface{} {
+	switch value := v.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range value {
+			value[key] = convertNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = convertNumbers(item)
+		}
+	}
+	return v
+}
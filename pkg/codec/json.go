@@ -0,0 +1,21 @@
+package codec
+
+// jsonCodec JSON documents are passed through as is
+type jsonCodec struct{}
+
+// JSON application/json codec
+func JSON() Codec {
+	return jsonCodec{}
+}
+
+func (jsonCodec) MediaTypes() []string {
+	return []string{"application/json"}
+}
+
+func (jsonCodec) FromJSON(doc []byte) ([]byte, error) {
+	return doc, nil
+}
+
+func (jsonCodec) ToJSON(body []byte) ([]byte, error) {
+	return body, nil
+}
@@ -0,0 +1,36 @@
+package codec
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// msgpackCodec MessagePack maps with JSON field names, times and IDs are strings as in JSON
+type msgpackCodec struct{}
+
+// MessagePack application/msgpack codec
+func MessagePack() Codec {
+	return msgpackCodec{}
+}
+
+func (msgpackCodec) MediaTypes() []string {
+	return []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"}
+}
+
+func (msgpackCodec) FromJSON(doc []byte) ([]byte, error) {
+	v, err := decodeJSON(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "msgpackCodec.FromJSON")
+	}
+	return msgpack.Marshal(v)
+}
+
+func (msgpackCodec) ToJSON(body []byte) ([]byte, error) {
+	var v interface{}
+	if err := msgpack.Unmarshal(body, &v); err != nil {
+		return nil, errors.Wrap(err, "msgpackCodec.ToJSON")
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,176 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	xmlRoot = "response"
+	xmlItem = "item"
+)
+
+// xmlCodec XML documents with JSON field names as elements under <response>, array values are <item> elements.
+// Decoded scalar values are strings, empty elements are null.
+type xmlCodec struct{}
+
+// XML application/xml codec
+func XML() Codec {
+	return xmlCodec{}
+}
+
+func (xmlCodec) MediaTypes() []string {
+	return []string{"application/xml", "text/xml"}
+}
+
+func (xmlCodec) FromJSON(doc []byte) ([]byte, error) {
+	v, err := decodeJSON(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "xmlCodec.FromJSON")
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	if err := encodeXML(encoder, xmlRoot, v); err != nil {
+		return nil, errors.Wrap(err, "xmlCodec.FromJSON")
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, errors.Wrap(err, "xmlCodec.FromJSON")
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeXML(encoder *xml.Encoder, name string, v interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch value := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := encodeXML(encoder, key, value[key]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if err := encodeXML(encoder, xmlItem, item); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := encoder.EncodeToken(xml.CharData(fmt.Sprint(value))); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+// xmlNode parsed element
+type xmlNode struct {
+	name     string
+	text     strings.Builder
+	children []*xmlNode
+}
+
+func (xmlCodec) ToJSON(body []byte) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+
+	var (
+		root  *xmlNode
+		stack []*xmlNode
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "xmlCodec.ToJSON")
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			} else if root == nil {
+				root = node
+			} else {
+				return nil, errors.New("xmlCodec.ToJSON: more than one root element")
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("xmlCodec.ToJSON: no root element")
+	}
+
+	return json.Marshal(root.value())
+}
+
+// value element as generic value: object when it has named children, array when all children are <item>
+func (n *xmlNode) value() interface{} {
+	if len(n.children) == 0 {
+		text := strings.TrimSpace(n.text.String())
+		if text == "" {
+			return nil
+		}
+		return text
+	}
+
+	isArray := true
+	for _, child := range n.children {
+		if child.name != xmlItem {
+			isArray = false
+			break
+		}
+	}
+	if isArray {
+		items := make([]interface{}, len(n.children))
+		for i, child := range n.children {
+			items[i] = child.value()
+		}
+		return items
+	}
+
+	fields := make(map[string]interface{}, len(n.children))
+	repeated := make(map[string]bool)
+	for _, child := range n.children {
+		existing, ok := fields[child.name]
+		switch {
+		case !ok:
+			fields[child.name] = child.value()
+		case repeated[child.name]:
+			fields[child.name] = append(existing.([]interface{}), child.value())
+		default:
+			// repeated elements are collected into array
+			fields[child.name] = []interface{}{existing, child.value()}
+			repeated[child.name] = true
+		}
+	}
+	return fields
+}
@@ -0,0 +1,36 @@
+package codec
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlCodec YAML documents with JSON field names
+type yamlCodec struct{}
+
+// YAML application/yaml codec
+func YAML() Codec {
+	return yamlCodec{}
+}
+
+func (yamlCodec) MediaTypes() []string {
+	return []string{"application/yaml", "application/x-yaml", "text/yaml"}
+}
+
+func (yamlCodec) FromJSON(doc []byte) ([]byte, error) {
+	v, err := decodeJSON(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "yamlCodec.FromJSON")
+	}
+	return yaml.Marshal(v)
+}
+
+func (yamlCodec) ToJSON(body []byte) ([]byte, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(body, &v); err != nil {
+		return nil, errors.Wrap(err, "yamlCodec.ToJSON")
+	}
+	return json.Marshal(v)
+}
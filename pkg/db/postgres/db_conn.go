@@ -5,6 +5,7 @@ import (
 	"github.com/Dostonlv/task-del/config"
 	"time"
 
+	"github.com/jackc/pgx"
 	_ "github.com/jackc/pgx/stdlib" // pgx driver
 	"github.com/jmoiron/sqlx"
 )
@@ -18,15 +19,7 @@ const (
 
 // Return new Postgresql db instance
 func NewPsqlDB(c *config.Config) (*sqlx.DB, error) {
-	dataSourceName := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=require password=%s",
-		c.Postgres.PostgresqlHost,
-		c.Postgres.PostgresqlPort,
-		c.Postgres.PostgresqlUser,
-		c.Postgres.PostgresqlDbname,
-		c.Postgres.PostgresqlPassword,
-	)
-
-	db, err := sqlx.Connect(c.Postgres.PgDriver, dataSourceName)
+	db, err := sqlx.Connect(c.Postgres.PgDriver, dataSourceName(c))
 	if err != nil {
 		return nil, err
 	}
@@ -41,3 +34,23 @@ func NewPsqlDB(c *config.Config) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+// NewListenConn open dedicated connection for LISTEN, notifications are not delivered to pooled connections
+func NewListenConn(c *config.Config) (*pgx.Conn, error) {
+	connConfig, err := pgx.ParseDSN(dataSourceName(c))
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.Connect(connConfig)
+}
+
+func dataSourceName(c *config.Config) string {
+	return fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=require password=%s",
+		c.Postgres.PostgresqlHost,
+		c.Postgres.PostgresqlPort,
+		c.Postgres.PostgresqlUser,
+		c.Postgres.PostgresqlDbname,
+		c.Postgres.PostgresqlPassword,
+	)
+}
@@ -0,0 +1,88 @@
+package grpcErrors
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain domain of ErrorInfo details
+const ErrorDomain = "task-del"
+
+// Map http status code to grpc code
+func MapHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// Error response, translates error with httpErrors rules into grpc status error.
+// Validation errors carry BadRequest field violations and ErrorInfo of every failed rule,
+// messages are localized by accept-language metadata.
+func ErrorResponse(ctx context.Context, err error) error {
+	restErr := httpErrors.ParseErrors(err)
+	code := MapHTTPStatus(restErr.Status())
+
+	msg := restErr.Error()
+	var fields []httpErrors.FieldError
+	if re, ok := restErr.(httpErrors.RestError); ok {
+		msg, fields = re.ErrError, re.ErrFields
+	}
+	// do not leak internal error details to clients
+	if code == codes.Internal {
+		msg = httpErrors.InternalServerError.Error()
+	}
+
+	st := status.New(code, msg)
+	if len(fields) == 0 {
+		return st.Err()
+	}
+
+	fields = httpErrors.LocalizeFieldErrors(fields, acceptLanguage(ctx))
+	badRequest := &errdetails.BadRequest{}
+	details := []protoadapt.MessageV1{badRequest}
+	for _, field := range fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field.Field,
+			Description: field.Message,
+		})
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   field.Rule,
+			Domain:   ErrorDomain,
+			Metadata: map[string]string{"field": field.Field, "param": field.Param},
+		})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func acceptLanguage(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return strings.Join(md.Get("accept-language"), ",")
+}
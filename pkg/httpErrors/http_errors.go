@@ -8,6 +8,12 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/Dostonlv/task-del/pkg/codec"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx"
+	"github.com/labstack/echo/v4"
 )
 
 const (
@@ -19,6 +25,7 @@ const (
 	ErrUnauthorized       = "Unauthorized"
 	ErrForbidden          = "Forbidden"
 	ErrBadQueryParams     = "Invalid query params"
+	ErrTooManyRequests    = "Too many requests"
 )
 
 var (
@@ -47,13 +54,16 @@ type RestErr interface {
 	Status() int
 	Error() string
 	Causes() interface{}
+	Code() string
 }
 
 // Rest error struct
 type RestError struct {
-	ErrStatus int         `json:"status,omitempty"`
-	ErrError  string      `json:"error,omitempty"`
-	ErrCauses interface{} `json:"-"`
+	ErrStatus int          `json:"status,omitempty"`
+	ErrError  string       `json:"error,omitempty"`
+	ErrCode   string       `json:"code,omitempty"`
+	ErrFields []FieldError `json:"fields,omitempty"`
+	ErrCauses interface{}  `json:"-"`
 }
 
 // Error  Error() interface method
@@ -71,6 +81,14 @@ func (e RestError) Causes() interface{} {
 	return e.ErrCauses
 }
 
+// Code catalogue code, derived from status when error was created without one
+func (e RestError) Code() string {
+	if e.ErrCode != "" {
+		return e.ErrCode
+	}
+	return string(statusCode(e.ErrStatus))
+}
+
 // New Rest Error
 func NewRestError(status int, err string, causes interface{}) RestErr {
 	return RestError{
@@ -144,53 +162,75 @@ func NewInternalServerError(causes interface{}) RestErr {
 	return result
 }
 
-// Parser of error string messages returns RestError
+// ParseErrors map error to RestError by its type: catalogue errors by code, then known sentinel and library errors.
+// Unknown errors are internal, their details are not shown to clients.
 func ParseErrors(err error) RestErr {
+	if appErr, ok := appErrors.As(err); ok {
+		return RestError{ErrStatus: codeStatus(appErr.Code), ErrError: appErr.Message, ErrCode: string(appErr.Code), ErrCauses: err}
+	}
+	var restErr RestError
+	if errors.As(err, &restErr) {
+		return restErr
+	}
+
+	var (
+		pgErr          pgx.PgError
+		validationErrs validator.ValidationErrors
+		syntaxErr      *json.SyntaxError
+		typeErr        *json.UnmarshalTypeError
+		echoErr        *echo.HTTPError
+	)
 	switch {
-	case errors.Is(err, sql.ErrNoRows):
-		return NewRestError(http.StatusNotFound, NotFound.Error(), err)
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, NotFound):
+		return newCodeError(appErrors.CodeNotFound, NotFound.Error(), err)
 	case errors.Is(err, context.DeadlineExceeded):
-		return NewRestError(http.StatusRequestTimeout, RequestTimeoutError.Error(), err)
-	case strings.Contains(err.Error(), "SQLSTATE"):
-		return parseSqlErrors(err)
-	case strings.Contains(err.Error(), "Field validation"):
-		return parseValidatorError(err)
-	case strings.Contains(err.Error(), "Unmarshal"):
-		return NewRestError(http.StatusBadRequest, BadRequest.Error(), err)
-	case strings.Contains(err.Error(), "UUID"):
-		return NewRestError(http.StatusBadRequest, err.Error(), err)
-	case strings.Contains(strings.ToLower(err.Error()), "cookie"):
-		return NewRestError(http.StatusUnauthorized, Unauthorized.Error(), err)
-	case strings.Contains(strings.ToLower(err.Error()), "token"):
-		return NewRestError(http.StatusUnauthorized, Unauthorized.Error(), err)
-	case strings.Contains(strings.ToLower(err.Error()), "bcrypt"):
-		return NewRestError(http.StatusBadRequest, BadRequest.Error(), err)
+		return newCodeError(appErrors.CodeTimeout, RequestTimeoutError.Error(), err)
+	case errors.Is(err, codec.ErrNotAcceptable):
+		return newCodeError(appErrors.CodeNotAcceptable, err.Error(), err)
+	case errors.Is(err, codec.ErrUnsupportedMediaType):
+		return newCodeError(appErrors.CodeUnsupportedMediaType, err.Error(), err)
+	case errors.As(err, &pgErr):
+		return parseSqlErrors(pgErr, err)
+	case errors.As(err, &validationErrs):
+		return parseValidatorError(validationErrs)
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return newCodeError(appErrors.CodeBadRequest, BadRequest.Error(), err)
+	case errors.As(err, &echoErr):
+		return NewRestError(echoErr.Code, strings.ToLower(http.StatusText(echoErr.Code)), err)
+	case errors.Is(err, Unauthorized), errors.Is(err, WrongCredentials), errors.Is(err, InvalidJWTToken),
+		errors.Is(err, InvalidJWTClaims), errors.Is(err, NoCookie), errors.Is(err, ExpiredCSRFError),
+		errors.Is(err, WrongCSRFToken), errors.Is(err, CSRFNotPresented):
+		return newCodeError(appErrors.CodeUnauthorized, Unauthorized.Error(), err)
+	case errors.Is(err, Forbidden), errors.Is(err, PermissionDenied):
+		return newCodeError(appErrors.CodeForbidden, Forbidden.Error(), err)
+	case errors.Is(err, BadRequest), errors.Is(err, BadQueryParams), errors.Is(err, NotRequiredFields):
+		return newCodeError(appErrors.CodeBadRequest, err.Error(), err)
 	default:
-		if restErr, ok := err.(RestErr); ok {
-			return restErr
-		}
 		return NewInternalServerError(err)
 	}
 }
 
-func parseSqlErrors(err error) RestErr {
-	if strings.Contains(err.Error(), "23505") {
-		return NewRestError(http.StatusBadRequest, ExistsEmailError.Error(), err)
+// parseSqlErrors constraint violations and invalid input are client errors, other database errors are internal
+func parseSqlErrors(pgErr pgx.PgError, err error) RestErr {
+	switch {
+	case pgErr.Code == "23505":
+		return newCodeError(appErrors.CodeAlreadyExists, ExistsEmailError.Error(), err)
+	case strings.HasPrefix(pgErr.Code, "22"), strings.HasPrefix(pgErr.Code, "23"):
+		return newCodeError(appErrors.CodeBadRequest, BadRequest.Error(), err)
+	default:
+		return NewInternalServerError(err)
 	}
-
-	return NewRestError(http.StatusBadRequest, BadRequest.Error(), err)
 }
 
-func parseValidatorError(err error) RestErr {
-	if strings.Contains(err.Error(), "Password") {
-		return NewRestError(http.StatusBadRequest, "Invalid password, min length 6", err)
-	}
-
-	if strings.Contains(err.Error(), "Email") {
-		return NewRestError(http.StatusBadRequest, "Invalid email", err)
+// parseValidatorError validation error listing every failed rule of request fields
+func parseValidatorError(errs validator.ValidationErrors) RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  "validation failed",
+		ErrCode:   string(appErrors.CodeValidation),
+		ErrFields: NewFieldErrors(errs),
+		ErrCauses: errs,
 	}
-
-	return NewRestError(http.StatusBadRequest, BadRequest.Error(), err)
 }
 
 // Error response
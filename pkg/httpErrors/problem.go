@@ -0,0 +1,127 @@
+package httpErrors
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+)
+
+// ProblemMediaType media type of RFC 7807 problem details
+const ProblemMediaType = "application/problem+json"
+
+// problemTypePrefix problem type URI is prefix followed by code in kebab case, e.g. urn:task-del:problem:content-not-found
+const problemTypePrefix = "urn:task-del:problem:"
+
+// problemType status and title of catalogue code
+type problemType struct {
+	status int
+	title  string
+}
+
+// catalogue HTTP mapping of error codes
+var catalogue = map[appErrors.Code]problemType{
+	appErrors.CodeInternal:             {http.StatusInternalServerError, "Internal server error"},
+	appErrors.CodeBadRequest:           {http.StatusBadRequest, "Bad request"},
+	appErrors.CodeValidation:           {http.StatusBadRequest, "Validation failed"},
+	appErrors.CodeInvalidID:            {http.StatusBadRequest, "Invalid ID"},
+	appErrors.CodeNotFound:             {http.StatusNotFound, "Not found"},
+	appErrors.CodeAlreadyExists:        {http.StatusConflict, "Already exists"},
+	appErrors.CodeUnauthorized:         {http.StatusUnauthorized, "Unauthorized"},
+	appErrors.CodeForbidden:            {http.StatusForbidden, "Forbidden"},
+	appErrors.CodeTimeout:              {http.StatusRequestTimeout, "Request timeout"},
+	appErrors.CodeNotAcceptable:        {http.StatusNotAcceptable, "Not acceptable"},
+	appErrors.CodeUnsupportedMediaType: {http.StatusUnsupportedMediaType, "Unsupported media type"},
+	appErrors.CodeTooManyRequests:      {http.StatusTooManyRequests, "Too many requests"},
+	appErrors.CodeUnavailable:          {http.StatusServiceUnavailable, "Service unavailable"},
+
+	appErrors.CodeContentNotFound:     {http.StatusNotFound, "Content not found"},
+	appErrors.CodeTranslationNotFound: {http.StatusNotFound, "Translation not found"},
+	appErrors.CodeUnsupportedLocale:   {http.StatusBadRequest, "Unsupported locale"},
+	appErrors.CodeSourceLocale:        {http.StatusBadRequest, "Source locale is not a translation"},
+	appErrors.CodeWebhookNotFound:     {http.StatusNotFound, "Webhook not found"},
+	appErrors.CodeDeliveryNotFound:    {http.StatusNotFound, "Webhook delivery not found"},
+	appErrors.CodeWebhookDisabled:     {http.StatusBadRequest, "Webhook is disabled"},
+
+	appErrors.CodeIdempotencyKeyReused:     {http.StatusConflict, "Idempotency key reused with different request"},
+	appErrors.CodeIdempotencyKeyInProgress: {http.StatusConflict, "Request with idempotency key is in progress"},
+}
+
+// Problem RFC 7807 problem details, Instance is ID of failed request
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Fields   []FieldError `json:"fields,omitempty"`
+}
+
+// NewProblem problem details of rest error, requestID becomes instance
+func NewProblem(err RestErr, requestID string) Problem {
+	code := err.Code()
+	title := http.StatusText(err.Status())
+	if problem, ok := catalogue[appErrors.Code(code)]; ok {
+		title = problem.title
+	}
+
+	detail := err.Error()
+	var fields []FieldError
+	if re, ok := err.(RestError); ok {
+		detail, fields = re.ErrError, re.ErrFields
+	}
+
+	return Problem{
+		Type:     problemTypePrefix + strings.ReplaceAll(strings.ToLower(code), "_", "-"),
+		Title:    title,
+		Status:   err.Status(),
+		Detail:   detail,
+		Instance: requestID,
+		Code:     code,
+		Fields:   fields,
+	}
+}
+
+// newCodeError rest error of catalogue code
+func newCodeError(code appErrors.Code, message string, causes interface{}) RestErr {
+	return RestError{ErrStatus: codeStatus(code), ErrError: message, ErrCode: string(code), ErrCauses: causes}
+}
+
+// codeStatus status of code, codes missing in catalogue are internal errors
+func codeStatus(code appErrors.Code) int {
+	if problem, ok := catalogue[code]; ok {
+		return problem.status
+	}
+	return http.StatusInternalServerError
+}
+
+// statusCode generic code of status, for rest errors created without code
+func statusCode(status int) appErrors.Code {
+	switch status {
+	case http.StatusBadRequest:
+		return appErrors.CodeBadRequest
+	case http.StatusUnauthorized:
+		return appErrors.CodeUnauthorized
+	case http.StatusForbidden:
+		return appErrors.CodeForbidden
+	case http.StatusNotFound:
+		return appErrors.CodeNotFound
+	case http.StatusRequestTimeout:
+		return appErrors.CodeTimeout
+	case http.StatusNotAcceptable:
+		return appErrors.CodeNotAcceptable
+	case http.StatusConflict:
+		return appErrors.CodeAlreadyExists
+	case http.StatusUnsupportedMediaType:
+		return appErrors.CodeUnsupportedMediaType
+	case http.StatusTooManyRequests:
+		return appErrors.CodeTooManyRequests
+	case http.StatusServiceUnavailable:
+		return appErrors.CodeUnavailable
+	}
+	if status >= http.StatusBadRequest && status < http.StatusInternalServerError {
+		return appErrors.CodeBadRequest
+	}
+	return appErrors.CodeInternal
+}
@@ -0,0 +1,174 @@
+package httpErrors
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage language of validation messages when client accepts none of registered ones
+const DefaultLanguage = "en"
+
+// FieldError failed validation rule of request field, Field is JSON path, e.g. tags[1]
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// defaultRule key of message used for rules without own message
+const defaultRule = ""
+
+// validationMessages message templates by language and rule, {field} and {param} are replaced
+var validationMessages = map[string]map[string]string{
+	"en": {
+		defaultRule: "{field} is invalid",
+		"required":  "{field} is required",
+		"gte":       "{field} must be at least {param} characters long",
+		"lte":       "{field} must be at most {param} characters long",
+		"min":       "{field} must contain at least {param} items",
+		"max":       "{field} must contain at most {param} items",
+		"oneof":     "{field} must be one of: {param}",
+		"url":       "{field} must be a valid URL",
+		"uuid":      "{field} must be a valid UUID",
+		"email":     "{field} must be a valid email address",
+	},
+	"ru": {
+		defaultRule: "поле {field} некорректно",
+		"required":  "поле {field} обязательно",
+		"gte":       "поле {field} должно содержать не менее {param} символов",
+		"lte":       "поле {field} должно содержать не более {param} символов",
+		"min":       "поле {field} должно содержать не менее {param} элементов",
+		"max":       "поле {field} должно содержать не более {param} элементов",
+		"oneof":     "поле {field} должно быть одним из: {param}",
+		"url":       "поле {field} должно быть корректным URL",
+		"uuid":      "поле {field} должно быть корректным UUID",
+		"email":     "поле {field} должно быть корректным email адресом",
+	},
+	"uz": {
+		defaultRule: "{field} maydoni noto'g'ri",
+		"required":  "{field} maydoni majburiy",
+		"gte":       "{field} maydoni kamida {param} ta belgidan iborat bo'lishi kerak",
+		"lte":       "{field} maydoni ko'pi bilan {param} ta belgidan iborat bo'lishi kerak",
+		"min":       "{field} maydonida kamida {param} ta element bo'lishi kerak",
+		"max":       "{field} maydonida ko'pi bilan {param} ta element bo'lishi kerak",
+		"oneof":     "{field} maydoni quyidagilardan biri bo'lishi kerak: {param}",
+		"url":       "{field} maydoni to'g'ri URL bo'lishi kerak",
+		"uuid":      "{field} maydoni to'g'ri UUID bo'lishi kerak",
+		"email":     "{field} maydoni to'g'ri email manzil bo'lishi kerak",
+	},
+}
+
+var validationMessagesMu sync.RWMutex
+
+// RegisterValidationMessages add or replace message templates of language, e.g. for custom rules
+func RegisterValidationMessages(lang string, messages map[string]string) {
+	validationMessagesMu.Lock()
+	defer validationMessagesMu.Unlock()
+
+	lang = strings.ToLower(lang)
+	if validationMessages[lang] == nil {
+		validationMessages[lang] = make(map[string]string, len(messages))
+	}
+	for rule, message := range messages {
+		validationMessages[lang][rule] = message
+	}
+}
+
+// NewFieldErrors field errors of validator errors, messages in default language
+func NewFieldErrors(errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(errs))
+	for _, fieldErr := range errs {
+		fields = append(fields, FieldError{
+			Field: fieldPath(fieldErr.Namespace()),
+			Rule:  fieldErr.Tag(),
+			Param: fieldErr.Param(),
+		})
+	}
+	return LocalizeFieldErrors(fields, DefaultLanguage)
+}
+
+// embeddedField name of embedded struct without JSON name, its fields are promoted in JSON
+const embeddedField = "@embedded"
+
+// JSONFieldName validator tag name func, fields are reported by JSON names
+func JSONFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch {
+	case name == "-":
+		return field.Name
+	case name != "":
+		return name
+	case field.Anonymous:
+		return embeddedField
+	default:
+		return field.Name
+	}
+}
+
+// fieldPath namespace without root struct and embedded structs, Blog.@embedded.tags[1] becomes tags[1]
+func fieldPath(namespace string) string {
+	segments := strings.Split(namespace, ".")
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments[1:] {
+		if segment != embeddedField {
+			path = append(path, segment)
+		}
+	}
+	return strings.Join(path, ".")
+}
+
+// LocalizeFieldErrors copy of fields with messages in best language of Accept-Language value
+func LocalizeFieldErrors(fields []FieldError, acceptLanguage string) []FieldError {
+	validationMessagesMu.RLock()
+	defer validationMessagesMu.RUnlock()
+
+	messages := validationMessages[matchLanguage(acceptLanguage)]
+	defaults := validationMessages[DefaultLanguage]
+
+	localized := make([]FieldError, len(fields))
+	for i, field := range fields {
+		template := firstNonEmpty(messages[field.Rule], defaults[field.Rule], messages[defaultRule], defaults[defaultRule])
+		field.Message = strings.NewReplacer("{field}", field.Field, "{param}", field.Param).Replace(template)
+		localized[i] = field
+	}
+	return localized
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Localize rest error with validation messages in best language of Accept-Language value
+func Localize(err RestErr, acceptLanguage string) RestErr {
+	restErr, ok := err.(RestError)
+	if !ok || len(restErr.ErrFields) == 0 {
+		return err
+	}
+	restErr.ErrFields = LocalizeFieldErrors(restErr.ErrFields, acceptLanguage)
+	return restErr
+}
+
+// matchLanguage registered language of Accept-Language value by quality, regional variants match their base language
+func matchLanguage(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return DefaultLanguage
+	}
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		if _, ok := validationMessages[base.String()]; ok {
+			return base.String()
+		}
+	}
+	return DefaultLanguage
+}
@@ -0,0 +1,114 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const defaultStopTimeout = 10 * time.Second
+
+// Component application component with start and stop hooks.
+// Start must not block, long running work should be started in a goroutine
+// and report failures with Manager.Fail. Both hooks are optional.
+type Component struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// Manager starts components in registration order and stops them in reverse order
+type Manager struct {
+	logger      logger.Logger
+	stopTimeout time.Duration
+
+	mu         sync.Mutex
+	components []Component
+	failed     chan error
+	failOnce   sync.Once
+}
+
+// NewManager lifecycle manager constructor
+func NewManager(logger logger.Logger, stopTimeout time.Duration) *Manager {
+	if stopTimeout <= 0 {
+		stopTimeout = defaultStopTimeout
+	}
+	return &Manager{logger: logger, stopTimeout: stopTimeout, failed: make(chan error, 1)}
+}
+
+// Append register component, components are started in the order they are appended
+func (m *Manager) Append(c Component) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.components = append(m.components, c)
+}
+
+// Fail report fatal runtime error of a component, triggers shutdown of all components
+func (m *Manager) Fail(name string, err error) {
+	m.failOnce.Do(func() {
+		m.failed <- errors.Wrapf(err, "component %s", name)
+	})
+}
+
+// Run start all components, block until ctx is done or a component fails, then stop all started components.
+// Returns the first start or runtime error, or the first stop error.
+func (m *Manager) Run(ctx context.Context) error {
+	m.mu.Lock()
+	components := make([]Component, len(m.components))
+	copy(components, m.components)
+	m.mu.Unlock()
+
+	started, runErr := m.start(ctx, components)
+	if runErr == nil {
+		select {
+		case <-ctx.Done():
+			m.logger.Info("Shutdown signal received")
+		case runErr = <-m.failed:
+			m.logger.Errorf("Component failed: %s", runErr)
+		}
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), m.stopTimeout)
+	defer cancel()
+
+	stopErr := m.stop(stopCtx, started)
+	if runErr != nil {
+		return runErr
+	}
+	return stopErr
+}
+
+func (m *Manager) start(ctx context.Context, components []Component) ([]Component, error) {
+	started := make([]Component, 0, len(components))
+	for _, c := range components {
+		if c.Start != nil {
+			m.logger.Infof("Starting component: %s", c.Name)
+			if err := c.Start(ctx); err != nil {
+				return started, errors.Wrapf(err, "start %s", c.Name)
+			}
+		}
+		started = append(started, c)
+	}
+	return started, nil
+}
+
+func (m *Manager) stop(ctx context.Context, started []Component) error {
+	var firstErr error
+	for i := len(started) - 1; i >= 0; i-- {
+		c := started[i]
+		if c.Stop == nil {
+			continue
+		}
+		m.logger.Infof("Stopping component: %s", c.Name)
+		if err := c.Stop(ctx); err != nil {
+			m.logger.Errorf("Stop component %s: %s", c.Name, err)
+			if firstErr == nil {
+				firstErr = errors.Wrapf(err, "stop %s", c.Name)
+			}
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,150 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder calls of fake components in order
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) add(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) all() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+// component fake component recording its start and stop, startErr fails start
+func (r *recorder) component(name string, startErr error) Component {
+	return Component{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			r.add("start " + name)
+			return startErr
+		},
+		Stop: func(ctx context.Context) error {
+			r.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestManager_Run(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Start order and reverse stop order", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{}
+		m := NewManager(logger.NewApiLogger(nil), time.Second)
+		m.Append(rec.component("postgres", nil))
+		m.Append(rec.component("worker", nil))
+		// hooks are optional
+		m.Append(Component{Name: "readiness", Stop: func(ctx context.Context) error {
+			rec.add("stop readiness")
+			return nil
+		}})
+		m.Append(rec.component("http", nil))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- m.Run(ctx) }()
+
+		require.Eventually(t, func() bool { return len(rec.all()) == 3 }, time.Second, time.Millisecond)
+		cancel()
+
+		require.NoError(t, <-done)
+		require.Equal(t, []string{
+			"start postgres", "start worker", "start http",
+			"stop http", "stop readiness", "stop worker", "stop postgres",
+		}, rec.all())
+	})
+
+	t.Run("Failed start stops started components", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{}
+		startErr := errors.New("address in use")
+		m := NewManager(logger.NewApiLogger(nil), time.Second)
+		m.Append(rec.component("postgres", nil))
+		m.Append(rec.component("worker", nil))
+		m.Append(rec.component("http", startErr))
+		m.Append(rec.component("config watcher", nil))
+
+		err := m.Run(context.Background())
+		require.ErrorIs(t, err, startErr)
+		require.Contains(t, err.Error(), "start http")
+		require.Equal(t, []string{
+			"start postgres", "start worker", "start http",
+			"stop worker", "stop postgres",
+		}, rec.all())
+	})
+
+	t.Run("Fail stops all components", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{}
+		serveErr := errors.New("listener closed")
+		m := NewManager(logger.NewApiLogger(nil), time.Second)
+		m.Append(rec.component("postgres", nil))
+		m.Append(Component{Name: "http", Start: func(ctx context.Context) error {
+			rec.add("start http")
+			go func() {
+				m.Fail("http", serveErr)
+				// only the first failure is reported
+				m.Fail("http", errors.New("second failure"))
+			}()
+			return nil
+		}})
+
+		err := m.Run(context.Background())
+		require.ErrorIs(t, err, serveErr)
+		require.Contains(t, err.Error(), "component http")
+		require.Equal(t, []string{"start postgres", "start http", "stop postgres"}, rec.all())
+	})
+
+	t.Run("Stop timeout", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{}
+		m := NewManager(logger.NewApiLogger(nil), 50*time.Millisecond)
+		m.Append(rec.component("postgres", nil))
+		m.Append(Component{Name: "http", Stop: func(ctx context.Context) error {
+			// connections are never drained, stop gives up on timeout
+			<-ctx.Done()
+			return ctx.Err()
+		}})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		start := time.Now()
+		err := m.Run(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Contains(t, err.Error(), "stop http")
+		require.Less(t, time.Since(start), time.Second)
+		// remaining components are still stopped
+		require.Equal(t, []string{"start postgres", "stop postgres"}, rec.all())
+	})
+
+	t.Run("Default stop timeout", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, defaultStopTimeout, NewManager(logger.NewApiLogger(nil), 0).stopTimeout)
+	})
+}
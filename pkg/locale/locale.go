@@ -0,0 +1,142 @@
+package locale
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Dostonlv/task-del/config"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale source locale when config does not set one
+const DefaultLocale = "en"
+
+type ctxKey struct{}
+
+// Locales supported content locales and their fallback chains
+type Locales struct {
+	def       string
+	supported []string
+	fallbacks map[string][]string
+}
+
+// NewLocales locales constructor, default locale is always supported
+func NewLocales(cfg *config.Config) *Locales {
+	l := &Locales{def: DefaultLocale, fallbacks: make(map[string][]string)}
+	if cfg == nil {
+		l.supported = []string{l.def}
+		return l
+	}
+
+	if def := Normalize(cfg.Locales.Default); def != "" {
+		l.def = def
+	}
+	l.supported = []string{l.def}
+	for _, loc := range cfg.Locales.Supported {
+		if loc = Normalize(loc); loc != "" && !l.IsSupported(loc) {
+			l.supported = append(l.supported, loc)
+		}
+	}
+	for loc, fallbacks := range cfg.Locales.Fallbacks {
+		for _, fallback := range fallbacks {
+			if fallback = Normalize(fallback); l.IsSupported(fallback) {
+				l.fallbacks[Normalize(loc)] = append(l.fallbacks[Normalize(loc)], fallback)
+			}
+		}
+	}
+
+	return l
+}
+
+// Default source locale
+func (l *Locales) Default() string {
+	return l.def
+}
+
+// Supported locales, default first
+func (l *Locales) Supported() []string {
+	return l.supported
+}
+
+// IsSupported locale is configured
+func (l *Locales) IsSupported(loc string) bool {
+	for _, supported := range l.supported {
+		if supported == loc {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate pick locale from lang param first, then from Accept-Language header by quality,
+// regional variants match their base language. Default locale when nothing matches.
+func (l *Locales) Negotiate(lang string, acceptLanguage string) string {
+	if loc, ok := l.match(lang); ok {
+		return loc
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return l.def
+	}
+	for _, tag := range tags {
+		if loc, ok := l.match(tag.String()); ok {
+			return loc
+		}
+	}
+
+	return l.def
+}
+
+// Chain locale followed by its fallbacks and default locale, each once
+func (l *Locales) Chain(loc string) []string {
+	chain := make([]string, 0, 3)
+	add := func(loc string) {
+		for _, added := range chain {
+			if added == loc {
+				return
+			}
+		}
+		chain = append(chain, loc)
+	}
+
+	if l.IsSupported(loc) {
+		add(loc)
+		for _, fallback := range l.fallbacks[loc] {
+			add(fallback)
+		}
+	}
+	add(l.def)
+
+	return chain
+}
+
+func (l *Locales) match(name string) (string, bool) {
+	loc := Normalize(name)
+	if loc == "" {
+		return "", false
+	}
+	if l.IsSupported(loc) {
+		return loc, true
+	}
+	if base, _, ok := strings.Cut(loc, "-"); ok && l.IsSupported(base) {
+		return base, true
+	}
+	return "", false
+}
+
+// Normalize lower case locale with dash separator, uz_Latn becomes uz-latn
+func Normalize(loc string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(loc), "_", "-"))
+}
+
+// WithContext store fallback chain of requested locale
+func WithContext(ctx context.Context, chain []string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, chain)
+}
+
+// FromContext fallback chain of requested locale, nil when request did not ask for localized content
+func FromContext(ctx context.Context) []string {
+	chain, _ := ctx.Value(ctxKey{}).([]string)
+	return chain
+}
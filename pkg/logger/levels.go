@@ -0,0 +1,231 @@
+package logger
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelState runtime log levels, overrides are keyed by package path or its tail, e.g. internal/webhooks or webhooks/usecase
+type LevelState struct {
+	Level     string            `json:"level"`
+	Overrides map[string]string `json:"overrides,omitempty"`
+	RevertAt  *time.Time        `json:"revertAt,omitempty"`
+}
+
+// levelsSnapshot levels read on every log entry
+type levelsSnapshot struct {
+	level     zapcore.Level
+	min       zapcore.Level
+	overrides []packageLevel
+}
+
+// packageLevel override of package, longest package first
+type packageLevel struct {
+	pkg   string
+	level zapcore.Level
+}
+
+// Levels log level and per package overrides changed at runtime, a change may be time boxed and reverted automatically
+type Levels struct {
+	snapshot atomic.Pointer[levelsSnapshot]
+
+	mu       sync.Mutex
+	state    LevelState
+	previous *LevelState
+	timer    *time.Timer
+}
+
+// NewLevels levels constructor
+func NewLevels(level zapcore.Level) *Levels {
+	l := &Levels{}
+	l.apply(LevelState{Level: level.String()})
+	return l
+}
+
+// State current levels
+func (l *Levels) State() LevelState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return copyState(l.state)
+}
+
+// Set replace levels, with positive ttl previous levels are restored after ttl, otherwise change is kept.
+// Set cancels pending revert of earlier time boxed change.
+func (l *Levels) Set(state LevelState, ttl time.Duration) error {
+	if err := validateState(state); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+
+	if ttl <= 0 {
+		l.previous = nil
+		state.RevertAt = nil
+		l.apply(state)
+		return nil
+	}
+
+	// reverting twice in a row keeps levels from before the first time boxed change
+	if l.previous == nil {
+		previous := copyState(l.state)
+		l.previous = &previous
+	}
+	revertAt := time.Now().Add(ttl)
+	state.RevertAt = &revertAt
+	l.apply(state)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if l.timer != timer || l.previous == nil {
+			return
+		}
+		l.apply(*l.previous)
+		l.previous, l.timer = nil, nil
+	})
+	l.timer = timer
+	return nil
+}
+
+// SetLevel change default level, e.g. on config reload. During time boxed change the level is restored on revert.
+func (l *Levels) SetLevel(level string) error {
+	if _, ok := loggerLevelMap[level]; !ok {
+		return errors.Errorf("unknown log level %q", level)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.previous != nil {
+		l.previous.Level = level
+		return nil
+	}
+	state := copyState(l.state)
+	state.Level = level
+	l.apply(state)
+	return nil
+}
+
+// apply store state and its snapshot, state must be valid
+func (l *Levels) apply(state LevelState) {
+	snapshot := &levelsSnapshot{level: loggerLevelMap[state.Level]}
+	snapshot.min = snapshot.level
+	for pkg, level := range state.Overrides {
+		zapLevel := loggerLevelMap[level]
+		snapshot.overrides = append(snapshot.overrides, packageLevel{pkg: strings.Trim(pkg, "/"), level: zapLevel})
+		if zapLevel < snapshot.min {
+			snapshot.min = zapLevel
+		}
+	}
+	sort.Slice(snapshot.overrides, func(i, j int) bool {
+		return len(snapshot.overrides[i].pkg) > len(snapshot.overrides[j].pkg)
+	})
+
+	l.state = copyState(state)
+	l.snapshot.Store(snapshot)
+}
+
+// Enabled entry of level may be written by some package
+func (l *Levels) Enabled(level zapcore.Level) bool {
+	return level >= l.snapshot.Load().min
+}
+
+// enabledFor entry of level logged from caller function, e.g. github.com/org/app/internal/webhooks/usecase.(*Dispatcher).run
+func (l *Levels) enabledFor(level zapcore.Level, function string) bool {
+	snapshot := l.snapshot.Load()
+	if len(snapshot.overrides) == 0 || function == "" {
+		return level >= snapshot.level
+	}
+
+	pkg := packagePath(function)
+	for _, override := range snapshot.overrides {
+		if matchPackage(pkg, override.pkg) {
+			return level >= override.level
+		}
+	}
+	return level >= snapshot.level
+}
+
+// packagePath package of fully qualified function name
+func packagePath(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
+
+// matchPackage key is package path, its tail or a parent of either
+func matchPackage(pkg, key string) bool {
+	return pkg == key ||
+		strings.HasPrefix(pkg, key+"/") ||
+		strings.HasSuffix(pkg, "/"+key) ||
+		strings.Contains(pkg, "/"+key+"/")
+}
+
+func validateState(state LevelState) error {
+	if _, ok := loggerLevelMap[state.Level]; !ok {
+		return errors.Errorf("unknown log level %q", state.Level)
+	}
+	for pkg, level := range state.Overrides {
+		if strings.Trim(pkg, "/") == "" {
+			return errors.New("override package must not be empty")
+		}
+		if _, ok := loggerLevelMap[level]; !ok {
+			return errors.Errorf("unknown log level %q of package %s", level, pkg)
+		}
+	}
+	return nil
+}
+
+func copyState(state LevelState) LevelState {
+	if state.Overrides != nil {
+		overrides := make(map[string]string, len(state.Overrides))
+		for pkg, level := range state.Overrides {
+			overrides[pkg] = level
+		}
+		state.Overrides = overrides
+	}
+	return state
+}
+
+// levelsCore core filtering entries by Levels, package overrides are checked on write when caller is known
+type levelsCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelsCore) Enabled(level zapcore.Level) bool {
+	return c.levels.Enabled(level)
+}
+
+func (c *levelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelsCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelsCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *levelsCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !c.levels.enabledFor(entry.Level, entry.Caller.Function) {
+		return nil
+	}
+	return c.Core.Write(entry, fields)
+}
@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/Dostonlv/task-del/pkg/secrets"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// bearerPattern credentials of Authorization header value
+var bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[^\s"',;]+`)
+
+// redactCore mask values of sensitive fields and key=value or key: value pairs in messages before they reach sinks
+type redactCore struct {
+	zapcore.Core
+	keys    []string
+	pattern *regexp.Regexp
+}
+
+// newRedactCore wrap core, field is sensitive when its key contains one of keys, case insensitive
+func newRedactCore(core zapcore.Core, keys []string) zapcore.Core {
+	if len(keys) == 0 {
+		return core
+	}
+
+	lower := make([]string, 0, len(keys))
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lower = append(lower, strings.ToLower(key))
+		quoted = append(quoted, regexp.QuoteMeta(key))
+	}
+	pattern := regexp.MustCompile(`(?i)([\w-]*(?:` + strings.Join(quoted, "|") + `)[\w-]*["']?\s*[:=]\s*["']?)([^\s"',;&]+)`)
+
+	return &redactCore{Core: core, keys: lower, pattern: pattern}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redactFields(fields)), keys: c.keys, pattern: c.pattern}
+}
+
+func (c *redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = c.redactMessage(entry.Message)
+	return c.Core.Write(entry, c.redactFields(fields))
+}
+
+func (c *redactCore) redactMessage(message string) string {
+	message = bearerPattern.ReplaceAllString(message, "$1 "+secrets.Redacted)
+	return c.pattern.ReplaceAllString(message, "${1}"+secrets.Redacted)
+}
+
+func (c *redactCore) redactFields(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+	for i, field := range fields {
+		switch {
+		case c.sensitive(field.Key):
+			field = zap.String(field.Key, secrets.Redacted)
+		case field.Type == zapcore.StringType:
+			field.String = c.redactMessage(field.String)
+		case field.Type == zapcore.ObjectMarshalerType, field.Type == zapcore.ArrayMarshalerType, field.Type == zapcore.ReflectType:
+			var ok bool
+			if field, ok = c.redactNested(field); !ok {
+				continue
+			}
+		default:
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]zapcore.Field(nil), fields...)
+		}
+		redacted[i] = field
+	}
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// redactNested mask sensitive keys inside objects, arrays, maps and structs, false when nothing is masked
+func (c *redactCore) redactNested(field zapcore.Field) (zapcore.Field, bool) {
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+
+	// reflected values are normalized to maps and slices the way JSON encoder would output them
+	data, err := json.Marshal(enc.Fields[field.Key])
+	if err != nil {
+		return field, false
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return field, false
+	}
+
+	value, changed := c.redactValue(value)
+	if !changed {
+		return field, false
+	}
+	return zap.Any(field.Key, value), true
+}
+
+func (c *redactCore) redactValue(value interface{}) (interface{}, bool) {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if c.sensitive(key) {
+				v[key] = secrets.Redacted
+				changed = true
+				continue
+			}
+			if redacted, ok := c.redactValue(nested); ok {
+				v[key] = redacted
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, nested := range v {
+			if redacted, ok := c.redactValue(nested); ok {
+				v[i] = redacted
+				changed = true
+			}
+		}
+	case string:
+		if redacted := c.redactMessage(v); redacted != v {
+			return redacted, true
+		}
+	}
+	return value, changed
+}
+
+func (c *redactCore) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range c.keys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
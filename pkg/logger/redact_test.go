@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/Dostonlv/task-del/pkg/secrets"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var testRedactKeys = []string{"password", "token", "authorization"}
+
+type credentials struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+func (c credentials) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("user", c.User)
+	enc.AddString("password", c.Password)
+	return nil
+}
+
+func newRedactLogger(t *testing.T) (Logger, *observer.ObservedLogs) {
+	t.Helper()
+
+	core, logs := observer.New(zap.DebugLevel)
+	return NewCoreLogger(newRedactCore(core, testRedactKeys)), logs
+}
+
+func TestRedactCore_Fields(t *testing.T) {
+	t.Parallel()
+
+	log, logs := newRedactLogger(t)
+	log.With("Authorization", "Bearer abc", "request_id", "request-id").
+		With("password", "secret-password", "refresh_token", "secret-token", "user", "admin").
+		Info("login")
+
+	require.Equal(t, map[string]interface{}{
+		"Authorization": secrets.Redacted,
+		"request_id":    "request-id",
+		"password":      secrets.Redacted,
+		"refresh_token": secrets.Redacted,
+		"user":          "admin",
+	}, logs.All()[0].ContextMap())
+}
+
+func TestRedactCore_Nested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		field zapcore.Field
+		want  interface{}
+	}{
+		{
+			name: "map",
+			field: zap.Any("request", map[string]interface{}{
+				"headers": map[string]interface{}{"Authorization": "Bearer abc", "Accept": "application/json"},
+				"body":    map[string]interface{}{"user": "admin", "password": "secret-password"},
+			}),
+			want: map[string]interface{}{
+				"headers": map[string]interface{}{"Authorization": secrets.Redacted, "Accept": "application/json"},
+				"body":    map[string]interface{}{"user": "admin", "password": secrets.Redacted},
+			},
+		},
+		{
+			name:  "struct",
+			field: zap.Any("credentials", credentials{User: "admin", Password: "secret-password"}),
+			want:  map[string]interface{}{"user": "admin", "password": secrets.Redacted},
+		},
+		{
+			name:  "object marshaler",
+			field: zap.Object("credentials", credentials{User: "admin", Password: "secret-password"}),
+			want:  map[string]interface{}{"user": "admin", "password": secrets.Redacted},
+		},
+		{
+			name: "array",
+			field: zap.Any("sessions", []map[string]string{
+				{"id": "1", "token": "secret-token"},
+			}),
+			want: []interface{}{map[string]interface{}{"id": "1", "token": secrets.Redacted}},
+		},
+		{
+			// credentials in string values are masked as in messages
+			name:  "string in map",
+			field: zap.Any("query", map[string]string{"raw": "user=admin&password=secret-password"}),
+			want:  map[string]interface{}{"raw": "user=admin&password=" + secrets.Redacted},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, logs := observer.New(zap.DebugLevel)
+			zap.New(newRedactCore(core, testRedactKeys)).Info("nested", tt.field)
+
+			require.Equal(t, tt.want, logs.All()[0].ContextMap()[tt.field.Key])
+		})
+	}
+
+	// fields without sensitive keys are passed unchanged
+	t.Run("unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		core, logs := observer.New(zap.DebugLevel)
+		field := zap.Any("credentials", map[string]int{"attempts": 3})
+		zap.New(newRedactCore(core, testRedactKeys)).Info("nested", field)
+
+		require.Equal(t, field, logs.All()[0].Context[0])
+	})
+}
+
+func TestRedactCore_Message(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		message string
+		want    string
+	}{
+		// header value is masked as key value pair after credentials were masked
+		{message: "Authorization: Bearer abc.def", want: "Authorization: " + secrets.Redacted + " " + secrets.Redacted},
+		{message: "request with bearer abc.def failed", want: "request with bearer " + secrets.Redacted + " failed"},
+		{message: `connect password="secret" user=admin`, want: `connect password="` + secrets.Redacted + `" user=admin`},
+		{message: "X-Api-Token=abc&page=1", want: "X-Api-Token=" + secrets.Redacted + "&page=1"},
+		{message: "nothing to hide", want: "nothing to hide"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.message, func(t *testing.T) {
+			t.Parallel()
+
+			log, logs := newRedactLogger(t)
+			log.Info(tt.message)
+			require.Equal(t, tt.want, logs.All()[0].Message)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newTestRotatingFile rotating file with limit in bytes instead of megabytes
+func newTestRotatingFile(t *testing.T, maxSize int64, maxAge time.Duration, maxBackups int) (*rotatingFile, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	f := &rotatingFile{path: filepath.Join(dir, "logs", "app.log"), maxSize: maxSize, maxAge: maxAge, maxBackups: maxBackups}
+	require.NoError(t, f.open())
+	t.Cleanup(func() { _ = f.Close() })
+	return f, dir
+}
+
+func backups(t *testing.T, f *rotatingFile) []string {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(filepath.Dir(f.path), "app-*.log"))
+	require.NoError(t, err)
+	sort.Strings(paths)
+	return paths
+}
+
+func write(t *testing.T, f *rotatingFile, data string) {
+	t.Helper()
+
+	// backup names have millisecond precision
+	time.Sleep(2 * time.Millisecond)
+	_, err := f.Write([]byte(data))
+	require.NoError(t, err)
+}
+
+func TestRotatingFile_Size(t *testing.T) {
+	t.Parallel()
+
+	f, _ := newTestRotatingFile(t, 10, 0, 0)
+
+	write(t, f, "first\n")
+	write(t, f, "second\n")
+	write(t, f, "third\n")
+
+	// write not fitting into file goes to new file, rotated files keep their content
+	rotated := backups(t, f)
+	require.Len(t, rotated, 2)
+	for i, want := range []string{"first\n", "second\n"} {
+		data, err := os.ReadFile(rotated[i])
+		require.NoError(t, err)
+		require.Equal(t, want, string(data))
+	}
+	data, err := os.ReadFile(f.path)
+	require.NoError(t, err)
+	require.Equal(t, "third\n", string(data))
+}
+
+func TestRotatingFile_Age(t *testing.T) {
+	t.Parallel()
+
+	f, _ := newTestRotatingFile(t, 0, time.Hour, 0)
+
+	write(t, f, "first\n")
+	write(t, f, "second\n")
+	require.Empty(t, backups(t, f))
+
+	f.opened = time.Now().Add(-2 * time.Hour)
+	write(t, f, "third\n")
+	require.Len(t, backups(t, f), 1)
+
+	data, err := os.ReadFile(f.path)
+	require.NoError(t, err)
+	require.Equal(t, "third\n", string(data))
+}
+
+func TestRotatingFile_MaxBackups(t *testing.T) {
+	t.Parallel()
+
+	f, _ := newTestRotatingFile(t, 1, 0, 2)
+	for _, line := range []string{"1\n", "2\n", "3\n", "4\n", "5\n"} {
+		write(t, f, line)
+	}
+
+	// oldest rotated files are removed
+	rotated := backups(t, f)
+	require.Len(t, rotated, 2)
+	data, err := os.ReadFile(rotated[0])
+	require.NoError(t, err)
+	require.Equal(t, "3\n", string(data))
+}
+
+func TestRotatingFile_Reopen(t *testing.T) {
+	t.Parallel()
+
+	f, _ := newTestRotatingFile(t, 10, 0, 0)
+	write(t, f, "first\n")
+	require.NoError(t, f.Close())
+
+	// size of existing file counts towards limit after restart
+	reopened := &rotatingFile{path: f.path, maxSize: 10}
+	require.NoError(t, reopened.open())
+	defer reopened.Close()
+	require.Equal(t, int64(6), reopened.size)
+
+	write(t, reopened, "second\n")
+	require.Len(t, backups(t, reopened), 1)
+}
+
+func TestRotatingFile_Core(t *testing.T) {
+	t.Parallel()
+
+	f, _ := newTestRotatingFile(t, 100, 0, 0)
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "MESSAGE"})
+	log := zap.New(zapcore.NewCore(encoder, f, zap.DebugLevel))
+
+	for i := 0; i < 5; i++ {
+		time.Sleep(2 * time.Millisecond)
+		log.Info("entry written through zap core")
+	}
+	require.NoError(t, log.Sync())
+
+	// entries are never split between files
+	for _, path := range append(backups(t, f), f.path) {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Contains(t, string(data), `{"MESSAGE":"entry written through zap core"}`)
+		require.LessOrEqual(t, len(data), 100)
+	}
+}
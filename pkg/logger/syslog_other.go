@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logger
+
+import (
+	"github.com/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+// newSyslogCore syslog is not available on this platform
+func newSyslogCore(network, address, tag string, encoder zapcore.Encoder, level zapcore.LevelEnabler) (zapcore.Core, error) {
+	return nil, errors.New("syslog sink is not supported on this platform")
+}
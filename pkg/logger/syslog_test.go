@@ -0,0 +1,64 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSyslogCore(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "MESSAGE"})
+	core, err := newSyslogCore("udp", conn.LocalAddr().String(), "task-del", encoder, zap.InfoLevel)
+	require.NoError(t, err)
+
+	// sink level is applied by sinkCore as for configured sinks
+	log := NewCoreLogger(&sinkCore{Core: core}).With("request_id", "request-id")
+	log.Debug("dropped")
+	log.Info("info")
+	log.Warn("warn")
+	log.Error("error")
+
+	// priority is user facility with severity of entry level, message is encoded entry
+	tests := []struct {
+		priority string
+		message  string
+	}{
+		{priority: "<14>", message: `{"MESSAGE":"info","request_id":"request-id"}`},
+		{priority: "<12>", message: `{"MESSAGE":"warn","request_id":"request-id"}`},
+		{priority: "<11>", message: `{"MESSAGE":"error","request_id":"request-id"}`},
+	}
+
+	buf := make([]byte, 1024)
+	for _, tt := range tests {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+
+		packet := string(buf[:n])
+		require.True(t, strings.HasPrefix(packet, tt.priority), packet)
+		require.Contains(t, packet, "task-del")
+		require.True(t, strings.HasSuffix(strings.TrimSuffix(packet, "\n"), tt.message), packet)
+	}
+}
+
+func TestSyslogCore_DialError(t *testing.T) {
+	t.Parallel()
+
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "MESSAGE"})
+	_, err := newSyslogCore("tcp", "127.0.0.1:1", "task-del", encoder, zap.InfoLevel)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "syslog.Dial")
+}
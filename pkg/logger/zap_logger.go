@@ -1,9 +1,12 @@
 package logger
 
 import (
+	"context"
 	"github.com/Dostonlv/task-del/config"
 	"os"
+	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -23,17 +26,44 @@ type Logger interface {
 	DPanicf(template string, args ...interface{})
 	Fatal(args ...interface{})
 	Fatalf(template string, args ...interface{})
+	With(fields ...interface{}) Logger
+	FromContext(ctx context.Context) Logger
+	SetLevel(level string) error
+	Levels() *Levels
+}
+
+// loggerCtxKey is a key used for the request scoped Logger in context
+type loggerCtxKey struct{}
+
+// ContextWithLogger returns copy of ctx carrying given logger
+func ContextWithLogger(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// FromContext returns request scoped logger stored in ctx or no-op logger if there is none
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(Logger); ok {
+		return l
+	}
+	return &apiLogger{sugarLogger: zap.NewNop().Sugar(), levels: NewLevels(zapcore.InfoLevel)}
 }
 
 // Logger
 type apiLogger struct {
 	cfg         *config.Config
 	sugarLogger *zap.SugaredLogger
+	levels      *Levels
 }
 
 // App Logger constructor
 func NewApiLogger(cfg *config.Config) *apiLogger {
-	return &apiLogger{cfg: cfg}
+	return &apiLogger{cfg: cfg, sugarLogger: zap.NewNop().Sugar(), levels: NewLevels(zapcore.InfoLevel)}
+}
+
+// NewCoreLogger logger writing to given core through runtime levels, e.g. zaptest observer core in tests
+func NewCoreLogger(core zapcore.Core) Logger {
+	levels := NewLevels(zapcore.DebugLevel)
+	return &apiLogger{sugarLogger: zap.New(&levelsCore{Core: core, levels: levels}).Sugar(), levels: levels}
 }
 
 // For mapping config logger to app logger levels
@@ -56,42 +86,148 @@ func (l *apiLogger) getLoggerLevel(cfg *config.Config) zapcore.Level {
 	return level
 }
 
-// Init logger
+// Init logger, entries go through runtime levels, redaction and sampling to every configured sink.
+// Sinks failing to open are reported and skipped, stderr is used when none is left.
 func (l *apiLogger) InitLogger() {
-	logLevel := l.getLoggerLevel(l.cfg)
-
-	logWriter := zapcore.AddSync(os.Stderr)
+	_ = l.levels.SetLevel(l.getLoggerLevel(l.cfg).String())
 
 	var encoderCfg zapcore.EncoderConfig
-	if l.cfg.Server.Mode == "Development" {
+	if l.cfg.Server.Mode == config.ModeDevelopment {
 		encoderCfg = zap.NewDevelopmentEncoderConfig()
 	} else {
 		encoderCfg = zap.NewProductionEncoderConfig()
 	}
-
-	var encoder zapcore.Encoder
 	encoderCfg.LevelKey = "LEVEL"
 	encoderCfg.CallerKey = "CALLER"
 	encoderCfg.TimeKey = "TIME"
 	encoderCfg.NameKey = "NAME"
 	encoderCfg.MessageKey = "MESSAGE"
 
-	if l.cfg.Logger.Encoding == "console" {
-		encoder = zapcore.NewConsoleEncoder(encoderCfg)
-	} else {
-		encoder = zapcore.NewJSONEncoder(encoderCfg)
+	sinks := l.cfg.Logger.Sinks
+	if len(sinks) == 0 {
+		sinks = []config.LogSink{{Type: "stderr"}}
 	}
 
-	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
-	core := zapcore.NewCore(encoder, logWriter, zap.NewAtomicLevelAt(logLevel))
+	var cores []zapcore.Core
+	var sinkErrs []error
+	for _, sink := range sinks {
+		core, err := newSinkCore(sink, l.cfg.Logger.Encoding, encoderCfg)
+		if err != nil {
+			sinkErrs = append(sinkErrs, errors.Wrapf(err, "log sink %s", sink.Type))
+			continue
+		}
+		cores = append(cores, core)
+	}
+	if len(cores) == 0 {
+		core, _ := newSinkCore(config.LogSink{Type: "stderr"}, l.cfg.Logger.Encoding, encoderCfg)
+		cores = append(cores, core)
+	}
+
+	var core zapcore.Core = &levelsCore{Core: newRedactCore(zapcore.NewTee(cores...), l.cfg.Logger.RedactKeys), levels: l.levels}
+	if sampling := l.cfg.Logger.Sampling; sampling.Enabled {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, sampling.Initial, sampling.Thereafter)
+	}
 	logger := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
 
 	l.sugarLogger = logger.Sugar()
+	for _, err := range sinkErrs {
+		l.sugarLogger.Error(err)
+	}
 	if err := l.sugarLogger.Sync(); err != nil {
 		l.sugarLogger.Error(err)
 	}
 }
 
+// newSinkCore core writing to sink, entries below sink level are dropped
+func newSinkCore(sink config.LogSink, encoding string, encoderCfg zapcore.EncoderConfig) (zapcore.Core, error) {
+	if sink.Encoding != "" {
+		encoding = sink.Encoding
+	}
+	var encoder zapcore.Encoder
+	if encoding == "console" {
+		encoder = zapcore.NewConsoleEncoder(encoderCfg)
+	} else {
+		encoder = zapcore.NewJSONEncoder(encoderCfg)
+	}
+
+	level := zapcore.DebugLevel
+	if sink.Level != "" {
+		level = loggerLevelMap[sink.Level]
+	}
+
+	var writer zapcore.WriteSyncer
+	switch sink.Type {
+	case "stdout":
+		writer = zapcore.Lock(consoleSyncer{os.Stdout})
+	case "stderr":
+		writer = zapcore.Lock(consoleSyncer{os.Stderr})
+	case "file":
+		file, err := newRotatingFile(sink.Path, sink.MaxSize, sink.MaxAge*time.Hour, sink.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		writer = file
+	case "syslog":
+		core, err := newSyslogCore(sink.Network, sink.Address, sink.Tag, encoder, level)
+		if err != nil {
+			return nil, err
+		}
+		return &sinkCore{Core: core}, nil
+	default:
+		return nil, errors.Errorf("unknown sink type %q", sink.Type)
+	}
+
+	return &sinkCore{Core: zapcore.NewCore(encoder, writer, level)}, nil
+}
+
+// consoleSyncer standard stream, sync fails on pipes and terminals and is skipped
+type consoleSyncer struct {
+	*os.File
+}
+
+func (consoleSyncer) Sync() error {
+	return nil
+}
+
+// sinkCore check sink level on write, outer cores add themselves to checked entry and skip level check of sinks
+type sinkCore struct {
+	zapcore.Core
+}
+
+func (c *sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sinkCore{Core: c.Core.With(fields)}
+}
+
+func (c *sinkCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !c.Enabled(entry.Level) {
+		return nil
+	}
+	return c.Core.Write(entry, fields)
+}
+
+// With returns child logger with given key-value pairs added to every entry
+func (l *apiLogger) With(fields ...interface{}) Logger {
+	return &apiLogger{cfg: l.cfg, sugarLogger: l.sugarLogger.With(fields...), levels: l.levels}
+}
+
+// SetLevel change level of logger and all loggers derived from it at runtime
+func (l *apiLogger) SetLevel(level string) error {
+	return l.levels.SetLevel(level)
+}
+
+// Levels runtime levels shared by logger and all loggers derived from it
+func (l *apiLogger) Levels() *Levels {
+	return l.levels
+}
+
+// FromContext returns request scoped logger stored in ctx or l if there is none
+func (l *apiLogger) FromContext(ctx context.Context) Logger {
+	if ctxLogger, ok := ctx.Value(loggerCtxKey{}).(Logger); ok {
+		return ctxLogger
+	}
+	return l
+}
+
 // Logger methods
 
 func (l *apiLogger) Debug(args ...interface{}) {
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger_With(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zap.DebugLevel)
+	parent := NewCoreLogger(core)
+	child := parent.With("request_id", "request-id", "route", "/v1/news")
+
+	child.Infof("child %d", 1)
+	parent.Info("parent")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	require.Equal(t, "child 1", entries[0].Message)
+	require.Equal(t, map[string]interface{}{"request_id": "request-id", "route": "/v1/news"}, entries[0].ContextMap())
+	require.Empty(t, entries[1].ContextMap())
+
+	// level change of parent applies to child
+	require.NoError(t, parent.SetLevel("error"))
+	child.Info("dropped")
+	require.Equal(t, 2, logs.Len())
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zap.DebugLevel)
+	base := NewCoreLogger(core)
+	reqLogger := base.With("request_id", "request-id")
+	ctx := ContextWithLogger(context.Background(), reqLogger)
+
+	FromContext(ctx).Info("from package")
+	base.FromContext(ctx).Info("from logger")
+	// no logger in context, package level logger drops entries and logger method falls back to itself
+	FromContext(context.Background()).Info("dropped")
+	base.FromContext(context.Background()).Info("base")
+
+	entries := logs.All()
+	require.Len(t, entries, 3)
+	require.Equal(t, "request-id", entries[0].ContextMap()["request_id"])
+	require.Equal(t, "request-id", entries[1].ContextMap()["request_id"])
+	require.Equal(t, "base", entries[2].Message)
+	require.Empty(t, entries[2].ContextMap())
+}
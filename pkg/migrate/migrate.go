@@ -0,0 +1,351 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+// lockKey advisory lock held while migrating, so replicas migrating on start do not race
+const lockKey int64 = 4201350623
+
+const createVersionTable = `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`
+
+var (
+	fileRe    = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+	invalidRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+	// ErrDirty previous migration failed half way, schema must be fixed by hand and version forced
+	ErrDirty = errors.New("database version is dirty, fix schema and force version")
+)
+
+// Migration pair of up and down scripts with the same version
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status applied version compared to available migrations
+type Status struct {
+	Version int64
+	Dirty   bool
+	Latest  int64
+	Pending []*Migration
+}
+
+// Load read migrations named <version>_<name>.<up|down>.sql from fsys root, sorted by version
+func Load(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.Wrap(err, "migrate.Load.ReadDir")
+	}
+
+	byVersion := make(map[int64]*Migration)
+	hasUp := make(map[int64]bool)
+	for _, entry := range entries {
+		m := fileRe.FindStringSubmatch(entry.Name())
+		if m == nil || entry.IsDir() {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "migrate.Load.ParseInt %s", entry.Name())
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "migrate.Load.ReadFile %s", entry.Name())
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != m[2] {
+			return nil, errors.Errorf("migrate.Load: version %d has different names %q and %q", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			migration.Up = string(body)
+			hasUp[version] = true
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if !hasUp[migration.Version] {
+			return nil, errors.Errorf("migrate.Load: version %d has no up migration", migration.Version)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Latest highest migration version, 0 when there are none
+func Latest(migrations []*Migration) int64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// Migrator applies migrations, schema version is kept in schema_migrations table compatible with golang-migrate
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []*Migration
+	logger     logger.Logger
+}
+
+// NewMigrator migrator constructor
+func NewMigrator(db *sqlx.DB, fsys fs.FS, logger logger.Logger) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, logger: logger}, nil
+}
+
+// Up apply up to steps pending migrations, all of them when steps <= 0. Returns number of applied migrations.
+func (m *Migrator) Up(ctx context.Context, steps int) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return ErrDirty
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version <= version {
+				continue
+			}
+			if steps > 0 && applied == steps {
+				break
+			}
+			if err := m.apply(ctx, conn, migration.Up, migration.Version); err != nil {
+				return errors.Wrapf(err, "up %d_%s", migration.Version, migration.Name)
+			}
+			m.logger.Infof("Migration applied, Version: %d, Name: %s", migration.Version, migration.Name)
+			applied++
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down revert steps applied migrations, newest first. Returns number of reverted migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return ErrDirty
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if migration.Version > version {
+				continue
+			}
+			var previous int64
+			if i > 0 {
+				previous = m.migrations[i-1].Version
+			}
+			if err := m.apply(ctx, conn, migration.Down, previous); err != nil {
+				return errors.Wrapf(err, "down %d_%s", migration.Version, migration.Name)
+			}
+			m.logger.Infof("Migration reverted, Version: %d, Name: %s", migration.Version, migration.Name)
+			version = previous
+			reverted++
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Force set schema version without running migrations and clear dirty flag, 0 removes version
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version != 0 && !m.exists(version) {
+		return errors.Errorf("migration version %d does not exist", version)
+	}
+
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		tx, err := conn.BeginTxx(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "Migrator.Force.BeginTxx")
+		}
+		defer tx.Rollback()
+
+		if err := setVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return errors.Wrap(err, "Migrator.Force.Commit")
+		}
+
+		m.logger.Infof("Migration version forced, Version: %d", version)
+		return nil
+	})
+}
+
+// Status applied version and pending migrations
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Migrator.Status.Connx")
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, createVersionTable); err != nil {
+		return nil, errors.Wrap(err, "Migrator.Status.createVersionTable")
+	}
+	version, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{Version: version, Dirty: dirty, Latest: Latest(m.migrations)}
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+
+	return status, nil
+}
+
+// withLock run fn on single connection holding migrations advisory lock, waits while another instance migrates
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "Migrator.withLock.Connx")
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return errors.Wrap(err, "Migrator.withLock.pg_advisory_lock")
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockKey); err != nil {
+			m.logger.Errorf("Migrator.withLock.pg_advisory_unlock: %s", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, createVersionTable); err != nil {
+		return errors.Wrap(err, "Migrator.withLock.createVersionTable")
+	}
+
+	return fn(conn)
+}
+
+// apply run migration script and store new version in one transaction, failed migration leaves schema untouched
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, script string, version int64) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "Migrator.apply.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if strings.TrimSpace(script) != "" {
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return errors.Wrap(err, "Migrator.apply.ExecContext")
+		}
+	}
+	if err := setVersion(ctx, tx, version); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "Migrator.apply.Commit")
+	}
+	return nil
+}
+
+func (m *Migrator) version(ctx context.Context, conn *sqlx.Conn) (int64, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	if err := conn.QueryRowxContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrap(err, "Migrator.version.QueryRowxContext")
+	}
+	return version, dirty, nil
+}
+
+func (m *Migrator) exists(version int64) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func setVersion(ctx context.Context, tx *sqlx.Tx, version int64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return errors.Wrap(err, "migrate.setVersion.Delete")
+	}
+	if version == 0 {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, version); err != nil {
+		return errors.Wrap(err, "migrate.setVersion.Insert")
+	}
+	return nil
+}
+
+// Create write empty up and down migration files with next version into dir, returns their paths
+func Create(dir string, name string) ([]string, error) {
+	name = strings.Trim(invalidRe.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("migration name is empty")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("%02d_%s", Latest(migrations)+1, name)
+	paths := []string{
+		filepath.Join(dir, prefix+".up.sql"),
+		filepath.Join(dir, prefix+".down.sql"),
+	}
+	for _, path := range paths {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, errors.Wrap(err, "migrate.Create.OpenFile")
+		}
+		if err := f.Close(); err != nil {
+			return nil, errors.Wrap(err, "migrate.Create.Close")
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,362 @@
+package migrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Dostonlv/task-del/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	createBlogs = `CREATE TABLE blogs (id UUID PRIMARY KEY)`
+	dropBlogs   = `DROP TABLE blogs`
+	addTags     = `ALTER TABLE blogs ADD COLUMN tags TEXT[]`
+	dropTags    = `ALTER TABLE blogs DROP COLUMN tags`
+)
+
+var testFS = fstest.MapFS{
+	"01_create_blogs.up.sql":   {Data: []byte(createBlogs)},
+	"01_create_blogs.down.sql": {Data: []byte(dropBlogs)},
+	"02_add_tags.up.sql":       {Data: []byte(addTags)},
+	"02_add_tags.down.sql":     {Data: []byte(dropTags)},
+	"migrations.go":            {Data: []byte("package migrations")},
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	migrator, err := NewMigrator(sqlx.NewDb(db, "sqlmock"), testFS, logger.NewApiLogger(nil))
+	require.NoError(t, err)
+	return migrator, mock
+}
+
+func expectLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_lock($1)`).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(createVersionTable).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_unlock($1)`).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// expectVersion zero version without dirty flag is an empty version table
+func expectVersion(mock sqlmock.Sqlmock, version int64, dirty bool) {
+	rows := sqlmock.NewRows([]string{"version", "dirty"})
+	if version != 0 || dirty {
+		rows.AddRow(version, dirty)
+	}
+	mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations LIMIT 1`).WillReturnRows(rows)
+}
+
+func expectApply(mock sqlmock.Sqlmock, script string, version int64) {
+	mock.ExpectBegin()
+	mock.ExpectExec(script).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectSetVersion(mock, version)
+	mock.ExpectCommit()
+}
+
+func expectSetVersion(mock sqlmock.Sqlmock, version int64) {
+	mock.ExpectExec(`DELETE FROM schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 1))
+	if version != 0 {
+		mock.ExpectExec(`INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`).
+			WithArgs(version).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	// files are paired by version and sorted, other files are ignored
+	t.Run("Load", func(t *testing.T) {
+		migrations, err := Load(testFS)
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		require.Equal(t, &Migration{Version: 1, Name: "create_blogs", Up: createBlogs, Down: dropBlogs}, migrations[0])
+		require.Equal(t, int64(2), Latest(migrations))
+	})
+
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		err  string
+	}{
+		{
+			name: "missing up",
+			fsys: fstest.MapFS{"01_create_blogs.down.sql": {Data: []byte(dropBlogs)}},
+			err:  "version 1 has no up migration",
+		},
+		{
+			name: "different names",
+			fsys: fstest.MapFS{
+				"01_create_blogs.up.sql": {Data: []byte(createBlogs)},
+				"01_blogs.down.sql":      {Data: []byte(dropBlogs)},
+			},
+			err: "version 1 has different names",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Load(tt.fsys)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		steps   int
+		expect  func(mock sqlmock.Sqlmock)
+		applied int
+		err     string
+	}{
+		{
+			name: "all pending",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 0, false)
+				expectApply(mock, createBlogs, 1)
+				expectApply(mock, addTags, 2)
+			},
+			applied: 2,
+		},
+		{
+			name:  "steps",
+			steps: 1,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 0, false)
+				expectApply(mock, createBlogs, 1)
+			},
+			applied: 1,
+		},
+		{
+			name: "applied are skipped",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 1, false)
+				expectApply(mock, addTags, 2)
+			},
+			applied: 1,
+		},
+		{
+			name: "up to date",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 2, false)
+			},
+		},
+		{
+			name: "dirty",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 1, true)
+			},
+			err: ErrDirty.Error(),
+		},
+		{
+			// failed script is rolled back with its version, earlier migrations stay applied
+			name: "failed migration",
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 0, false)
+				expectApply(mock, createBlogs, 1)
+				mock.ExpectBegin()
+				mock.ExpectExec(addTags).WillReturnError(errors.New(`relation "blogs" does not exist`))
+				mock.ExpectRollback()
+			},
+			applied: 1,
+			err:     "up 2_add_tags",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			migrator, mock := newTestMigrator(t)
+			expectLock(mock)
+			tt.expect(mock)
+			// lock is released on failure as well
+			expectUnlock(mock)
+
+			applied, err := migrator.Up(context.Background(), tt.steps)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.applied, applied)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		steps    int
+		expect   func(mock sqlmock.Sqlmock)
+		reverted int
+		err      string
+	}{
+		{
+			name:  "one step",
+			steps: 1,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 2, false)
+				expectApply(mock, dropTags, 1)
+			},
+			reverted: 1,
+		},
+		{
+			// reverting first migration removes version
+			name:  "all",
+			steps: 5,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 2, false)
+				expectApply(mock, dropTags, 1)
+				expectApply(mock, dropBlogs, 0)
+			},
+			reverted: 2,
+		},
+		{
+			name:  "not applied are skipped",
+			steps: 1,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 1, false)
+				expectApply(mock, dropBlogs, 0)
+			},
+			reverted: 1,
+		},
+		{
+			name:  "dirty",
+			steps: 1,
+			expect: func(mock sqlmock.Sqlmock) {
+				expectVersion(mock, 2, true)
+			},
+			err: ErrDirty.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			migrator, mock := newTestMigrator(t)
+			expectLock(mock)
+			tt.expect(mock)
+			expectUnlock(mock)
+
+			reverted, err := migrator.Down(context.Background(), tt.steps)
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.reverted, reverted)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMigrator_Lock(t *testing.T) {
+	t.Parallel()
+
+	migrator, mock := newTestMigrator(t)
+
+	// nothing runs when advisory lock can not be taken
+	mock.ExpectExec(`SELECT pg_advisory_lock($1)`).WithArgs(lockKey).WillReturnError(context.DeadlineExceeded)
+
+	applied, err := migrator.Up(context.Background(), 0)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Zero(t, applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Force(t *testing.T) {
+	t.Parallel()
+
+	// dirty flag is cleared without running migrations
+	t.Run("Force", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+		expectLock(mock)
+		mock.ExpectBegin()
+		expectSetVersion(mock, 1)
+		mock.ExpectCommit()
+		expectUnlock(mock)
+
+		require.NoError(t, migrator.Force(context.Background(), 1))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Force Unknown Version", func(t *testing.T) {
+		migrator, mock := newTestMigrator(t)
+
+		err := migrator.Force(context.Background(), 7)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "migration version 7 does not exist")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMigrator_Status(t *testing.T) {
+	t.Parallel()
+
+	migrator, mock := newTestMigrator(t)
+	mock.ExpectExec(createVersionTable).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectVersion(mock, 1, false)
+
+	status, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), status.Version)
+	require.Equal(t, int64(2), status.Latest)
+	require.False(t, status.Dirty)
+	require.Len(t, status.Pending, 1)
+	require.Equal(t, "add_tags", status.Pending[0].Name)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "01_create_blogs.up.sql"), []byte(createBlogs), 0o600))
+
+	// next version with sanitized name
+	paths, err := Create(dir, "Add Audit-Log!")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(dir, "02_add_audit_log.up.sql"),
+		filepath.Join(dir, "02_add_audit_log.down.sql"),
+	}, paths)
+	for _, path := range paths {
+		require.FileExists(t, path)
+	}
+
+	// following migration gets next version
+	paths, err = Create(dir, "add audit log")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "03_add_audit_log.up.sql"), paths[0])
+
+	_, err = Create(dir, "!!")
+	require.EqualError(t, err, "migration name is empty")
+}
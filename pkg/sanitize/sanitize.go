@@ -60,3 +60,20 @@ func sanitize(data interface{}) {
 		}
 	}
 }
+
+// Sanitize string
+func SanitizeString(s string) string {
+	return sanitizer.Sanitize(s)
+}
+
+// Sanitize each string of slice
+func SanitizeStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	res := make([]string, 0, len(s))
+	for _, v := range s {
+		res = append(res, sanitizer.Sanitize(v))
+	}
+	return res
+}
@@ -0,0 +1,36 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FileProvider secret is content of file, e.g. file:/run/secrets/db_password, trailing newline is dropped
+type FileProvider struct{}
+
+// Get read secret file
+func (FileProvider) Get(ctx context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// EnvProvider secret is value of environment variable, e.g. env:DB_PASS
+type EnvProvider struct{}
+
+// Get read environment variable, unset one is not found
+func (EnvProvider) Get(ctx context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
@@ -0,0 +1,102 @@
+package secrets
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Redacted placeholder of secret value in logs and dumps
+const Redacted = "[REDACTED]"
+
+// MinLength shortest secret not considered weak
+const MinLength = 16
+
+// ErrNotFound reference points to missing secret
+var ErrNotFound = errors.New("secret not found")
+
+// ErrNoProvider reference scheme is known but its backend is not configured, e.g. vault: without Vault address
+var ErrNoProvider = errors.New("no provider for secret reference")
+
+// referenceSchemes schemes of references, value with one of them is never taken as literal
+var referenceSchemes = []string{"file", "env", "vault"}
+
+// weakValues defaults and placeholders that must not be used outside development
+var weakValues = []string{"secret", "secretkey", "password", "changeme", "admin", "test", "default", "local-admin-token"}
+
+// Provider secret backend, ref is the reference without scheme, e.g. path of file or name of variable
+type Provider interface {
+	Get(ctx context.Context, ref string) (string, error)
+}
+
+// Resolver resolve scheme:ref references by provider registered for scheme, values without reference scheme are returned as is
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver resolver with file: and env: providers
+func NewResolver() *Resolver {
+	r := &Resolver{providers: make(map[string]Provider)}
+	r.Register("file", FileProvider{})
+	r.Register("env", EnvProvider{})
+	return r
+}
+
+// Register provider of scheme, replaces previous one
+func (r *Resolver) Register(scheme string, provider Provider) {
+	r.providers[scheme] = provider
+}
+
+// Resolve value of reference, value without registered or known reference scheme is literal.
+// Reference of known scheme without registered provider fails instead of becoming the secret.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	scheme, ref, ok := strings.Cut(value, ":")
+	if !ok {
+		return value, nil
+	}
+	provider, ok := r.providers[scheme]
+	if !ok {
+		if isReferenceScheme(scheme) {
+			return "", errors.Wrapf(ErrNoProvider, "resolve %s:%s", scheme, ref)
+		}
+		return value, nil
+	}
+
+	secret, err := provider.Get(ctx, ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolve %s:%s", scheme, ref)
+	}
+	return secret, nil
+}
+
+func isReferenceScheme(scheme string) bool {
+	for _, known := range referenceSchemes {
+		if scheme == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact hide non empty secret
+func Redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return Redacted
+}
+
+// IsWeak secret is short, a known default or a single repeated character
+func IsWeak(value string) bool {
+	if len(value) < MinLength {
+		return true
+	}
+	lower := strings.ToLower(value)
+	for _, weak := range weakValues {
+		if lower == weak {
+			return true
+		}
+	}
+	return strings.Count(value, value[:1]) == len(value)
+}
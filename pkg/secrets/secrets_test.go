@@ -0,0 +1,148 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type staticProvider map[string]string
+
+func (p staticProvider) Get(ctx context.Context, ref string) (string, error) {
+	value, ok := p[ref]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Setenv("SECRETS_TEST_TOKEN", "token-from-env")
+
+	resolver := NewResolver()
+	resolver.Register("static", staticProvider{"db": "static-secret"})
+
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		err   error
+	}{
+		{name: "literal", value: "plain-secret", want: "plain-secret"},
+		{name: "empty", value: "", want: ""},
+		{name: "colon in literal", value: "user:pass@host", want: "user:pass@host"},
+		{name: "env reference", value: "env:SECRETS_TEST_TOKEN", want: "token-from-env"},
+		{name: "unset variable", value: "env:SECRETS_TEST_UNSET", err: ErrNotFound},
+		{name: "registered provider", value: "static:db", want: "static-secret"},
+		{name: "missing secret of registered provider", value: "static:cache", err: ErrNotFound},
+		// vault reference without configured Vault must not become the secret
+		{name: "vault without provider", value: "vault:app/postgres#password", err: ErrNoProvider},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.Resolve(context.Background(), tt.value)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				require.Empty(t, got)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFileProvider_Get(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+		err  error
+	}{
+		{name: "trailing newline dropped", path: write("newline", "db-pass\n"), want: "db-pass"},
+		{name: "trailing CRLF dropped", path: write("crlf", "db-pass\r\n"), want: "db-pass"},
+		{name: "inner newline kept", path: write("multiline", "line1\nline2\n"), want: "line1\nline2"},
+		{name: "leading spaces kept", path: write("spaces", "  db-pass"), want: "  db-pass"},
+		{name: "missing file", path: filepath.Join(dir, "missing"), err: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FileProvider{}.Get(context.Background(), tt.path)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	// unreadable path is an error other than not found
+	_, err := FileProvider{}.Get(context.Background(), dir)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestEnvProvider_Get(t *testing.T) {
+	t.Setenv("SECRETS_TEST_SET", "value")
+	t.Setenv("SECRETS_TEST_EMPTY", "")
+
+	got, err := EnvProvider{}.Get(context.Background(), "SECRETS_TEST_SET")
+	require.NoError(t, err)
+	require.Equal(t, "value", got)
+
+	// set but empty variable is a value, unset one is not found
+	got, err = EnvProvider{}.Get(context.Background(), "SECRETS_TEST_EMPTY")
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	_, err = EnvProvider{}.Get(context.Background(), "SECRETS_TEST_UNSET")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestIsWeak(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		weak  bool
+	}{
+		{value: "", weak: true},
+		{value: "short", weak: true},
+		{value: "local-admin-token", weak: true},
+		{value: "LOCAL-ADMIN-TOKEN", weak: true},
+		{value: strings.Repeat("a", 32), weak: true},
+		{value: "kX9v2Qm7Lp4Rt8Zw1Nc6", weak: false},
+		{value: strings.Repeat("ab", 8), weak: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.weak, IsWeak(tt.value), "IsWeak(%q)", tt.value)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, Redact(""))
+	require.Equal(t, Redacted, Redact("kX9v2Qm7Lp4Rt8Zw1Nc6"))
+}
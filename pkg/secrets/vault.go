@@ -0,0 +1,104 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// VaultProvider HashiCorp Vault KV version 2 backend, reference is path#key inside mount, e.g. vault:app/postgres#password.
+// Any server speaking the KV v2 read API works, so `vault server -dev` or a stub serves local runs.
+type VaultProvider struct {
+	address string
+	token   string
+	mount   string
+	client  *http.Client
+
+	mu    sync.Mutex
+	cache map[string]map[string]interface{}
+}
+
+// NewVaultProvider Vault provider constructor, mount defaults to "secret"
+func NewVaultProvider(address, token, mount string, timeout time.Duration) *VaultProvider {
+	if mount == "" {
+		mount = "secret"
+	}
+	return &VaultProvider{
+		address: strings.TrimRight(address, "/"),
+		token:   token,
+		mount:   strings.Trim(mount, "/"),
+		client:  &http.Client{Timeout: timeout},
+		cache:   make(map[string]map[string]interface{}),
+	}
+}
+
+// Get read key of secret at path, secrets are read once per path
+func (p *VaultProvider) Get(ctx context.Context, ref string) (string, error) {
+	path, key, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || key == "" {
+		return "", errors.New("vault reference must be path#key")
+	}
+
+	data, err := p.read(ctx, strings.Trim(path, "/"))
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+func (p *VaultProvider) read(ctx context.Context, path string) (map[string]interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if data, ok := p.cache[path]; ok {
+		return data, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/%s/data/%s", p.address, url.PathEscape(p.mount), path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "VaultProvider.read.NewRequest")
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "VaultProvider.read.Do")
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case res.StatusCode != http.StatusOK:
+		return nil, errors.Errorf("vault responded %s", res.Status)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, errors.Wrap(err, "VaultProvider.read.Decode")
+	}
+	if body.Data.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	p.cache[path] = body.Data.Data
+	return body.Data.Data, nil
+}
@@ -0,0 +1,84 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSanitizeSQL(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "String literal",
+			query: `SELECT id FROM news WHERE title = 'secret title'`,
+			want:  `SELECT id FROM news WHERE title = ?`,
+		},
+		{
+			name:  "Escaped quote",
+			query: `UPDATE users SET password = 'it''s secret' WHERE id = $1`,
+			want:  `UPDATE users SET password = ? WHERE id = $1`,
+		},
+		{
+			name:  "Numeric literals",
+			query: `SELECT id FROM news LIMIT 10 OFFSET 2.5`,
+			want:  `SELECT id FROM news LIMIT ? OFFSET ?`,
+		},
+		{
+			name:  "Positional parameters are kept",
+			query: `SELECT id FROM news WHERE id = $1 OFFSET $2 LIMIT $3`,
+			want:  `SELECT id FROM news WHERE id = $1 OFFSET $2 LIMIT $3`,
+		},
+		{
+			name:  "Identifiers with digits are kept",
+			query: `SELECT id FROM news_v2 WHERE tags && '{go,sql}'`,
+			want:  `SELECT id FROM news_v2 WHERE tags && ?`,
+		},
+		{
+			name:  "Whitespace is collapsed",
+			query: "\n\tSELECT id\n\tFROM news\n\tWHERE  id = $1\n",
+			want:  `SELECT id FROM news WHERE id = $1`,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tc.want, SanitizeSQL(tc.query))
+		})
+	}
+}
+
+// TestStartSQLSpan uses global tracer provider, so it does not run in parallel
+func TestStartSQLSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	_, span := StartSQLSpan(context.Background(), "newsRepo.GetAll", `SELECT id FROM news WHERE title LIKE '%private%' LIMIT 10`)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "newsRepo.GetAll", spans[0].Name())
+	require.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+
+	attrs := attribute.NewSet(spans[0].Attributes()...)
+	statement, ok := attrs.Value(semconv.DBStatementKey)
+	require.True(t, ok)
+	require.Equal(t, `SELECT id FROM news WHERE title LIKE ? LIMIT ?`, statement.AsString())
+	require.NotContains(t, statement.AsString(), "private")
+}
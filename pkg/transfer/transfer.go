@@ -0,0 +1,271 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Format of exported and imported records
+type Format string
+
+// Supported formats
+const (
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+)
+
+// ListSeparator joins list values inside single CSV cell
+const ListSeparator = ";"
+
+const maxLineSize = 4 << 20
+
+// ParseFormat parse format name, NDJSON when empty
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case "", FormatNDJSON, "jsonl":
+		return FormatNDJSON, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	default:
+		return "", errors.Errorf("unsupported format %q, use ndjson or csv", name)
+	}
+}
+
+// ContentType of format
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Column CSV column, List columns hold string lists joined with ListSeparator
+type Column struct {
+	Name string
+	List bool
+}
+
+// Encoder write records one by one
+type Encoder interface {
+	Encode(record interface{}) error
+	Flush() error
+}
+
+// NewEncoder records encoder, CSV header is written before the first record
+func NewEncoder(w io.Writer, format Format, columns []Column) Encoder {
+	if format == FormatCSV {
+		return &csvEncoder{w: csv.NewWriter(w), columns: columns}
+	}
+	buffered := bufio.NewWriter(w)
+	return &ndjsonEncoder{w: buffered, encoder: json.NewEncoder(buffered)}
+}
+
+type ndjsonEncoder struct {
+	w       *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (e *ndjsonEncoder) Encode(record interface{}) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return e.w.Flush()
+}
+
+type csvEncoder struct {
+	w             *csv.Writer
+	columns       []Column
+	headerWritten bool
+}
+
+// Encode record JSON fields into columns, so CSV and NDJSON share field names
+func (e *csvEncoder) Encode(record interface{}) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return err
+	}
+
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = cell(fields[column.Name])
+	}
+	return e.w.Write(row)
+}
+
+// Flush buffered rows, empty export still gets header
+func (e *csvEncoder) Flush() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	header := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		header[i] = column.Name
+	}
+	e.headerWritten = true
+	return e.w.Write(header)
+}
+
+func cell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = cell(item)
+		}
+		return strings.Join(items, ListSeparator)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Decoder read records as JSON documents, so they go through the same sanitize and validate pipeline as API requests
+type Decoder interface {
+	// Next returns line number and JSON document of next record, io.EOF when input is exhausted.
+	// Malformed record is returned as error with its line, decoding can continue.
+	Next() (int, []byte, error)
+}
+
+// LineError malformed record
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+// NewDecoder records decoder, CSV input must start with header row of column names
+func NewDecoder(r io.Reader, format Format, columns []Column) Decoder {
+	if format == FormatCSV {
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.ReuseRecord = true
+		return &csvDecoder{r: reader, columns: columns}
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), maxLineSize)
+	return &ndjsonDecoder{scanner: scanner}
+}
+
+type ndjsonDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (d *ndjsonDecoder) Next() (int, []byte, error) {
+	for d.scanner.Scan() {
+		d.line++
+		body := bytes.TrimSpace(d.scanner.Bytes())
+		if len(body) == 0 {
+			continue
+		}
+		doc := make([]byte, len(body))
+		copy(doc, body)
+		return d.line, doc, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return d.line + 1, nil, err
+	}
+	return d.line, nil, io.EOF
+}
+
+type csvDecoder struct {
+	r       *csv.Reader
+	columns []Column
+	header  []string
+}
+
+func (d *csvDecoder) Next() (int, []byte, error) {
+	if d.header == nil {
+		header, err := d.r.Read()
+		if err != nil {
+			if err == io.EOF {
+				return 0, nil, io.EOF
+			}
+			return 1, nil, err
+		}
+		d.header = make([]string, len(header))
+		for i, name := range header {
+			d.header[i] = strings.TrimSpace(name)
+		}
+	}
+
+	row, err := d.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return 0, nil, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return parseErr.StartLine, nil, &LineError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+		return 0, nil, err
+	}
+	line, _ := d.r.FieldPos(0)
+	if len(row) != len(d.header) {
+		return line, nil, &LineError{Line: line, Err: errors.Errorf("expected %d fields, got %d", len(d.header), len(row))}
+	}
+
+	fields := make(map[string]interface{}, len(row))
+	for i, value := range row {
+		name := d.header[i]
+		if value == "" {
+			continue
+		}
+		if d.isList(name) {
+			items := make([]string, 0)
+			for _, item := range strings.Split(value, ListSeparator) {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			fields[name] = items
+			continue
+		}
+		fields[name] = value
+	}
+
+	doc, err := json.Marshal(fields)
+	if err != nil {
+		return line, nil, &LineError{Line: line, Err: err}
+	}
+	return line, doc, nil
+}
+
+func (d *csvDecoder) isList(name string) bool {
+	for _, column := range d.columns {
+		if column.Name == name {
+			return column.List
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+}
+
+var columns = []Column{{Name: "id"}, {Name: "title"}, {Name: "content"}, {Name: "tags", List: true}}
+
+func encode(t *testing.T, format Format, records ...interface{}) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := NewEncoder(&buf, format, columns)
+	for _, r := range records {
+		require.NoError(t, encoder.Encode(r))
+	}
+	require.NoError(t, encoder.Flush())
+	return buf.String()
+}
+
+// decodeAll records of input, malformed ones are returned as line errors
+func decodeAll(t *testing.T, input string, format Format) ([]record, []*LineError) {
+	t.Helper()
+
+	decoder := NewDecoder(strings.NewReader(input), format, columns)
+	records := make([]record, 0)
+	lineErrors := make([]*LineError, 0)
+	for {
+		_, doc, err := decoder.Next()
+		if err == io.EOF {
+			return records, lineErrors
+		}
+		if err != nil {
+			var lineErr *LineError
+			require.ErrorAs(t, err, &lineErr)
+			lineErrors = append(lineErrors, lineErr)
+			continue
+		}
+		var r record
+		require.NoError(t, json.Unmarshal(doc, &r))
+		records = append(records, r)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format Format
+		err    bool
+	}{
+		{name: "", format: FormatNDJSON},
+		{name: "ndjson", format: FormatNDJSON},
+		{name: "JSONL", format: FormatNDJSON},
+		{name: "CSV", format: FormatCSV},
+		{name: "xml", err: true},
+	}
+
+	for _, tt := range tests {
+		format, err := ParseFormat(tt.name)
+		if tt.err {
+			require.Error(t, err, tt.name)
+			continue
+		}
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.format, format, tt.name)
+	}
+}
+
+func TestCSVEncoder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		record interface{}
+		want   string
+	}{
+		{
+			name:   "list column",
+			record: record{ID: "1", Title: "go", Content: "text", Tags: []string{"go", "sql"}},
+			want:   "1,go,text,go;sql\n",
+		},
+		{
+			name:   "empty list",
+			record: record{ID: "1", Title: "go", Content: "text"},
+			want:   "1,go,text,\n",
+		},
+		{
+			name:   "comma quoted",
+			record: record{ID: "1", Title: "go, rust", Content: "text"},
+			want:   "1,\"go, rust\",text,\n",
+		},
+		{
+			name:   "newline quoted",
+			record: record{ID: "1", Title: "go", Content: "first\nsecond"},
+			want:   "1,go,\"first\nsecond\",\n",
+		},
+		{
+			name:   "quote escaped",
+			record: record{ID: "1", Title: `say "hi"`, Content: "text"},
+			want:   "1,\"say \"\"hi\"\"\",text,\n",
+		},
+		{
+			name:   "missing field empty",
+			record: map[string]interface{}{"id": "1", "title": "go", "revision": 3},
+			want:   "1,go,,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, "id,title,content,tags\n"+tt.want, encode(t, FormatCSV, tt.record))
+		})
+	}
+
+	// header is written for empty export too
+	require.Equal(t, "id,title,content,tags\n", encode(t, FormatCSV))
+}
+
+func TestNDJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	out := encode(t, FormatNDJSON, record{ID: "1", Title: "go"}, record{ID: "2", Title: "multi\nline"})
+	require.Equal(t, `{"id":"1","title":"go","content":"","tags":null}`+"\n"+`{"id":"2","title":"multi\nline","content":"","tags":null}`+"\n", out)
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// CSV reader turns CRLF inside quoted cells into LF, so contents use LF only
+	records := []record{
+		{ID: "1", Title: "plain", Content: "text", Tags: []string{"go"}},
+		{ID: "2", Title: "comma, quote \" and ; semicolon", Content: "first line\nsecond line\nthird", Tags: []string{"go", "sql", "rust"}},
+		{ID: "3", Title: "unicode ✓ sarlavha", Content: "", Tags: []string{}},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatNDJSON} {
+		format := format
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			input := make([]interface{}, len(records))
+			for i := range records {
+				input[i] = records[i]
+			}
+			got, lineErrors := decodeAll(t, encode(t, format, input...), format)
+			require.Empty(t, lineErrors)
+			require.Len(t, got, len(records))
+			for i, want := range records {
+				require.Equal(t, want.ID, got[i].ID)
+				require.Equal(t, want.Title, got[i].Title)
+				require.Equal(t, want.Content, got[i].Content)
+				require.ElementsMatch(t, want.Tags, got[i].Tags)
+			}
+		})
+	}
+}
+
+func TestCSVDecoder_List(t *testing.T) {
+	t.Parallel()
+
+	// list items are trimmed, empty items dropped, header names trimmed
+	got, lineErrors := decodeAll(t, "id, title ,tags\n1,go, go ; ;sql\n", FormatCSV)
+	require.Empty(t, lineErrors)
+	require.Equal(t, []record{{ID: "1", Title: "go", Tags: []string{"go", "sql"}}}, got)
+}
+
+func TestDecoder_LineErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  Format
+		input   string
+		records int
+		lines   []int
+	}{
+		{
+			name:    "csv missing field",
+			format:  FormatCSV,
+			input:   "id,title,content,tags\n1,go,text,go\n2,go\n3,go,text,go\n",
+			records: 2,
+			lines:   []int{3},
+		},
+		{
+			name:    "csv extra field",
+			format:  FormatCSV,
+			input:   "id,title,content,tags\n1,go,text,go,extra\n",
+			records: 0,
+			lines:   []int{2},
+		},
+		{
+			// multiline quoted cell shifts line numbers of following rows
+			name:    "csv after multiline cell",
+			format:  FormatCSV,
+			input:   "id,title,content,tags\n1,go,\"first\nsecond\",go\n2,go\n",
+			records: 1,
+			lines:   []int{4},
+		},
+		{
+			name:    "csv bare quote",
+			format:  FormatCSV,
+			input:   "id,title,content,tags\n1,go,text,go\n2,say \"hi\",text,go\n",
+			records: 1,
+			lines:   []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			records, lineErrors := decodeAll(t, tt.input, tt.format)
+			require.Len(t, records, tt.records)
+			lines := make([]int, len(lineErrors))
+			for i, lineErr := range lineErrors {
+				lines[i] = lineErr.Line
+				require.Contains(t, lineErr.Error(), "line ")
+			}
+			require.Equal(t, tt.lines, lines)
+		})
+	}
+}
+
+func TestNDJSONDecoder_Lines(t *testing.T) {
+	t.Parallel()
+
+	// blank lines are skipped but counted, documents are passed as is for validation
+	decoder := NewDecoder(strings.NewReader("{\"id\":\"1\"}\n\n  \n{\"id\":\"2\"}\nnot json\n"), FormatNDJSON, columns)
+
+	wantLines := []int{1, 4, 5}
+	wantDocs := []string{`{"id":"1"}`, `{"id":"2"}`, "not json"}
+	for i := range wantLines {
+		line, doc, err := decoder.Next()
+		require.NoError(t, err)
+		require.Equal(t, wantLines[i], line)
+		require.Equal(t, wantDocs[i], string(doc))
+	}
+	_, _, err := decoder.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
@@ -0,0 +1,24 @@
+package utils
+
+import "time"
+
+// Backoff delay before next attempt after given number of attempts, starts at base and doubles
+// with every attempt up to max (zero max is unbounded), non-positive base defaults to a second
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	if delay <= 0 {
+		delay = time.Second
+	}
+
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if max > 0 && delay >= max {
+			return max
+		}
+	}
+
+	if max > 0 && delay > max {
+		return max
+	}
+	return delay
+}
@@ -3,7 +3,6 @@ package utils
 import (
 	"context"
 	"encoding/json"
-	"github.com/Dostonlv/task-del/pkg/httpErrors"
 	"github.com/Dostonlv/task-del/pkg/logger"
 	"github.com/Dostonlv/task-del/pkg/sanitize"
 	"github.com/labstack/echo/v4"
@@ -34,14 +33,6 @@ func GetRequestCtx(c echo.Context) context.Context {
 	return context.WithValue(c.Request().Context(), ReqIDCtxKey{}, GetRequestID(c))
 }
 
-// Get config path for local or docker
-func GetConfigPath(configPath string) string {
-	if configPath == "docker" {
-		return "./config/config-docker"
-	}
-	return "./config/config-local"
-}
-
 // UserCtxKey is a key used for the User object in the context
 type UserCtxKey struct{}
 
@@ -52,18 +43,18 @@ func GetIPAddress(c echo.Context) string {
 
 // Error response with logging error for echo context
 func ErrResponseWithLog(ctx echo.Context, logger logger.Logger, err error) error {
-	logger.Errorf(
+	logger.FromContext(ctx.Request().Context()).Errorf(
 		"ErrResponseWithLog, RequestID: %s, IPAddress: %s, Error: %s",
 		GetRequestID(ctx),
 		GetIPAddress(ctx),
 		err,
 	)
-	return ctx.JSON(httpErrors.ErrorResponse(err))
+	return RespondError(ctx, err)
 }
 
 // Error response with logging error for echo context
 func LogResponseError(ctx echo.Context, logger logger.Logger, err error) {
-	logger.Errorf(
+	logger.FromContext(ctx.Request().Context()).Errorf(
 		"ErrResponseWithLog, RequestID: %s, IPAddress: %s, Error: %s",
 		GetRequestID(ctx),
 		GetIPAddress(ctx),
@@ -79,7 +70,7 @@ func ReadRequest(ctx echo.Context, request interface{}) error {
 	return validate.StructCtx(ctx.Request().Context(), request)
 }
 
-// Read sanitize and validate request
+// Read sanitize and validate request, body is decoded by codec of its Content-Type
 func SanitizeRequest(ctx echo.Context, request interface{}) error {
 	body, err := io.ReadAll(ctx.Request().Body)
 	if err != nil {
@@ -87,6 +78,10 @@ func SanitizeRequest(ctx echo.Context, request interface{}) error {
 	}
 	defer ctx.Request().Body.Close()
 
+	if body, err = requestJSON(ctx, body); err != nil {
+		return err
+	}
+
 	sanBody, err := sanitize.SanitizeJSON(body)
 	if err != nil {
 		return ctx.NoContent(http.StatusBadRequest)
@@ -99,6 +94,20 @@ func SanitizeRequest(ctx echo.Context, request interface{}) error {
 	return validate.StructCtx(ctx.Request().Context(), request)
 }
 
+// SanitizeJSON sanitize, decode and validate JSON document, same pipeline as SanitizeRequest for non HTTP input
+func SanitizeJSON(ctx context.Context, body []byte, request interface{}) error {
+	sanBody, err := sanitize.SanitizeJSON(body)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(sanBody, request); err != nil {
+		return err
+	}
+
+	return validate.StructCtx(ctx, request)
+}
+
 var allowedImagesContentTypes = map[string]string{
 	"image/bmp":                "bmp",
 	"image/gif":                "gif",
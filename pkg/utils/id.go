@@ -0,0 +1,15 @@
+package utils
+
+import (
+	"github.com/Dostonlv/task-del/pkg/appErrors"
+	"github.com/google/uuid"
+)
+
+// ParseID parse UUID from request, malformed ID is INVALID_ID error
+func ParseID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, appErrors.Wrap(err, appErrors.CodeInvalidID, "invalid ID, UUID expected")
+	}
+	return parsed, nil
+}
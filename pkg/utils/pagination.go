@@ -100,6 +100,17 @@ func GetPaginationFromCtx(c echo.Context) (*PaginationQuery, error) {
 	return q, nil
 }
 
+// Get pagination query struct from numeric values, zero size falls back to default size
+func NewPaginationQuery(page int, size int, orderBy string) *PaginationQuery {
+	if size <= 0 {
+		size = defaultSize
+	}
+	if page < 0 {
+		page = 0
+	}
+	return &PaginationQuery{Page: page, Size: size, OrderBy: orderBy}
+}
+
 // Get total pages int
 func GetTotalPages(totalCount int, pageSize int) int {
 	d := float64(totalCount) / float64(pageSize)
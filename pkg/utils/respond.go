@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Dostonlv/task-del/pkg/codec"
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
+	"github.com/labstack/echo/v4"
+)
+
+// codecs response encoders and request decoders selected by Accept and Content-Type, JSON is the default
+var codecs = codec.NewRegistry(codec.JSON(), codec.XML(), codec.YAML(), codec.MessagePack())
+
+// Respond encode value in format accepted by client, 406 in JSON when no registered format is acceptable
+func Respond(c echo.Context, status int, v interface{}) error {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	responseCodec, err := codecs.Negotiate(c.Request().Header.Get(echo.HeaderAccept))
+	if err != nil {
+		return c.JSON(httpErrors.ErrorResponse(err))
+	}
+
+	body, err := codec.Marshal(responseCodec, v)
+	if err != nil {
+		return err
+	}
+
+	return c.Blob(status, responseCodec.MediaTypes()[0], body)
+}
+
+// RespondError encode error as RFC 7807 problem details when client accepts application/problem+json,
+// otherwise as RestError in format accepted by client
+func RespondError(c echo.Context, err error) error {
+	restErr := httpErrors.Localize(httpErrors.ParseErrors(err), c.Request().Header.Get("Accept-Language"))
+	if !acceptsProblem(c) {
+		return Respond(c, restErr.Status(), restErr)
+	}
+
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	body, err := json.Marshal(httpErrors.NewProblem(restErr, GetRequestID(c)))
+	if err != nil {
+		return err
+	}
+	return c.Blob(restErr.Status(), httpErrors.ProblemMediaType, body)
+}
+
+// acceptsProblem client listed problem+json in Accept, q=0 declines it
+func acceptsProblem(c echo.Context) bool {
+	return codec.Accepts(c.Request().Header.Get(echo.HeaderAccept), httpErrors.ProblemMediaType)
+}
+
+// requestJSON request body converted into JSON document by codec of its Content-Type
+func requestJSON(c echo.Context, body []byte) ([]byte, error) {
+	requestCodec, err := codecs.ForContentType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return nil, err
+	}
+	doc, err := requestCodec.ToJSON(body)
+	if err != nil {
+		return nil, httpErrors.NewRestError(http.StatusBadRequest, httpErrors.BadRequest.Error(), err)
+	}
+	return doc, nil
+}
+
+// CheckCodecs fail with codec error when response format is not acceptable or request body format is unsupported,
+// so request is rejected before handler does any work
+func CheckCodecs(c echo.Context) error {
+	if _, err := codecs.Negotiate(c.Request().Header.Get(echo.HeaderAccept)); err != nil {
+		return err
+	}
+	if c.Request().ContentLength != 0 {
+		if _, err := codecs.ForContentType(c.Request().Header.Get(echo.HeaderContentType)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
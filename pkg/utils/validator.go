@@ -3,6 +3,7 @@ package utils
 import (
 	"context"
 
+	"github.com/Dostonlv/task-del/pkg/httpErrors"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -11,6 +12,7 @@ var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	validate.RegisterTagNameFunc(httpErrors.JSONFieldName)
 }
 
 // Validate struct fields
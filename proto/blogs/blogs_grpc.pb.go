@@ -0,0 +1,257 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v25.3.0
+// source: blogs/blogs.proto
+
+package blogsService
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	BlogsService_Create_FullMethodName  = "/blogsService.BlogsService/Create"
+	BlogsService_Update_FullMethodName  = "/blogsService.BlogsService/Update"
+	BlogsService_Delete_FullMethodName  = "/blogsService.BlogsService/Delete"
+	BlogsService_GetByID_FullMethodName = "/blogsService.BlogsService/GetByID"
+	BlogsService_GetAll_FullMethodName  = "/blogsService.BlogsService/GetAll"
+)
+
+// BlogsServiceClient is the client API for BlogsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BlogsServiceClient interface {
+	Create(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error)
+	Update(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
+	Delete(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error)
+	GetByID(ctx context.Context, in *GetBlogByIDRequest, opts ...grpc.CallOption) (*GetBlogByIDResponse, error)
+	GetAll(ctx context.Context, in *GetBlogsRequest, opts ...grpc.CallOption) (*GetBlogsResponse, error)
+}
+
+type blogsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlogsServiceClient(cc grpc.ClientConnInterface) BlogsServiceClient {
+	return &blogsServiceClient{cc}
+}
+
+func (c *blogsServiceClient) Create(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error) {
+	out := new(CreateBlogResponse)
+	err := c.cc.Invoke(ctx, BlogsService_Create_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogsServiceClient) Update(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	err := c.cc.Invoke(ctx, BlogsService_Update_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogsServiceClient) Delete(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error) {
+	out := new(DeleteBlogResponse)
+	err := c.cc.Invoke(ctx, BlogsService_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogsServiceClient) GetByID(ctx context.Context, in *GetBlogByIDRequest, opts ...grpc.CallOption) (*GetBlogByIDResponse, error) {
+	out := new(GetBlogByIDResponse)
+	err := c.cc.Invoke(ctx, BlogsService_GetByID_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogsServiceClient) GetAll(ctx context.Context, in *GetBlogsRequest, opts ...grpc.CallOption) (*GetBlogsResponse, error) {
+	out := new(GetBlogsResponse)
+	err := c.cc.Invoke(ctx, BlogsService_GetAll_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlogsServiceServer is the server API for BlogsService service.
+// All implementations must embed UnimplementedBlogsServiceServer
+// for forward compatibility
+type BlogsServiceServer interface {
+	Create(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error)
+	Update(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
+	Delete(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
+	GetByID(context.Context, *GetBlogByIDRequest) (*GetBlogByIDResponse, error)
+	GetAll(context.Context, *GetBlogsRequest) (*GetBlogsResponse, error)
+	mustEmbedUnimplementedBlogsServiceServer()
+}
+
+// UnimplementedBlogsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBlogsServiceServer struct {
+}
+
+func (UnimplementedBlogsServiceServer) Create(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedBlogsServiceServer) Update(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedBlogsServiceServer) Delete(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedBlogsServiceServer) GetByID(context.Context, *GetBlogByIDRequest) (*GetBlogByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByID not implemented")
+}
+func (UnimplementedBlogsServiceServer) GetAll(context.Context, *GetBlogsRequest) (*GetBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedBlogsServiceServer) mustEmbedUnimplementedBlogsServiceServer() {}
+
+// UnsafeBlogsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BlogsServiceServer will
+// result in compilation errors.
+type UnsafeBlogsServiceServer interface {
+	mustEmbedUnimplementedBlogsServiceServer()
+}
+
+func RegisterBlogsServiceServer(s grpc.ServiceRegistrar, srv BlogsServiceServer) {
+	s.RegisterService(&BlogsService_ServiceDesc, srv)
+}
+
+func _BlogsService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogsServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogsService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogsServiceServer).Create(ctx, req.(*CreateBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogsService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogsServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogsService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogsServiceServer).Update(ctx, req.(*UpdateBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogsService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogsServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogsService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogsServiceServer).Delete(ctx, req.(*DeleteBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogsService_GetByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlogByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogsServiceServer).GetByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogsService_GetByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogsServiceServer).GetByID(ctx, req.(*GetBlogByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogsService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogsServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlogsService_GetAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogsServiceServer).GetAll(ctx, req.(*GetBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BlogsService_ServiceDesc is the grpc.ServiceDesc for BlogsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BlogsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blogsService.BlogsService",
+	HandlerType: (*BlogsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _BlogsService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _BlogsService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _BlogsService_Delete_Handler,
+		},
+		{
+			MethodName: "GetByID",
+			Handler:    _BlogsService_GetByID_Handler,
+		},
+		{
+			MethodName: "GetAll",
+			Handler:    _BlogsService_GetAll_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "blogs/blogs.proto",
+}